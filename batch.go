@@ -0,0 +1,150 @@
+package bolson
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Result is the outcome of calculating one line of a batch
+type Result struct {
+	// Index is the position of the line in the input
+	Index int `json:"index"`
+
+	Bag Bag `json:"bag"`
+
+	// Err is the error calculating the line, if any
+	Err error `json:"-"`
+}
+
+// CalculateBatch calculates lines in parallel with at most workers goroutines, each one using its own copy of
+// the configuration. When workers is not positive, runtime.GOMAXPROCS is used.
+//
+// The results are returned in the order of lines, and a line failing doesnt stop the others. When ctx is
+// cancelled, the lines not calculated yet get ctx.Err() as its error
+func (b Bolson) CalculateBatch(ctx context.Context, lines []Line, workers int) []Result {
+	in := make(chan Line)
+
+	go func() {
+		defer close(in)
+
+		for _, l := range lines {
+			select {
+			case in <- l:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make([]Result, len(lines))
+	calculated := make([]bool, len(lines))
+
+	for r := range b.CalculateStream(ctx, in, workers) {
+		results[r.Index] = r
+		calculated[r.Index] = true
+	}
+
+	for i := range results {
+		if !calculated[i] {
+			results[i] = Result{Index: i, Err: ctx.Err()}
+		}
+	}
+
+	return results
+}
+
+// CalculateStream calculates the lines received from in with at most workers goroutines, sending the results
+// in the order they were received. When workers is not positive, runtime.GOMAXPROCS is used.
+//
+// At most twice workers lines are held waiting for its turn, so any amount of lines can be streamed with
+// constant memory. The returned channel is closed when in is closed and every result was sent, or when
+// ctx is cancelled
+func (b Bolson) CalculateStream(ctx context.Context, in <-chan Line, workers int) <-chan Result {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	type job struct {
+		index int
+		line  Line
+		done  chan Result
+	}
+
+	jobs := make(chan job)
+	pending := make(chan chan Result, 2*workers)
+	out := make(chan Result)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func(c Bolson) {
+			defer wg.Done()
+
+			for j := range jobs {
+				bag, err := c.CalculateLine(j.line)
+				j.done <- Result{Index: j.index, Bag: bag, Err: err}
+			}
+		}(b.clone())
+	}
+
+	// dispatcher, the order of pending is the order of the input
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+
+		for i := 0; ; i++ {
+			var (
+				l  Line
+				ok bool
+			)
+
+			select {
+			case l, ok = <-in:
+			case <-ctx.Done():
+				return
+			}
+
+			if !ok {
+				return
+			}
+
+			j := job{index: i, line: l, done: make(chan Result, 1)}
+
+			select {
+			case pending <- j.done:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case jobs <- j:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// collector
+	go func() {
+		defer close(out)
+		defer wg.Wait()
+
+		for done := range pending {
+			select {
+			case r := <-done:
+				select {
+				case out <- r:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,71 @@
+package bolson
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCalculateBatch(t *testing.T) {
+	b := planBolson()
+
+	lines := make([]Line, 0, 200)
+
+	for i := 0; i < 200; i++ {
+		lines = append(lines, Line{Value: decimal.NewFromInt(int64(100 + i)), Qty: decimal.NewFromInt(3), MaxDiscount: decimal.NewFromInt(100), From: Origin(i % 3)})
+	}
+
+	// invalid quantity, must not stop the other lines
+	lines[17].Qty = decimal.Zero
+
+	results := b.CalculateBatch(context.Background(), lines, 4)
+
+	if len(results) != len(lines) {
+		t.Logf("expected %d results, got %d", len(lines), len(results))
+		t.FailNow()
+	}
+
+	for i, r := range results {
+		if r.Index != i {
+			t.Logf("expected result %d in order, got %d", i, r.Index)
+			t.FailNow()
+		}
+
+		if i == 17 {
+			if r.Err == nil {
+				t.Log("expected error for zero quantity")
+				t.FailNow()
+			}
+
+			continue
+		}
+
+		expected, err := b.CalculateLine(lines[i])
+
+		if err != nil || r.Err != nil || !expected.WithDiscount.Brute.Equal(r.Bag.WithDiscount.Brute) {
+			t.Logf("Fail line %d --- expected %v %v --- got %v %v", i, expected, err, r.Bag, r.Err)
+			t.FailNow()
+		}
+	}
+}
+
+func TestCalculateBatchCancelled(t *testing.T) {
+	b := planBolson()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	lines := []Line{
+		{Value: decimal.NewFromInt(100), Qty: decimal.NewFromInt(1), MaxDiscount: decimal.NewFromInt(100)},
+		{Value: decimal.NewFromInt(200), Qty: decimal.NewFromInt(1), MaxDiscount: decimal.NewFromInt(100)},
+	}
+
+	for i, r := range b.CalculateBatch(ctx, lines, 2) {
+		if r.Index != i || (r.Err != nil && !errors.Is(r.Err, context.Canceled)) {
+			t.Logf("expected line %d cancelled or calculated, got %v", i, r)
+			t.FailNow()
+		}
+	}
+}
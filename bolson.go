@@ -7,15 +7,13 @@ import (
 	"fmt"
 
 	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/index"
 	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/profe-ajedrez/bolson/numeric"
 	"github.com/profe-ajedrez/bolson/tax"
 	"github.com/shopspring/decimal"
 )
 
-const (
-	bruteWDIndex = 0
-)
-
 // WithDiscountValues represents the result of operations over sales values with applied discounts
 type WithDiscountValues struct {
 	// Net is the operation subtotal value without taxes
@@ -105,6 +103,15 @@ type Bag struct {
 
 	// WithoutDiscount contains the obtained values without discount
 	WithoutDiscount WithoutDiscountValues `json:"withoutDiscount"`
+
+	// Fired contains the codes of the conditional taxes applied in the calculation
+	Fired []string `json:"fired,omitempty"`
+
+	// Rates contains the conversion factors used for the amounts registered in indexed units
+	Rates map[index.Unit]decimal.Decimal `json:"rates,omitempty"`
+
+	// Taxes contains the amounts of the taxes registered with a code
+	Taxes []TaxDetail `json:"taxes,omitempty"`
 }
 
 func (b Bag) String() string {
@@ -118,6 +125,9 @@ func (b Bag) Round(scale int32) Bag {
 	return Bag{
 		WithDiscount:    b.WithDiscount.Round(scale),
 		WithoutDiscount: b.WithoutDiscount.Round(scale),
+		Fired:           b.Fired,
+		Rates:           b.Rates,
+		Taxes:           mapDetails(b.Taxes, func(d decimal.Decimal) decimal.Decimal { return d.Round(scale) }),
 	}
 }
 
@@ -150,15 +160,17 @@ func (b Bag) Round(scale int32) Bag {
 //	 }
 type Bolson struct {
 	taxHandler      *tax.Handler
+	listTaxHandler  *tax.Handler
 	discountHandler *discount.ComputedDiscount
-	buffer          []decimal.Decimal
+	settings        *settings
 }
 
 func New() Bolson {
 	return Bolson{
 		taxHandler:      tax.NewHandler(),
+		listTaxHandler:  tax.NewHandler(),
 		discountHandler: discount.NewComputedDiscount(),
-		buffer:          make([]decimal.Decimal, 1),
+		settings:        &settings{},
 	}
 }
 
@@ -178,16 +190,32 @@ func (b Bolson) AddTax(value decimal.Decimal, mode tax.Mode, stage tax.Stage) er
 	return b.taxHandler.AddTax(value, mode, stage)
 }
 
+// AddTaxOverBase registers a tax specifying the base over which is calculated.
+//
+// Taxes registered over [tax.ListPriceBase] are calculated over the unit value without
+// discounts even in the with discount values, as some excise taxes require. Their stages are
+// computed apart from the ones of the taxes registered over [tax.DiscountedBase]
+func (b Bolson) AddTaxOverBase(value decimal.Decimal, mode tax.Mode, stage tax.Stage, base tax.Base) error {
+	switch base {
+	case tax.DiscountedBase:
+		return b.taxHandler.AddTax(value, mode, stage)
+	case tax.ListPriceBase:
+		return b.listTaxHandler.AddTax(value, mode, stage)
+	}
+
+	return tax.ErrInvalidTaxBase(base)
+}
+
 func (b Bolson) AddDiscount(value decimal.Decimal, mode discount.Mode) error {
 	return b.discountHandler.AddDiscount(value, mode)
 }
 
 func (b Bolson) Untax(taxed decimal.Decimal, qty decimal.Decimal, flow int8) (decimal.Decimal, error) {
-	return b.taxHandler.Untax(taxed, qty, flow)
+	return b.untax(taxed, qty, flow)
 }
 
 func (b Bolson) Tax(taxable decimal.Decimal, qty decimal.Decimal) (decimal.Decimal, error) {
-	return b.taxHandler.Tax(taxable, qty)
+	return b.tax(taxable, taxable, qty)
 }
 
 func (b Bolson) Discount(unitValue decimal.Decimal, qty decimal.Decimal, maxDiscount decimal.Decimal) (decimal.Decimal, decimal.Decimal, error) {
@@ -195,106 +223,118 @@ func (b Bolson) Discount(unitValue decimal.Decimal, qty decimal.Decimal, maxDisc
 }
 
 func (b Bolson) Calculate(unitValue decimal.Decimal, qty decimal.Decimal, maxDiscount decimal.Decimal) (calc Bag, err error) {
-	return b.subCalculate(unitValue, qty, maxDiscount, tax.FromUv)
+	return b.prepared(&unitValue, qty, func(p Bolson) (Bag, error) {
+		return p.calculate(unitValue, qty, maxDiscount, FromUnitValue)
+	})
 }
 
 func (b Bolson) CalculateFromBruteWD(bruteWD decimal.Decimal, qty decimal.Decimal, maxDiscount decimal.Decimal) (calc Bag, err error) {
-
-	discounted, _, err := b.discountHandler.Compute(bruteWD.Div(qty), qty, maxDiscount)
-
-	if err != nil {
-		return
-	}
-
-	brute := bruteWD.Sub(discounted)
-	b.buffer[bruteWDIndex] = bruteWD
-
-	calc, err = b.CalculateFromBrute(brute, qty, numbers.Hundred)
-
-	return
+	return b.prepared(nil, qty, func(p Bolson) (Bag, error) {
+		return p.calculate(bruteWD, qty, maxDiscount, FromBruteWD)
+	})
 }
 
 func (b Bolson) CalculateFromBrute(brute decimal.Decimal, qty decimal.Decimal, maxDiscount decimal.Decimal) (calc Bag, err error) {
+	return b.prepared(nil, qty, func(p Bolson) (Bag, error) {
+		return p.calculate(brute, qty, maxDiscount, FromBrute)
+	})
+}
 
-	//fmt.Printf("brute: %s\n", brute)
-
-	undiscounted, err := b.discountHandler.UnDiscount(brute, b.buffer[bruteWDIndex], qty)
+// calculate calculates a line from the value indicated by from with the arithmetic of [numeric.Decimal],
+// see [CalculateWith]. The tax stages keep the taxables of the line, as the handlers do when taxing
+// and untaxing brutes
+func (b Bolson) calculate(value decimal.Decimal, qty decimal.Decimal, maxDiscount decimal.Decimal, from Origin) (Bag, error) {
+	calc, err := calculateIn[decimal.Decimal](numeric.Decimal{}, b, value, qty, maxDiscount, from)
 
 	if err != nil {
-		return
+		return calc, err
 	}
 
-	//fmt.Printf("undiscounted: %s\n", undiscounted)
-
-	//fmt.Printf("sub: %s\n", sub)
+	unitValue := calc.WithoutDiscount.UnitValue
 
-	untaxedUnitary, err := b.taxHandler.Untax(undiscounted, qty, tax.FromBrute)
-
-	if err != nil {
-		return
+	if _, err = b.tax(unitValue, unitValue, qty); err != nil || from == FromUnitValue {
+		return calc, err
 	}
 
-	//fmt.Printf("untaxedUnitary: %s\n", untaxedUnitary)
-
-	calc, err = b.subCalculate(untaxedUnitary.Div(qty), qty, numbers.Hundred, tax.FromBrute)
+	_, err = b.untax(calc.WithoutDiscount.Brute, qty, tax.FromBrute)
 
-	return
+	return calc, err
 }
 
-func (b Bolson) subCalculate(unitValue decimal.Decimal, qty decimal.Decimal, maxDiscount decimal.Decimal, flow int8) (calc Bag, err error) {
-	discounted, discount, err := b.discountHandler.Compute(unitValue, qty, maxDiscount)
+// tax calculates the taxes registered over the discounted base using taxable and the
+// taxes registered over the list price base using listPrice
+func (b Bolson) tax(taxable decimal.Decimal, listPrice decimal.Decimal, qty decimal.Decimal) (decimal.Decimal, error) {
+	taxed, err := b.taxHandler.Tax(taxable, qty)
 
-	if err != nil {
-		return
+	if err != nil || b.listTaxHandler.IsZero() {
+		return taxed, err
 	}
 
-	tax, err := b.taxHandler.Tax(unitValue.Mul(numbers.Hundred.Sub(discount).Div(numbers.Hundred)), qty)
+	listTaxed, err := b.listTaxHandler.Tax(listPrice, qty)
 
 	if err != nil {
-		return
+		return numbers.Zero.Copy(), err
 	}
 
-	taxWD, err := b.taxHandler.Tax(unitValue, qty)
+	return taxed.Add(listTaxed), nil
+}
 
-	if err != nil {
-		return
+// untax removes the registered taxes of both bases from a value without discounts
+func (b Bolson) untax(taxed decimal.Decimal, qty decimal.Decimal, flow int8) (decimal.Decimal, error) {
+	if b.listTaxHandler.IsZero() {
+		return b.taxHandler.Untax(taxed, qty, flow)
 	}
 
-	calc = calculate(unitValue, qty, discounted, tax, discount, taxWD)
+	if qty.LessThanOrEqual(numbers.Zero) {
+		return numbers.Zero.Copy(), tax.ErrNegativeQty(fmt.Sprintf("untaxing %v with qty %v", taxed, qty))
+	}
 
-	calc.WithoutDiscount.UnitValue, err = b.taxHandler.Untax(calc.WithoutDiscount.Brute, qty, flow)
+	// without discounts, taxed = uv * (qty + slope) + intercept
+	slope, intercept := b.factors(qty)
+	untaxed := taxed.Sub(intercept).Div(qty.Add(slope)).Mul(qty)
 
-	if err != nil {
-		err = fmt.Errorf("after try to untax brute to recalculate uv %v", err)
-		return
-	}
+	if flow == tax.FromBrute {
+		// the stages keep the taxables obtained from the brute, as tax.Handler.Untax does
+		unitValue := untaxed.Div(qty)
 
-	calc.WithDiscount.UnitValue = calc.WithDiscount.Net.Div(qty)
-	calc.WithoutDiscount.UnitValue = calc.WithoutDiscount.Net.Div(qty)
+		for _, h := range []*tax.Handler{b.taxHandler, b.listTaxHandler} {
+			taxes, err := h.Tax(unitValue, qty)
 
-	return
-}
+			if err != nil {
+				return numbers.Zero.Copy(), err
+			}
 
-func (b Bolson) Reset() {
-	b.discountHandler.Reset()
-	b.taxHandler.Reset()
+			if _, err = h.Untax(untaxed.Add(taxes), qty, tax.FromBrute); err != nil {
+				return numbers.Zero.Copy(), err
+			}
+		}
+	}
+
+	return untaxed, nil
 }
 
-func calculate(unitValue decimal.Decimal, qty decimal.Decimal, discounted decimal.Decimal, tax decimal.Decimal, discount decimal.Decimal, taxWD decimal.Decimal) (calc Bag) {
-	netWD := unitValue.Mul(qty)
-	net := netWD.Sub(discounted)
+// factors returns the combined slope and intercept of the taxes of both bases
+func (b Bolson) factors(qty decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	slopeD, interceptD := b.taxHandler.Factors(qty)
+	slopeL, interceptL := b.listTaxHandler.Factors(qty)
 
-	calc.WithDiscount.Net = net
-	calc.WithDiscount.Brute = calc.WithDiscount.Net.Add(tax)
-	calc.WithDiscount.Tax = tax
-	calc.WithDiscount.Discount = discount
-	calc.WithDiscount.DiscountedValue = discounted
+	return slopeD.Add(slopeL), interceptD.Add(interceptL)
+}
 
-	calc.WithoutDiscount.Net = netWD
-	calc.WithoutDiscount.Brute = calc.WithoutDiscount.Net.Add(taxWD)
-	calc.WithoutDiscount.Tax = taxWD
+// clone returns a copy of b whose handlers could be modified without affecting b
+func (b Bolson) clone() Bolson {
+	c := b
+	c.taxHandler = b.taxHandler.Clone()
+	c.listTaxHandler = b.listTaxHandler.Clone()
+	c.discountHandler = b.discountHandler.Clone()
+	c.settings = b.settings.clone()
 
-	calc.WithDiscount.DiscountedValueBrute = calc.WithoutDiscount.Brute.Sub(calc.WithDiscount.Brute)
+	return c
+}
 
-	return calc
+func (b Bolson) Reset() {
+	b.discountHandler.Reset()
+	b.taxHandler.Reset()
+	b.listTaxHandler.Reset()
+	b.settings.reset()
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/numeric"
 	"github.com/profe-ajedrez/bolson/tax"
 	"github.com/shopspring/decimal"
 )
@@ -55,6 +56,51 @@ func BenchmarkBolson(b *testing.B) {
 
 }
 
+func BenchmarkPlanDecimal(b *testing.B) {
+	plan, _ := Compile[decimal.Decimal](numeric.Decimal{}, planBolson())
+
+	uv := decimal.RequireFromString("913.79")
+	qty := decimal.NewFromInt(3)
+	maxDiscount := decimal.NewFromInt(100)
+
+	var v LineValues[decimal.Decimal]
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for k := 0; k < b.N; k++ {
+		_ = plan.Calculate(uv, qty, maxDiscount, &v)
+	}
+}
+
+func BenchmarkPlanFixed(b *testing.B) {
+	plan, _ := Compile[int64](numeric.Fixed{Scale: 2}, planBolson())
+
+	var v LineValues[int64]
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for k := 0; k < b.N; k++ {
+		_ = plan.Calculate(91379, 300, 10000, &v)
+	}
+}
+
+func BenchmarkCalculate(b *testing.B) {
+	bl := planBolson()
+
+	uv := decimal.RequireFromString("913.79")
+	qty := decimal.NewFromInt(3)
+	maxDiscount := decimal.NewFromInt(100)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for k := 0; k < b.N; k++ {
+		_, _ = bl.Calculate(uv, qty, maxDiscount)
+	}
+}
+
 var testBolsonCases = []struct {
 	testCase func(b *Bolson) (Bag, error)
 	expected string
@@ -219,3 +265,87 @@ var testBolsonCases = []struct {
 		expected: `{"withDiscount":{"net":"637.9310344827586222","brute":"740.000000000000001756274132676085568","tax":"102.068965517241379556274132676085568","discount":"30.1885553573578","discountedValue":"275.8604473412657312","discountedValueBrute":"319.998118915868248187725867323914432","unitValue":"637.9310344827586222"},"withoutDiscount":{"net":"913.7914818240243534","brute":"1059.998118915868249944","tax":"146.206637091843896544","unitValue":"913.7914818240243534"}}`,
 	},
 }
+
+func TestTaxOverListPriceBase(t *testing.T) {
+	b := New()
+
+	_ = b.AddTaxOverBase(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase)
+	_ = b.AddTaxOverBase(decimal.NewFromInt(10), tax.PercentualMode, tax.OverTaxable, tax.ListPriceBase)
+	_ = b.AddDiscount(decimal.NewFromInt(10), discount.Percentual)
+
+	if err := b.AddTaxOverBase(decimal.NewFromInt(10), tax.PercentualMode, tax.OverTaxable, tax.InvalidBase); err == nil {
+		t.Log("this should be failed because an invalid base was passed to AddTaxOverBase")
+		t.FailNow()
+	}
+
+	qty := decimal.NewFromInt(10)
+	maxDiscount := decimal.NewFromInt(100)
+
+	// the VAT is calculated over 900 and the excise over 1000
+	expected := `{"withDiscount":{"net":"900","brute":"1171","tax":"271","discount":"10","discountedValue":"100","discountedValueBrute":"119","unitValue":"90"},"withoutDiscount":{"net":"1000","brute":"1290","tax":"290","unitValue":"100"}}`
+
+	calculations := []func() (Bag, error){
+		func() (Bag, error) { return b.Calculate(decimal.NewFromInt(100), qty, maxDiscount) },
+		func() (Bag, error) { return b.CalculateFromBrute(decimal.NewFromInt(1171), qty, maxDiscount) },
+		func() (Bag, error) { return b.CalculateFromBruteWD(decimal.NewFromInt(1290), qty, maxDiscount) },
+	}
+
+	for i, calculation := range calculations {
+		calc, err := calculation()
+
+		if err != nil {
+			t.Logf("Fail calculation[%d] --- %v", i, err)
+			t.FailNow()
+		}
+
+		js, _ := json.Marshal(calc.Round(6))
+
+		if string(js) != expected {
+			t.Logf("Fail calculation[%d] --- expected %v --- got %v", i, expected, string(js))
+			t.FailNow()
+		}
+	}
+}
+
+func TestAmountDiscountFromBruteWDOverListPriceBase(t *testing.T) {
+	qty := decimal.NewFromInt(2)
+	unitValue := decimal.NewFromInt(500)
+
+	// the discount of 100 is 8.4% of the brute 1190 and 7.75% of the brute 1290, but 10% of the net
+	maxDiscount := decimal.NewFromInt(9)
+
+	for _, list := range []bool{false, true} {
+		b := New()
+
+		_ = b.AddTaxOverBase(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase)
+		_ = b.AddDiscount(decimal.NewFromInt(100), discount.AmountLine)
+
+		if list {
+			_ = b.AddTaxOverBase(decimal.NewFromInt(10), tax.PercentualMode, tax.OverTaxable, tax.ListPriceBase)
+		}
+
+		expected, err := b.Calculate(unitValue, qty, decimal.NewFromInt(100))
+
+		if err != nil {
+			t.Logf("Fail list %v --- %v", list, err)
+			t.FailNow()
+		}
+
+		calc, err := b.CalculateFromBruteWD(expected.WithoutDiscount.Brute, qty, maxDiscount)
+
+		if err != nil {
+			t.Logf("Fail list %v --- %v", list, err)
+			t.FailNow()
+		}
+
+		if !calc.WithDiscount.Net.Round(6).Equal(decimal.NewFromInt(900)) || calc.Round(6).String() != expected.Round(6).String() {
+			t.Logf("Fail list %v --- expected %v --- got %v", list, expected.Round(6), calc.Round(6))
+			t.FailNow()
+		}
+
+		if !b.OverTaxables().Taxable().Round(6).Equal(unitValue) {
+			t.Logf("Fail list %v --- expected the taxable %v --- got %v", list, unitValue, b.OverTaxables().Taxable())
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package canadatax
+
+import (
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// cents are the decimals of the amounts
+const cents = 2
+
+// Stage returns the stage of r: the federal taxes are over the value, the compounded provincial taxes over
+// the value plus the federal ones, and the other provincial taxes over the value without entering the base
+// of the compounded ones
+func (r Rate) Stage() tax.Stage {
+	switch {
+	case r.Kind.federal():
+		return tax.OverTaxable
+	case r.Compound:
+		return tax.OverTax
+	}
+
+	return tax.OverTaxIgnorable
+}
+
+// Configure adds to b the components of the province as coded taxes, with the kinds as codes. The kinds
+// in exempt are not added
+func (t *Table) Configure(b bolson.Bolson, province string, exempt ...Kind) error {
+	p, err := t.Province(province)
+
+	if err != nil {
+		return err
+	}
+
+	for _, r := range p.Rates {
+		if contains(exempt, r.Kind) {
+			continue
+		}
+
+		if err := b.AddCodedTax(string(r.Kind), r.Rate, tax.PercentualMode, r.Stage(), tax.DiscountedBase); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Calculate calculates the sales taxes of items sold in the province
+func (t *Table) Calculate(province string, items ...Item) (*Receipt, error) {
+	p, err := t.Province(province)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rc := &Receipt{Province: p, Lines: make([]Line, 0, len(items))}
+
+	// the components of the receipt are rounded from the sums of the lines without rounding
+	taxable, amount := map[Kind]decimal.Decimal{}, map[Kind]decimal.Decimal{}
+
+	for i, it := range items {
+		l, err := t.line(p, it)
+
+		if err != nil {
+			return nil, ErrInvalidItem(i, err)
+		}
+
+		rc.Lines = append(rc.Lines, l)
+		rc.Subtotal = rc.Subtotal.Add(l.Calc.WithDiscount.Net)
+
+		for _, d := range l.Calc.Taxes {
+			taxable[Kind(d.Code)] = taxable[Kind(d.Code)].Add(d.Taxable)
+			amount[Kind(d.Code)] = amount[Kind(d.Code)].Add(d.Amount)
+		}
+	}
+
+	rc.Subtotal = rc.Subtotal.Round(cents)
+	rc.Total = rc.Subtotal
+
+	for _, r := range p.Rates {
+		a, ok := amount[r.Kind]
+
+		if !ok {
+			continue
+		}
+
+		rc.Taxes = append(rc.Taxes, Amount{
+			Kind: r.Kind, Label: r.Label(), Rate: r.Rate, Taxable: taxable[r.Kind].Round(cents), Amount: a.Round(cents),
+		})
+
+		rc.Total = rc.Total.Add(a.Round(cents))
+	}
+
+	return rc, nil
+}
+
+// line calculates the components of the item
+func (t *Table) line(p Province, it Item) (Line, error) {
+	l := Line{Item: it}
+
+	b := bolson.New()
+
+	if !it.Discount.IsZero() {
+		if err := b.AddDiscount(it.Discount, discount.AmountLine); err != nil {
+			return l, err
+		}
+	}
+
+	if err := t.Configure(b, p.Code, it.Exempt...); err != nil {
+		return l, err
+	}
+
+	calc, err := b.CalculateLine(bolson.Line{Value: it.Value, Qty: it.Qty, MaxDiscount: numbers.Hundred, From: bolson.FromUnitValue})
+
+	if err != nil {
+		return l, err
+	}
+
+	l.Calc = calc
+	l.Net = calc.WithDiscount.Net.Round(cents)
+	l.Total = l.Net
+
+	labels := map[Kind]Rate{}
+
+	for _, r := range p.Rates {
+		labels[r.Kind] = r
+	}
+
+	for _, d := range calc.Taxes {
+		r := labels[Kind(d.Code)]
+		a := Amount{Kind: r.Kind, Label: r.Label(), Rate: r.Rate, Taxable: d.Taxable.Round(cents), Amount: d.Amount.Round(cents)}
+
+		l.Taxes = append(l.Taxes, a)
+		l.Total = l.Total.Add(a.Amount)
+	}
+
+	return l, nil
+}
@@ -0,0 +1,118 @@
+// Package canadatax calculates the canadian sales taxes of each province: the HST alone, the GST with a
+// provincial tax over the same base, or with a provincial tax compounded over the GST, as the QST was.
+//
+// The components are coded taxes of bolson, set up with the stages of the province by [Table.Configure]:
+//
+//	receipt, err := canadatax.DefaultTable().Calculate("BC", items...)
+package canadatax
+
+import (
+	"github.com/profe-ajedrez/bolson"
+	"github.com/shopspring/decimal"
+)
+
+// Kind is a component of the sales tax
+type Kind string
+
+const (
+	GST = Kind("GST")
+	HST = Kind("HST")
+	PST = Kind("PST")
+	QST = Kind("QST")
+
+	// RST is the retail sales tax of Manitoba
+	RST = Kind("RST")
+)
+
+// federal indicates if the kind is levied by the federal government, the HST included
+func (k Kind) federal() bool {
+	return k == GST || k == HST
+}
+
+// Rate is a component of the sales tax of a province
+type Rate struct {
+	Kind Kind            `json:"kind"`
+	Rate decimal.Decimal `json:"rate"`
+
+	// Compound indicates the provincial tax is calculated over the value plus the federal tax
+	Compound bool `json:"compound,omitempty"`
+}
+
+// Label returns the label of the component on receipts, as GST 5%
+func (r Rate) Label() string {
+	return string(r.Kind) + " " + r.Rate.String() + "%"
+}
+
+// Province are the components of the sales tax of a province or territory
+type Province struct {
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Rates []Rate `json:"rates"`
+}
+
+// Item is a line of the receipt
+type Item struct {
+	Description string          `json:"description,omitempty"`
+	Qty         decimal.Decimal `json:"qty"`
+
+	// Value is the unit value, Discount an amount of the line
+	Value    decimal.Decimal `json:"value"`
+	Discount decimal.Decimal `json:"discount"`
+
+	// Exempt are the components which do not apply to the item, as the PST of children clothing
+	Exempt []Kind `json:"exempt,omitempty"`
+}
+
+// contains indicates if kinds contains k
+func contains(kinds []Kind, k Kind) bool {
+	for _, e := range kinds {
+		if e == k {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Amount is a component of the tax of a line or of the receipt
+type Amount struct {
+	Kind  Kind            `json:"kind"`
+	Label string          `json:"label"`
+	Rate  decimal.Decimal `json:"rate"`
+
+	// Taxable is the value over which the component was calculated
+	Taxable decimal.Decimal `json:"taxable"`
+	Amount  decimal.Decimal `json:"amount"`
+}
+
+// Line is a calculated item, with its amounts rounded to cents
+type Line struct {
+	Item
+
+	Net   decimal.Decimal `json:"net"`
+	Taxes []Amount        `json:"taxes"`
+	Total decimal.Decimal `json:"total"`
+
+	Calc bolson.Bag `json:"calc"`
+}
+
+// Receipt is the calculated receipt. Its components are rounded to cents from the sum of the lines
+type Receipt struct {
+	Province Province `json:"province"`
+	Lines    []Line   `json:"lines"`
+
+	Subtotal decimal.Decimal `json:"subtotal"`
+	Taxes    []Amount        `json:"taxes"`
+	Total    decimal.Decimal `json:"total"`
+}
+
+// Tax returns the total of the kind in the receipt
+func (r *Receipt) Tax(k Kind) decimal.Decimal {
+	for _, a := range r.Taxes {
+		if a.Kind == k {
+			return a.Amount
+		}
+	}
+
+	return decimal.Zero
+}
@@ -0,0 +1,168 @@
+package canadatax
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// historic are the rates of 2012, when the QST and the PST of Prince Edward Island were compounded
+const historic = `province,name,kind,rate,compound
+QC,Quebec,GST,5,
+QC,Quebec,QST,9.5,true
+PE,Prince Edward Island,GST,5,
+PE,Prince Edward Island,PST,10,true
+`
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+// components returns the labels of taxes with their amounts
+func components(taxes []Amount) string {
+	c := make([]string, len(taxes))
+
+	for i, a := range taxes {
+		c[i] = a.Label + "=" + a.Amount.StringFixed(2)
+	}
+
+	return strings.Join(c, ",")
+}
+
+func TestHST(t *testing.T) {
+	rc, err := DefaultTable().Calculate("on", Item{Qty: dec("2"), Value: dec("100")})
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if components(rc.Taxes) != "HST 13%=26.00" || !rc.Total.Equal(dec("226")) || rc.Province.Name != "Ontario" {
+		t.Logf("unexpected receipt %s %v", components(rc.Taxes), rc.Total)
+		t.FailNow()
+	}
+}
+
+func TestProvincialOverSameBase(t *testing.T) {
+	rc, err := DefaultTable().Calculate("BC",
+		Item{Description: "lamp", Qty: dec("1"), Value: dec("100")},
+		Item{Description: "children shoes", Qty: dec("1"), Value: dec("50"), Exempt: []Kind{PST}},
+	)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if c := components(rc.Lines[1].Taxes); c != "GST 5%=2.50" {
+		t.Logf("unexpected taxes of the exempt item %s", c)
+		t.FailNow()
+	}
+
+	if components(rc.Taxes) != "GST 5%=7.50,PST 7%=7.00" || !rc.Taxes[1].Taxable.Equal(dec("100")) || !rc.Total.Equal(dec("164.5")) {
+		t.Logf("unexpected receipt %s %v", components(rc.Taxes), rc.Total)
+		t.FailNow()
+	}
+
+	rc, err = DefaultTable().Calculate("QC", Item{Qty: dec("1"), Value: dec("100")})
+
+	if err != nil || components(rc.Taxes) != "GST 5%=5.00,QST 9.975%=9.98" || !rc.Total.Equal(dec("114.98")) {
+		t.Logf("unexpected receipt of Quebec %v %v", err, rc)
+		t.FailNow()
+	}
+}
+
+func TestCompound(t *testing.T) {
+	tb, err := LoadTable(strings.NewReader(historic))
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	rc, err := tb.Calculate("QC", Item{Qty: dec("1"), Value: dec("100")})
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	qst := rc.Taxes[1]
+
+	if components(rc.Taxes) != "GST 5%=5.00,QST 9.5%=9.98" || !qst.Taxable.Equal(dec("105")) || !rc.Total.Equal(dec("114.98")) {
+		t.Logf("unexpected receipt %s %v %v", components(rc.Taxes), qst.Taxable, rc.Total)
+		t.FailNow()
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	tb, _ := LoadTable(strings.NewReader(historic))
+	b := bolson.New()
+
+	if err := tb.Configure(b, "PE"); err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	calc, err := b.Calculate(dec("100"), dec("1"), dec("100"))
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if len(calc.Taxes) != 2 || calc.Taxes[1].Stage != tax.OverTax || !calc.WithDiscount.Tax.Equal(dec("15.5")) {
+		t.Logf("unexpected calculation %v %v", calc.Taxes, calc.WithDiscount.Tax)
+		t.FailNow()
+	}
+
+	if (Rate{Kind: PST}).Stage() != tax.OverTaxIgnorable || (Rate{Kind: HST}).Stage() != tax.OverTaxable {
+		t.Logf("unexpected stages")
+		t.FailNow()
+	}
+}
+
+func TestRounding(t *testing.T) {
+	rc, err := DefaultTable().Calculate("ON",
+		Item{Qty: dec("1"), Value: dec("0.33")},
+		Item{Qty: dec("1"), Value: dec("0.33")},
+		Item{Qty: dec("1"), Value: dec("0.33")},
+	)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	// each line has 0.04 of HST, the receipt rounds the sum of 0.1287
+	if !rc.Lines[0].Taxes[0].Amount.Equal(dec("0.04")) || !rc.Tax(HST).Equal(dec("0.13")) || !rc.Total.Equal(dec("1.12")) {
+		t.Logf("unexpected rounding %v %v %v", rc.Lines[0].Taxes, rc.Tax(HST), rc.Total)
+		t.FailNow()
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := DefaultTable().Calculate("XX"); err == nil || !strings.Contains(err.Error(), "[ErrUnknownProvince]") {
+		t.Logf("expected unknown province, got %v", err)
+		t.FailNow()
+	}
+
+	if _, err := DefaultTable().Calculate("AB", Item{Qty: dec("0"), Value: dec("1")}); err == nil || !strings.Contains(err.Error(), "[ErrInvalidItem]") {
+		t.Logf("expected invalid item, got %v", err)
+		t.FailNow()
+	}
+
+	for _, table := range []string{
+		"province,name,kind,rate\nQC,Quebec,VAT,5\n",
+		"province,name,kind,rate,compound\nQC,Quebec,GST,5,true\n",
+		"province,name,kind\nQC,Quebec,GST\n",
+	} {
+		if _, err := LoadTable(strings.NewReader(table)); err == nil || !strings.Contains(err.Error(), "[ErrInvalidTable]") {
+			t.Logf("expected invalid table, got %v", err)
+			t.FailNow()
+		}
+	}
+}
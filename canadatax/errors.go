@@ -0,0 +1,27 @@
+package canadatax
+
+import (
+	"fmt"
+
+	"github.com/profe-ajedrez/bolson/internal/csvtable"
+)
+
+// ErrUnknownProvince the table has no province with the code
+func ErrUnknownProvince(info any) error {
+	return fmt.Errorf("[ErrUnknownProvince] the table has no province with the code. %v", info)
+}
+
+// ErrInvalidKind the kind of tax is not valid
+func ErrInvalidKind(info any) error {
+	return fmt.Errorf("[ErrInvalidKind] the kind of tax is not valid. %v", info)
+}
+
+// ErrInvalidItem an item could not be calculated
+func ErrInvalidItem(index int, err error) error {
+	return fmt.Errorf("[ErrInvalidItem] the item %d could not be calculated. %w", index+1, err)
+}
+
+// ErrInvalidTable the rate table could not be read
+func ErrInvalidTable(row int, err error) error {
+	return csvtable.ErrInvalidTable(row, err)
+}
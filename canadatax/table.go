@@ -0,0 +1,140 @@
+package canadatax
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/profe-ajedrez/bolson/internal/csvtable"
+	"github.com/shopspring/decimal"
+)
+
+// columns of the rate table, a row by component, compound is optional
+var columns = []string{"province", "name", "kind", "rate", "compound"}
+
+// Table is a local table of the sales taxes of the provinces
+type Table struct {
+	provinces map[string]Province
+}
+
+// DefaultTable returns the rates in force since April 2025
+func DefaultTable() *Table {
+	five := decimal.NewFromInt(5)
+	gst := func(code, name string, rates ...Rate) Province {
+		return Province{Code: code, Name: name, Rates: append([]Rate{{Kind: GST, Rate: five}}, rates...)}
+	}
+	hst := func(code, name string, rate int64) Province {
+		return Province{Code: code, Name: name, Rates: []Rate{{Kind: HST, Rate: decimal.NewFromInt(rate)}}}
+	}
+
+	return NewTable(
+		gst("AB", "Alberta"),
+		gst("BC", "British Columbia", Rate{Kind: PST, Rate: decimal.NewFromInt(7)}),
+		gst("MB", "Manitoba", Rate{Kind: RST, Rate: decimal.NewFromInt(7)}),
+		hst("NB", "New Brunswick", 15),
+		hst("NL", "Newfoundland and Labrador", 15),
+		hst("NS", "Nova Scotia", 14),
+		gst("NT", "Northwest Territories"),
+		gst("NU", "Nunavut"),
+		hst("ON", "Ontario", 13),
+		hst("PE", "Prince Edward Island", 15),
+		gst("QC", "Quebec", Rate{Kind: QST, Rate: decimal.RequireFromString("9.975")}),
+		gst("SK", "Saskatchewan", Rate{Kind: PST, Rate: decimal.NewFromInt(6)}),
+		gst("YT", "Yukon"),
+	)
+}
+
+// NewTable returns a table with the provinces ps
+func NewTable(ps ...Province) *Table {
+	t := &Table{provinces: map[string]Province{}}
+
+	for _, p := range ps {
+		t.Add(p)
+	}
+
+	return t
+}
+
+// LoadTable reads a rate table in CSV with a header row naming the columns province, name, kind, rate and
+// compound, a row by component of each province. Compound is optional
+func LoadTable(r io.Reader) (*Table, error) {
+	t := NewTable()
+
+	if err := csvtable.Load(r, columns[:4], t.parseRate); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *Table) parseRate(row csvtable.Row) error {
+	code := strings.ToUpper(row.Field("province"))
+
+	if code == "" {
+		return errors.New("province is required")
+	}
+
+	kind, err := ParseKind(row.Field("kind"))
+
+	if err != nil {
+		return err
+	}
+
+	r := Rate{Kind: kind}
+
+	if r.Rate, err = decimal.NewFromString(row.Field("rate")); err != nil {
+		return err
+	}
+
+	if compound := row.Field("compound"); compound != "" {
+		if r.Compound, err = strconv.ParseBool(compound); err != nil {
+			return err
+		}
+	}
+
+	if r.Rate.IsNegative() || (r.Compound && kind.federal()) {
+		return errors.New("invalid rate " + r.Label())
+	}
+
+	p := t.provinces[code]
+	p.Code = code
+
+	if name := row.Field("name"); name != "" {
+		p.Name = name
+	}
+
+	p.Rates = append(p.Rates, r)
+	t.provinces[code] = p
+
+	return nil
+}
+
+// ParseKind returns the kind named s, in any case
+func ParseKind(s string) (Kind, error) {
+	k := Kind(strings.ToUpper(strings.TrimSpace(s)))
+
+	switch k {
+	case GST, HST, PST, QST, RST:
+		return k, nil
+	}
+
+	return k, ErrInvalidKind(s)
+}
+
+// Add adds the province p to t, replacing the one with its code
+func (t *Table) Add(p Province) {
+	p.Code = strings.ToUpper(p.Code)
+	t.provinces[p.Code] = p
+}
+
+// Province returns the province with the code
+func (t *Table) Province(code string) (Province, error) {
+	p, ok := t.provinces[strings.ToUpper(strings.TrimSpace(code))]
+
+	if !ok {
+		return p, ErrUnknownProvince(code)
+	}
+
+	return p, nil
+}
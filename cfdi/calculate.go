@@ -0,0 +1,221 @@
+package cfdi
+
+import (
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/profe-ajedrez/bolson/tax"
+)
+
+// currencies are the decimals of the currencies, those not listed have 2
+var currencies = map[string]int32{
+	"MXN": 2,
+	"USD": 2,
+	"EUR": 2,
+	"JPY": 0,
+	"CLP": 0,
+}
+
+// currencyDecimals returns the decimals of the currency of d
+func (d Document) currencyDecimals() int32 {
+	if n, ok := currencies[d.currency()]; ok {
+		return n
+	}
+
+	return 2
+}
+
+func (d Document) currency() string {
+	if d.Currency == "" {
+		return "MXN"
+	}
+
+	return d.Currency
+}
+
+// decimals returns the decimals of the amounts of the concepts of d
+func (d Document) decimals() int32 {
+	if d.Decimals == 0 {
+		return d.currencyDecimals()
+	}
+
+	return d.Decimals
+}
+
+// Calculate calculates the concepts of d, their taxes, and the taxes and totals of the document
+func Calculate(d Document) (*Calculated, error) {
+	if d.Decimals != 0 && (d.Decimals < d.currencyDecimals() || d.Decimals > 6) {
+		return nil, ErrInvalidDecimals(d.Decimals)
+	}
+
+	c := &Calculated{Document: d, Lines: make([]Line, len(d.Concepts))}
+
+	for i, concept := range d.Concepts {
+		l, err := calculateConcept(concept, d.decimals())
+
+		if err != nil {
+			return nil, ErrInvalidConcept(i, err)
+		}
+
+		c.Lines[i] = l
+	}
+
+	c.total()
+
+	return c, nil
+}
+
+// handler returns the calculator of the transferred or withheld taxes of concept
+func handler(concept Concept, withheld bool) (bolson.Bolson, []Tax, error) {
+	b := bolson.New()
+	taxes := []Tax{}
+
+	for _, t := range concept.Taxes {
+		if t.Withheld != withheld || t.Factor == Exento {
+			continue
+		}
+
+		if t.Rate.IsNegative() {
+			return b, nil, ErrInvalidTax(t)
+		}
+
+		mode, value := tax.PercentualMode, t.Rate.Mul(numbers.Hundred)
+
+		switch t.Factor {
+		case Tasa:
+		case Cuota:
+			mode, value = tax.AmountUnitMode, t.Rate
+		default:
+			return b, nil, ErrInvalidTax(t)
+		}
+
+		// IVA is transferred over the value plus IEPS
+		stage := tax.OverTaxable
+
+		if t.Tax == IVA && !withheld {
+			stage = tax.OverTax
+		}
+
+		if err := b.AddCodedTax(t.Tax, value, mode, stage, tax.DiscountedBase); err != nil {
+			return b, nil, err
+		}
+
+		taxes = append(taxes, t)
+	}
+
+	if concept.Discount.IsPositive() {
+		if err := b.AddDiscount(concept.Discount, discount.AmountLine); err != nil {
+			return b, nil, err
+		}
+	}
+
+	return b, taxes, nil
+}
+
+func calculateConcept(concept Concept, decimals int32) (Line, error) {
+	l := Line{Concept: concept}
+
+	for _, t := range concept.Taxes {
+		if t.Tax != ISR && t.Tax != IVA && t.Tax != IEPS || (t.Withheld && t.Factor == Exento) {
+			return l, ErrInvalidTax(t)
+		}
+	}
+
+	line := bolson.Line{Value: concept.UnitValue, Qty: concept.Qty, MaxDiscount: numbers.Hundred, From: bolson.FromUnitValue}
+
+	for _, withheld := range []bool{false, true} {
+		b, taxes, err := handler(concept, withheld)
+
+		if err != nil {
+			return l, err
+		}
+
+		calc, err := b.CalculateLine(line)
+
+		if err != nil {
+			return l, err
+		}
+
+		amounts := make([]Amount, len(calc.Taxes))
+
+		for i, d := range calc.Taxes {
+			amounts[i] = Amount{Tax: taxes[i], Base: d.Taxable.Round(decimals), Amount: d.Amount.Round(decimals)}
+
+			// the base of a quota is the quantity of units
+			if taxes[i].Factor == Cuota {
+				amounts[i].Base = concept.Qty
+			}
+		}
+
+		if withheld {
+			l.Withholdings = amounts
+			continue
+		}
+
+		l.Calc = calc
+		l.Amount = calc.WithoutDiscount.Net.Round(decimals)
+		l.Transfers = amounts
+	}
+
+	// the exempt taxes only report their base, the value of the concept
+	for _, t := range concept.Taxes {
+		if t.Factor == Exento {
+			l.Transfers = append(l.Transfers, Amount{Tax: t, Base: l.Amount.Sub(concept.Discount.Round(decimals))})
+		}
+	}
+
+	return l, nil
+}
+
+// total groups the taxes of the concepts and calculates the totals
+func (c *Calculated) total() {
+	decimals := c.currencyDecimals()
+
+	transfers, withholdings := map[string]int{}, map[string]int{}
+	t := Totals{}
+
+	for _, l := range c.Lines {
+		t.SubTotal = t.SubTotal.Add(l.Amount)
+		t.Discount = t.Discount.Add(l.Discount)
+
+		for _, a := range l.Transfers {
+			k := a.Tax.Tax + string(a.Factor) + a.Rate.StringFixed(6)
+
+			i, ok := transfers[k]
+
+			if !ok {
+				i, transfers[k] = len(c.Transfers), len(c.Transfers)
+				c.Transfers = append(c.Transfers, Amount{Tax: a.Tax})
+			}
+
+			c.Transfers[i].Base = c.Transfers[i].Base.Add(a.Base)
+			c.Transfers[i].Amount = c.Transfers[i].Amount.Add(a.Amount)
+		}
+
+		for _, a := range l.Withholdings {
+			i, ok := withholdings[a.Tax.Tax]
+
+			if !ok {
+				i, withholdings[a.Tax.Tax] = len(c.Withholdings), len(c.Withholdings)
+				c.Withholdings = append(c.Withholdings, Amount{Tax: Tax{Tax: a.Tax.Tax, Withheld: true}})
+			}
+
+			c.Withholdings[i].Amount = c.Withholdings[i].Amount.Add(a.Amount)
+		}
+	}
+
+	for i, a := range c.Transfers {
+		c.Transfers[i].Base, c.Transfers[i].Amount = a.Base.Round(decimals), a.Amount.Round(decimals)
+		t.Transferred = t.Transferred.Add(c.Transfers[i].Amount)
+	}
+
+	for i, a := range c.Withholdings {
+		c.Withholdings[i].Amount = a.Amount.Round(decimals)
+		t.Withheld = t.Withheld.Add(c.Withholdings[i].Amount)
+	}
+
+	t.SubTotal, t.Discount = t.SubTotal.Round(decimals), t.Discount.Round(decimals)
+	t.Total = t.SubTotal.Sub(t.Discount).Add(t.Transferred).Sub(t.Withheld)
+
+	c.Totals = t
+}
@@ -0,0 +1,151 @@
+// Package cfdi calculates the taxes of the mexican CFDI 4.0, the transferred taxes, traslados, and the
+// withheld ones, retenciones, of each concept and of the document, and writes its Impuestos nodes.
+//
+// The taxes of the concepts are calculated by [tax.Handler] through bolson, IEPS over the value of the
+// concept and IVA over the value plus IEPS. The totals of the document are the sums of the concepts,
+// rounded to the decimals of the currency:
+//
+//	calc, err := cfdi.Calculate(doc)
+//	err = cfdi.Verify(calc)
+//	xml, err := calc.XML()
+//
+// The sello and the certificate of the comprobante are not generated
+package cfdi
+
+import (
+	"time"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/shopspring/decimal"
+)
+
+// codes of the taxes in the catalog c_Impuesto
+const (
+	ISR  = "001"
+	IVA  = "002"
+	IEPS = "003"
+)
+
+// Factor is the TipoFactor of a tax
+type Factor string
+
+const (
+	// Tasa is a rate over the base, as 0.160000
+	Tasa = Factor("Tasa")
+
+	// Cuota is an amount by unit, the base is the quantity
+	Cuota = Factor("Cuota")
+
+	// Exento is an exempt transferred tax, without rate nor amount
+	Exento = Factor("Exento")
+)
+
+// Tax is a tax of a concept
+type Tax struct {
+	// Tax is the code of the tax, [ISR], [IVA] or [IEPS]
+	Tax    string `json:"tax"`
+	Factor Factor `json:"factor"`
+
+	// Rate is the TasaOCuota, a fraction as 0.16 in Tasa or an amount by unit in Cuota
+	Rate decimal.Decimal `json:"rate"`
+
+	// Withheld indicates a retención instead of a traslado
+	Withheld bool `json:"withheld,omitempty"`
+}
+
+// Issuer is the Emisor
+type Issuer struct {
+	RFC    string `json:"rfc"`
+	Name   string `json:"name"`
+	Regime string `json:"regime"`
+}
+
+// Receiver is the Receptor
+type Receiver struct {
+	RFC        string `json:"rfc"`
+	Name       string `json:"name"`
+	PostalCode string `json:"postalCode"`
+	Regime     string `json:"regime"`
+
+	// Use is the UsoCFDI, as G03
+	Use string `json:"use"`
+}
+
+// Concept is a concepto of the comprobante
+type Concept struct {
+	ProductKey  string          `json:"productKey"`
+	Qty         decimal.Decimal `json:"qty"`
+	UnitKey     string          `json:"unitKey"`
+	Description string          `json:"description"`
+	UnitValue   decimal.Decimal `json:"unitValue"`
+
+	// Discount is the Descuento of the concept, an amount
+	Discount decimal.Decimal `json:"discount"`
+
+	// Taxes are the taxes of the concept, its ObjetoImp is 01 when it has none
+	Taxes []Tax `json:"taxes,omitempty"`
+}
+
+// Document are the data of a comprobante of income before being calculated
+type Document struct {
+	Series string    `json:"series,omitempty"`
+	Folio  string    `json:"folio,omitempty"`
+	Date   time.Time `json:"date"`
+
+	// Currency is the ISO 4217 code, MXN by default
+	Currency string `json:"currency,omitempty"`
+
+	// PlaceOfIssue is the LugarExpedicion, a postal code
+	PlaceOfIssue string   `json:"placeOfIssue"`
+	Issuer       Issuer   `json:"issuer"`
+	Receiver     Receiver `json:"receiver"`
+
+	Concepts []Concept `json:"concepts"`
+
+	// Decimals are the decimals of the amounts of the concepts, between the decimals of the currency and 6.
+	// By default those of the currency
+	Decimals int32 `json:"decimals,omitempty"`
+}
+
+// Amount is a traslado or retención, of a concept or of the document
+type Amount struct {
+	Tax
+
+	Base   decimal.Decimal `json:"base"`
+	Amount decimal.Decimal `json:"amount"`
+}
+
+// Line is a calculated concept
+type Line struct {
+	Concept
+
+	// Amount is the Importe of the concept, the quantity by the unit value
+	Amount decimal.Decimal `json:"amount"`
+
+	Transfers    []Amount `json:"transfers,omitempty"`
+	Withholdings []Amount `json:"withholdings,omitempty"`
+
+	Calc bolson.Bag `json:"calc"`
+}
+
+// Totals are the totals of the comprobante and of its Impuestos node
+type Totals struct {
+	SubTotal    decimal.Decimal `json:"subTotal"`
+	Discount    decimal.Decimal `json:"discount"`
+	Transferred decimal.Decimal `json:"transferred"`
+	Withheld    decimal.Decimal `json:"withheld"`
+	Total       decimal.Decimal `json:"total"`
+}
+
+// Calculated is a document with its concepts and totals calculated
+type Calculated struct {
+	Document
+
+	Lines []Line `json:"lines"`
+
+	// Transfers are grouped by tax, factor and rate, Withholdings by tax
+	Transfers    []Amount `json:"transfers,omitempty"`
+	Withholdings []Amount `json:"withholdings,omitempty"`
+
+	Totals Totals `json:"totals"`
+}
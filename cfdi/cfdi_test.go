@@ -0,0 +1,221 @@
+package cfdi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func document() Document {
+	return Document{
+		Series:       "A",
+		Folio:        "1001",
+		Date:         time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		PlaceOfIssue: "06600",
+		Issuer:       Issuer{RFC: "EKU9003173C9", Name: "ESCUELA KEMPER URGATE", Regime: "601"},
+		Receiver:     Receiver{RFC: "XAXX010101000", Name: "PUBLICO EN GENERAL", PostalCode: "06600", Regime: "616", Use: "S01"},
+		Concepts: []Concept{
+			{ProductKey: "80111600", Qty: dec("1"), UnitKey: "E48", Description: "Consultoría", UnitValue: dec("10000"), Taxes: ProfessionalServices()},
+			{
+				ProductKey: "50202306", Qty: dec("24"), UnitKey: "LTR", Description: "Refresco", UnitValue: dec("15.5"), Discount: dec("12"),
+				Taxes: WithIEPS(Cuota, dec("1.5086")),
+			},
+			{ProductKey: "55101500", Qty: dec("2"), UnitKey: "H87", Description: "Libro", UnitValue: dec("250"), Taxes: Exempt()},
+		},
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	c, err := Calculate(document())
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	// IEPS is 24 liters by 1.5086, and IVA is over 360 plus IEPS
+	refresco := c.Lines[1]
+
+	if !refresco.Transfers[0].Amount.Equal(dec("36.21")) || !refresco.Transfers[0].Base.Equal(dec("24")) {
+		t.Logf("unexpected IEPS %+v", refresco.Transfers[0])
+		t.FailNow()
+	}
+
+	if !refresco.Transfers[1].Base.Equal(dec("396.21")) || !refresco.Transfers[1].Amount.Equal(dec("63.39")) {
+		t.Logf("unexpected IVA %+v", refresco.Transfers[1])
+		t.FailNow()
+	}
+
+	if w := c.Lines[0].Withholdings; len(w) != 2 || !w[0].Amount.Equal(dec("1000")) || !w[1].Amount.Equal(dec("1066.67")) {
+		t.Logf("unexpected withholdings %+v", w)
+		t.FailNow()
+	}
+
+	if len(c.Transfers) != 3 || !c.Transfers[0].Base.Equal(dec("10396.21")) || !c.Transfers[0].Amount.Equal(dec("1663.39")) {
+		t.Logf("unexpected transfers %+v", c.Transfers)
+		t.FailNow()
+	}
+
+	if c.Transfers[2].Factor != Exento || !c.Transfers[2].Base.Equal(dec("500")) {
+		t.Logf("unexpected exempt transfer %+v", c.Transfers[2])
+		t.FailNow()
+	}
+
+	tt := c.Totals
+
+	expected := map[string][2]decimal.Decimal{
+		"subTotal":    {tt.SubTotal, dec("10872")},
+		"discount":    {tt.Discount, dec("12")},
+		"transferred": {tt.Transferred, dec("1699.60")},
+		"withheld":    {tt.Withheld, dec("2066.67")},
+		"total":       {tt.Total, dec("10492.93")},
+	}
+
+	for name, v := range expected {
+		if !v[0].Equal(v[1]) {
+			t.Logf("%s: expected %s. Got %s", name, v[1], v[0])
+			t.FailNow()
+		}
+	}
+
+	if err := Verify(c); err != nil {
+		t.Logf("unexpected verification error %v", err)
+		t.FailNow()
+	}
+}
+
+func TestImpuestos(t *testing.T) {
+	c, _ := Calculate(document())
+
+	out, err := c.Impuestos()
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	expected := `<cfdi:Impuestos TotalImpuestosRetenidos="2066.67" TotalImpuestosTrasladados="1699.60">
+  <cfdi:Retenciones>
+    <cfdi:Retencion Impuesto="001" Importe="1000.00"></cfdi:Retencion>
+    <cfdi:Retencion Impuesto="002" Importe="1066.67"></cfdi:Retencion>
+  </cfdi:Retenciones>
+  <cfdi:Traslados>
+    <cfdi:Traslado Base="10396.21" Impuesto="002" TipoFactor="Tasa" TasaOCuota="0.160000" Importe="1663.39"></cfdi:Traslado>
+    <cfdi:Traslado Base="24.00" Impuesto="003" TipoFactor="Cuota" TasaOCuota="1.508600" Importe="36.21"></cfdi:Traslado>
+    <cfdi:Traslado Base="500.00" Impuesto="002" TipoFactor="Exento"></cfdi:Traslado>
+  </cfdi:Traslados>
+</cfdi:Impuestos>`
+
+	if string(out) != expected {
+		t.Logf("expected\n%s\nGot\n%s", expected, out)
+		t.FailNow()
+	}
+}
+
+func TestXML(t *testing.T) {
+	c, _ := Calculate(document())
+
+	out, err := c.XML()
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	s := string(out)
+
+	for _, e := range []string{
+		`<cfdi:Comprobante xmlns:cfdi="http://www.sat.gob.mx/cfd/4" Version="4.0" Serie="A" Folio="1001" Fecha="2026-10-18T12:00:00" SubTotal="10872.00" Descuento="12.00" Moneda="MXN" Total="10492.93"`,
+		`<cfdi:Concepto ClaveProdServ="80111600" Cantidad="1" ClaveUnidad="E48" Descripcion="Consultoría" ValorUnitario="10000" Importe="10000.00" ObjetoImp="02">`,
+		`<cfdi:Retencion Base="10000.00" Impuesto="002" TipoFactor="Tasa" TasaOCuota="0.106667" Importe="1066.67"></cfdi:Retencion>`,
+		`Importe="372.00" Descuento="12.00" ObjetoImp="02"`,
+	} {
+		if !strings.Contains(s, e) {
+			t.Logf("expected %s in %s", e, s)
+			t.FailNow()
+		}
+	}
+
+	// the taxes of the document go after the concepts
+	if strings.Index(s, "TotalImpuestosTrasladados") < strings.Index(s, "</cfdi:Conceptos>") {
+		t.Logf("unexpected order of nodes %s", s)
+		t.FailNow()
+	}
+}
+
+func TestVerify(t *testing.T) {
+	c, _ := Calculate(document())
+
+	c.Lines[0].Transfers[0].Amount = dec("1601")
+	c.Totals.Total = c.Totals.Total.Add(dec("1"))
+
+	err := Verify(c)
+
+	var ve *ValidationError
+
+	if !errors.As(err, &ve) {
+		t.Logf("expected a validation error. Got %v", err)
+		t.FailNow()
+	}
+
+	expected := []string{
+		"[ImporteTraslado] line 1 Traslado.Importe: 1601.00 must be between 1599.99 and 1600.01",
+		"[ImporteTraslado] document Traslado.Importe",
+		"[Total] document Total",
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(err.Error(), e) {
+			t.Logf("expected %s in %v", e, err)
+			t.FailNow()
+		}
+	}
+
+	if len(ve.Violations) != len(expected) {
+		t.Logf("expected %d violations. Got %v", len(expected), ve.Violations)
+		t.FailNow()
+	}
+}
+
+func TestLimits(t *testing.T) {
+	// from 1.45 by 10.245, 14.85525, to 1.55 by 10.255, 15.89525
+	lower, upper := limits("1.5", "10.25", 2)
+
+	if !lower.Equal(dec("14.85")) || !upper.Equal(dec("15.9")) {
+		t.Logf("unexpected limits %s %s", lower, upper)
+		t.FailNow()
+	}
+}
+
+func TestCalculateInvalid(t *testing.T) {
+	d := document()
+	d.Concepts[0].Taxes = []Tax{{Tax: "004", Factor: Tasa, Rate: dec("0.16")}}
+
+	if _, err := Calculate(d); err == nil || !strings.Contains(err.Error(), "ErrInvalidConcept") {
+		t.Logf("expected ErrInvalidConcept. Got %v", err)
+		t.FailNow()
+	}
+
+	d = document()
+	d.Concepts[0].Taxes = []Tax{{Tax: ISR, Factor: Exento, Withheld: true}}
+
+	if _, err := Calculate(d); err == nil || !strings.Contains(err.Error(), "ErrInvalidTax") {
+		t.Logf("expected ErrInvalidTax. Got %v", err)
+		t.FailNow()
+	}
+
+	d = document()
+	d.Decimals = 1
+
+	if _, err := Calculate(d); err == nil || !strings.Contains(err.Error(), "ErrInvalidDecimals") {
+		t.Logf("expected ErrInvalidDecimals. Got %v", err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,18 @@
+package cfdi
+
+import "fmt"
+
+// ErrInvalidConcept a concept could not be calculated
+func ErrInvalidConcept(index int, err error) error {
+	return fmt.Errorf("[ErrInvalidConcept] the concept %d could not be calculated. %w", index+1, err)
+}
+
+// ErrInvalidTax a tax of a concept is not valid
+func ErrInvalidTax(info any) error {
+	return fmt.Errorf("[ErrInvalidTax] the tax is not valid. %v", info)
+}
+
+// ErrInvalidDecimals the decimals of the amounts are not allowed by the currency
+func ErrInvalidDecimals(info any) error {
+	return fmt.Errorf("[ErrInvalidDecimals] the decimals of the amounts are not allowed by the currency. %v", info)
+}
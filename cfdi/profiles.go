@@ -0,0 +1,59 @@
+package cfdi
+
+import "github.com/shopspring/decimal"
+
+// Profiles are the taxes of the usual concepts, each call returns a new slice which can be extended
+var (
+	rateIVA          = decimal.RequireFromString("0.16")
+	rateBorderIVA    = decimal.RequireFromString("0.08")
+	rateRetainedISR  = decimal.RequireFromString("0.10")
+	rateRetainedIVA  = decimal.RequireFromString("0.106667")
+	rateFreightIVA   = decimal.RequireFromString("0.04")
+	rateResicoISR    = decimal.RequireFromString("0.0125")
+	transferredIVA16 = Tax{Tax: IVA, Factor: Tasa, Rate: rateIVA}
+)
+
+// General is IVA at 16%
+func General() []Tax {
+	return []Tax{transferredIVA16}
+}
+
+// Border is IVA at 8% of the border region
+func Border() []Tax {
+	return []Tax{{Tax: IVA, Factor: Tasa, Rate: rateBorderIVA}}
+}
+
+// ZeroRated is IVA at 0%
+func ZeroRated() []Tax {
+	return []Tax{{Tax: IVA, Factor: Tasa, Rate: decimal.Zero}}
+}
+
+// Exempt is IVA exempt
+func Exempt() []Tax {
+	return []Tax{{Tax: IVA, Factor: Exento}}
+}
+
+// ProfessionalServices is IVA at 16% with the withholdings of a company paying professional services or
+// a lease to a person, 10% of ISR and two thirds of the IVA
+func ProfessionalServices() []Tax {
+	return []Tax{
+		transferredIVA16,
+		{Tax: ISR, Factor: Tasa, Rate: rateRetainedISR, Withheld: true},
+		{Tax: IVA, Factor: Tasa, Rate: rateRetainedIVA, Withheld: true},
+	}
+}
+
+// Freight is IVA at 16% with the withholding of 4% of IVA of the ground freight
+func Freight() []Tax {
+	return []Tax{transferredIVA16, {Tax: IVA, Factor: Tasa, Rate: rateFreightIVA, Withheld: true}}
+}
+
+// Resico is IVA at 16% with the withholding of 1.25% of ISR of the simplified regime of trust
+func Resico() []Tax {
+	return []Tax{transferredIVA16, {Tax: ISR, Factor: Tasa, Rate: rateResicoISR, Withheld: true}}
+}
+
+// WithIEPS returns IVA at 16% over the value plus IEPS with factor and rate, as a quota by liter
+func WithIEPS(factor Factor, rate decimal.Decimal) []Tax {
+	return []Tax{{Tax: IEPS, Factor: factor, Rate: rate}, transferredIVA16}
+}
@@ -0,0 +1,228 @@
+package cfdi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// DocumentLine is the line of the violations of the document itself instead of one of its concepts
+const DocumentLine = 0
+
+// Violation is a rule of the SAT over the amounts not satisfied by a document
+type Violation struct {
+	// Line is the number of the concept starting at 1, or [DocumentLine]
+	Line int `json:"line"`
+
+	// Code identifies the kind of the problem
+	Code string `json:"code"`
+
+	// Field is the offending attribute, named as in the XML
+	Field string `json:"field"`
+
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Line == DocumentLine {
+		return fmt.Sprintf("[%s] document %s: %s", v.Code, v.Field, v.Message)
+	}
+
+	return fmt.Sprintf("[%s] line %d %s: %s", v.Code, v.Line, v.Field, v.Message)
+}
+
+// ValidationError reports every violation found by [Verify]
+type ValidationError struct {
+	Violations []Violation `json:"violations"`
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+
+	return fmt.Sprintf("[ErrValidation] %d violations found. %s", len(e.Violations), strings.Join(msgs, "; "))
+}
+
+type verifier struct {
+	violations []Violation
+}
+
+func (v *verifier) add(line int, code string, field string, msg string) {
+	v.violations = append(v.violations, Violation{Line: line, Code: code, Field: field, Message: msg})
+}
+
+// places returns the decimals of d as it is written
+func places(d string) int32 {
+	if i := strings.IndexByte(d, '.'); i >= 0 {
+		return int32(len(d) - i - 1)
+	}
+
+	return 0
+}
+
+// half returns half of the last decimal of a value written with n decimals
+func half(n int32) decimal.Decimal {
+	return decimal.New(5, -n-1)
+}
+
+// limits returns the lower and upper limits the SAT accepts for the product of a and b, written as they
+// are in the XML, when the product is written with n decimals
+func limits(a string, b string, n int32) (decimal.Decimal, decimal.Decimal) {
+	x, y := decimal.RequireFromString(a), decimal.RequireFromString(b)
+	hx, hy := half(places(a)), half(places(b))
+
+	lower := x.Sub(hx).Mul(y.Sub(hy)).Truncate(n)
+	upper := x.Add(hx).Mul(y.Add(hy)).RoundCeil(n)
+
+	return lower, upper
+}
+
+// within checks the amount written as value is between the limits of the product of a and b
+func (v *verifier) within(line int, code string, field string, value string, a string, b string) {
+	lower, upper := limits(a, b, places(value))
+	d := decimal.RequireFromString(value)
+
+	if d.LessThan(lower) || d.GreaterThan(upper) {
+		v.add(line, code, field, fmt.Sprintf("%s must be between %s and %s", value, lower, upper))
+	}
+}
+
+// equal checks an amount written as value is the expected one
+func (v *verifier) equal(code string, field string, value string, expected decimal.Decimal, decimals int32, msg string) {
+	if value != fixed(expected, decimals) {
+		v.add(DocumentLine, code, field, fmt.Sprintf("%s must be %s, %s", value, fixed(expected, decimals), msg))
+	}
+}
+
+// Verify checks the amounts of c as they are written in its XML against the rules of the SAT: the
+// amounts of the concepts and of their taxes must be within the tolerance of their products, and the
+// totals of the document must be the rounding of the sums of the concepts. Every violation found is
+// reported in a [*ValidationError]
+func Verify(c *Calculated) error {
+	v := &verifier{}
+	x := c.comprobante()
+	decimals := c.currencyDecimals()
+
+	v.concepts(x)
+
+	subTotal, discount := decimal.Zero, decimal.Zero
+	transfers, withholdings := map[string]decimal.Decimal{}, map[string]decimal.Decimal{}
+	bases := map[string]decimal.Decimal{}
+
+	for _, cx := range x.Conceptos {
+		subTotal = subTotal.Add(decimal.RequireFromString(cx.Importe))
+
+		if cx.Descuento != "" {
+			discount = discount.Add(decimal.RequireFromString(cx.Descuento))
+		}
+
+		if cx.Impuestos == nil {
+			continue
+		}
+
+		for _, t := range cx.Impuestos.Traslados {
+			k := t.Impuesto + t.TipoFactor + t.TasaOCuota
+			bases[k] = bases[k].Add(decimal.RequireFromString(t.Base))
+
+			if t.Importe != "" {
+				transfers[k] = transfers[k].Add(decimal.RequireFromString(t.Importe))
+			}
+		}
+
+		for _, t := range cx.Impuestos.Retenciones {
+			withholdings[t.Impuesto] = withholdings[t.Impuesto].Add(decimal.RequireFromString(t.Importe))
+		}
+	}
+
+	v.equal("SubTotal", "SubTotal", x.SubTotal, subTotal, decimals, "the sum of the Importe of the concepts")
+
+	if x.Descuento != "" {
+		v.equal("Descuento", "Descuento", x.Descuento, discount, decimals, "the sum of the Descuento of the concepts")
+
+		if decimal.RequireFromString(x.Descuento).GreaterThan(decimal.RequireFromString(x.SubTotal)) {
+			v.add(DocumentLine, "Descuento", "Descuento", "cannot be greater than SubTotal")
+		}
+	}
+
+	transferred, withheld := decimal.Zero, decimal.Zero
+
+	if x.Impuestos != nil {
+		for _, t := range x.Impuestos.Traslados {
+			k := t.Impuesto + t.TipoFactor + t.TasaOCuota
+
+			v.equal("BaseTraslado", "Traslado.Base", t.Base, bases[k], decimals, "the sum of the bases of the concepts")
+
+			if t.Importe != "" {
+				v.equal("ImporteTraslado", "Traslado.Importe", t.Importe, transfers[k], decimals, "the sum of the transfers of the concepts")
+				transferred = transferred.Add(decimal.RequireFromString(t.Importe))
+			}
+		}
+
+		for _, t := range x.Impuestos.Retenciones {
+			v.equal("ImporteRetencion", "Retencion.Importe", t.Importe, withholdings[t.Impuesto], decimals, "the sum of the withholdings of the concepts")
+			withheld = withheld.Add(decimal.RequireFromString(t.Importe))
+		}
+
+		if x.Impuestos.TotalImpuestosTrasladados != "" {
+			v.equal("TotalImpuestosTrasladados", "TotalImpuestosTrasladados", x.Impuestos.TotalImpuestosTrasladados, transferred, decimals, "the sum of the transfers")
+		}
+
+		if x.Impuestos.TotalImpuestosRetenidos != "" {
+			v.equal("TotalImpuestosRetenidos", "TotalImpuestosRetenidos", x.Impuestos.TotalImpuestosRetenidos, withheld, decimals, "the sum of the withholdings")
+		}
+	}
+
+	total := decimal.RequireFromString(x.SubTotal).Add(transferred).Sub(withheld)
+
+	if x.Descuento != "" {
+		total = total.Sub(decimal.RequireFromString(x.Descuento))
+	}
+
+	v.equal("Total", "Total", x.Total, total, decimals, "SubTotal minus Descuento plus the transfers minus the withholdings")
+
+	if len(v.violations) > 0 {
+		return &ValidationError{Violations: v.violations}
+	}
+
+	return nil
+}
+
+func (v *verifier) concepts(x xmlComprobante) {
+	for i, cx := range x.Conceptos {
+		n := i + 1
+
+		v.within(n, "Importe", "Importe", cx.Importe, cx.Cantidad, cx.ValorUnitario)
+
+		if cx.Descuento != "" && decimal.RequireFromString(cx.Descuento).GreaterThan(decimal.RequireFromString(cx.Importe)) {
+			v.add(n, "Descuento", "Descuento", "cannot be greater than Importe")
+		}
+
+		if cx.Impuestos == nil {
+			continue
+		}
+
+		taxes := append(append([]xmlImpuesto{}, cx.Impuestos.Traslados...), cx.Impuestos.Retenciones...)
+
+		for j, t := range taxes {
+			code, field := "ImporteTraslado", "Traslado"
+
+			if j >= len(cx.Impuestos.Traslados) {
+				code, field = "ImporteRetencion", "Retencion"
+			}
+
+			if !decimal.RequireFromString(t.Base).IsPositive() {
+				v.add(n, "Base", field+".Base", "must be greater than zero")
+			}
+
+			if t.TipoFactor == string(Exento) {
+				continue
+			}
+
+			v.within(n, code, field+".Importe", t.Importe, t.Base, t.TasaOCuota)
+		}
+	}
+}
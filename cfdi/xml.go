@@ -0,0 +1,224 @@
+package cfdi
+
+import (
+	"encoding/xml"
+
+	"github.com/shopspring/decimal"
+)
+
+// Namespace is the namespace of the CFDI 4.0
+const Namespace = "http://www.sat.gob.mx/cfd/4"
+
+const dateLayout = "2006-01-02T15:04:05"
+
+type xmlComprobante struct {
+	XMLName           xml.Name      `xml:"cfdi:Comprobante"`
+	Xmlns             string        `xml:"xmlns:cfdi,attr"`
+	Version           string        `xml:"Version,attr"`
+	Serie             string        `xml:"Serie,attr,omitempty"`
+	Folio             string        `xml:"Folio,attr,omitempty"`
+	Fecha             string        `xml:"Fecha,attr"`
+	SubTotal          string        `xml:"SubTotal,attr"`
+	Descuento         string        `xml:"Descuento,attr,omitempty"`
+	Moneda            string        `xml:"Moneda,attr"`
+	Total             string        `xml:"Total,attr"`
+	TipoDeComprobante string        `xml:"TipoDeComprobante,attr"`
+	Exportacion       string        `xml:"Exportacion,attr"`
+	LugarExpedicion   string        `xml:"LugarExpedicion,attr"`
+	Emisor            xmlEmisor     `xml:"cfdi:Emisor"`
+	Receptor          xmlReceptor   `xml:"cfdi:Receptor"`
+	Conceptos         []xmlConcepto `xml:"cfdi:Conceptos>cfdi:Concepto"`
+	Impuestos         *xmlImpuestos `xml:"cfdi:Impuestos,omitempty"`
+}
+
+type xmlEmisor struct {
+	Rfc           string `xml:"Rfc,attr"`
+	Nombre        string `xml:"Nombre,attr"`
+	RegimenFiscal string `xml:"RegimenFiscal,attr"`
+}
+
+type xmlReceptor struct {
+	Rfc                     string `xml:"Rfc,attr"`
+	Nombre                  string `xml:"Nombre,attr"`
+	DomicilioFiscalReceptor string `xml:"DomicilioFiscalReceptor,attr"`
+	RegimenFiscalReceptor   string `xml:"RegimenFiscalReceptor,attr"`
+	UsoCFDI                 string `xml:"UsoCFDI,attr"`
+}
+
+type xmlConcepto struct {
+	ClaveProdServ string                `xml:"ClaveProdServ,attr"`
+	Cantidad      string                `xml:"Cantidad,attr"`
+	ClaveUnidad   string                `xml:"ClaveUnidad,attr"`
+	Descripcion   string                `xml:"Descripcion,attr"`
+	ValorUnitario string                `xml:"ValorUnitario,attr"`
+	Importe       string                `xml:"Importe,attr"`
+	Descuento     string                `xml:"Descuento,attr,omitempty"`
+	ObjetoImp     string                `xml:"ObjetoImp,attr"`
+	Impuestos     *xmlConceptoImpuestos `xml:"cfdi:Impuestos,omitempty"`
+}
+
+type xmlConceptoImpuestos struct {
+	Traslados   []xmlImpuesto `xml:"cfdi:Traslados>cfdi:Traslado,omitempty"`
+	Retenciones []xmlImpuesto `xml:"cfdi:Retenciones>cfdi:Retencion,omitempty"`
+}
+
+// xmlImpuestos is the node of the document, whose withholdings go before the transfers
+type xmlImpuestos struct {
+	TotalImpuestosRetenidos   string        `xml:"TotalImpuestosRetenidos,attr,omitempty"`
+	TotalImpuestosTrasladados string        `xml:"TotalImpuestosTrasladados,attr,omitempty"`
+	Retenciones               []xmlImpuesto `xml:"cfdi:Retenciones>cfdi:Retencion,omitempty"`
+	Traslados                 []xmlImpuesto `xml:"cfdi:Traslados>cfdi:Traslado,omitempty"`
+}
+
+type xmlImpuesto struct {
+	Base       string `xml:"Base,attr,omitempty"`
+	Impuesto   string `xml:"Impuesto,attr"`
+	TipoFactor string `xml:"TipoFactor,attr,omitempty"`
+	TasaOCuota string `xml:"TasaOCuota,attr,omitempty"`
+	Importe    string `xml:"Importe,attr,omitempty"`
+}
+
+// XML returns the Comprobante of c, without sello nor certificate
+func (c *Calculated) XML() ([]byte, error) {
+	out, err := xml.MarshalIndent(c.comprobante(), "", "  ")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), out...), nil
+}
+
+// Impuestos returns the Impuestos node of the document
+func (c *Calculated) Impuestos() ([]byte, error) {
+	impuestos := c.impuestos()
+
+	if impuestos == nil {
+		return nil, nil
+	}
+
+	return xml.MarshalIndent(struct {
+		XMLName xml.Name `xml:"cfdi:Impuestos"`
+		*xmlImpuestos
+	}{xmlImpuestos: impuestos}, "", "  ")
+}
+
+func fixed(d decimal.Decimal, decimals int32) string {
+	return d.StringFixed(decimals)
+}
+
+// impuesto writes a, with the decimals of the concepts or of the document. The transfers are written with
+// base, factor and rate, the withholdings of the document only with their amount
+func impuesto(a Amount, decimals int32, document bool) xmlImpuesto {
+	x := xmlImpuesto{Impuesto: a.Tax.Tax}
+
+	if document && a.Withheld {
+		x.Importe = fixed(a.Amount, decimals)
+
+		return x
+	}
+
+	x.Base, x.TipoFactor = fixed(a.Base, decimals), string(a.Factor)
+
+	if a.Factor != Exento {
+		x.TasaOCuota = fixed(a.Rate, 6)
+		x.Importe = fixed(a.Amount, decimals)
+	}
+
+	return x
+}
+
+func (c *Calculated) impuestos() *xmlImpuestos {
+	if len(c.Transfers) == 0 && len(c.Withholdings) == 0 {
+		return nil
+	}
+
+	decimals := c.currencyDecimals()
+	x := &xmlImpuestos{}
+
+	for _, a := range c.Withholdings {
+		x.Retenciones = append(x.Retenciones, impuesto(a, decimals, true))
+	}
+
+	exempt := true
+
+	for _, a := range c.Transfers {
+		x.Traslados = append(x.Traslados, impuesto(a, decimals, true))
+		exempt = exempt && a.Factor == Exento
+	}
+
+	if len(c.Withholdings) > 0 {
+		x.TotalImpuestosRetenidos = fixed(c.Totals.Withheld, decimals)
+	}
+
+	// the total is omitted when every transfer is exempt
+	if len(c.Transfers) > 0 && !exempt {
+		x.TotalImpuestosTrasladados = fixed(c.Totals.Transferred, decimals)
+	}
+
+	return x
+}
+
+func (c *Calculated) comprobante() xmlComprobante {
+	decimals, lineDecimals := c.currencyDecimals(), c.decimals()
+
+	x := xmlComprobante{
+		Xmlns:             Namespace,
+		Version:           "4.0",
+		Serie:             c.Series,
+		Folio:             c.Folio,
+		Fecha:             c.Date.Format(dateLayout),
+		SubTotal:          fixed(c.Totals.SubTotal, decimals),
+		Moneda:            c.currency(),
+		Total:             fixed(c.Totals.Total, decimals),
+		TipoDeComprobante: "I",
+		Exportacion:       "01",
+		LugarExpedicion:   c.PlaceOfIssue,
+		Emisor:            xmlEmisor{Rfc: c.Issuer.RFC, Nombre: c.Issuer.Name, RegimenFiscal: c.Issuer.Regime},
+		Receptor: xmlReceptor{
+			Rfc:                     c.Receiver.RFC,
+			Nombre:                  c.Receiver.Name,
+			DomicilioFiscalReceptor: c.Receiver.PostalCode,
+			RegimenFiscalReceptor:   c.Receiver.Regime,
+			UsoCFDI:                 c.Receiver.Use,
+		},
+		Impuestos: c.impuestos(),
+	}
+
+	if c.Totals.Discount.IsPositive() {
+		x.Descuento = fixed(c.Totals.Discount, decimals)
+	}
+
+	for _, l := range c.Lines {
+		cx := xmlConcepto{
+			ClaveProdServ: l.ProductKey,
+			Cantidad:      l.Qty.String(),
+			ClaveUnidad:   l.UnitKey,
+			Descripcion:   l.Description,
+			ValorUnitario: l.UnitValue.String(),
+			Importe:       fixed(l.Amount, lineDecimals),
+			ObjetoImp:     "01",
+		}
+
+		if l.Discount.IsPositive() {
+			cx.Descuento = fixed(l.Discount, lineDecimals)
+		}
+
+		if len(l.Transfers) > 0 || len(l.Withholdings) > 0 {
+			cx.ObjetoImp = "02"
+			cx.Impuestos = &xmlConceptoImpuestos{}
+
+			for _, a := range l.Transfers {
+				cx.Impuestos.Traslados = append(cx.Impuestos.Traslados, impuesto(a, lineDecimals, false))
+			}
+
+			for _, a := range l.Withholdings {
+				cx.Impuestos.Retenciones = append(cx.Impuestos.Retenciones, impuesto(a, lineDecimals, false))
+			}
+		}
+
+		x.Conceptos = append(x.Conceptos, cx)
+	}
+
+	return x
+}
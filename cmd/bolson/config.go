@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/profe-ajedrez/bolson"
+)
+
+// configFlags are the flags configuring the taxes and discounts
+type configFlags struct {
+	file      string
+	taxes     listFlag
+	discounts listFlag
+}
+
+func (c *configFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.file, "config", "", "JSON file with the taxes and discounts")
+	fs.Var(&c.taxes, "tax", "tax as value:mode:stage[:base], could be repeated")
+	fs.Var(&c.discounts, "discount", "discount as value:mode, could be repeated")
+}
+
+// config returns the configuration of the file, if any, with the taxes and discounts of the flags added
+func (c *configFlags) config() (bolson.Config, error) {
+	var cfg bolson.Config
+
+	if c.file != "" {
+		data, err := os.ReadFile(c.file)
+
+		if err != nil {
+			return cfg, err
+		}
+
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("reading %s: %w", c.file, err)
+		}
+	}
+
+	for _, v := range c.taxes {
+		t, err := bolson.ParseTaxConfig(v)
+
+		if err != nil {
+			return cfg, err
+		}
+
+		cfg.Taxes = append(cfg.Taxes, t)
+	}
+
+	for _, v := range c.discounts {
+		d, err := bolson.ParseDiscountConfig(v)
+
+		if err != nil {
+			return cfg, err
+		}
+
+		cfg.Discounts = append(cfg.Discounts, d)
+	}
+
+	return cfg, nil
+}
+
+func (c *configFlags) bolson() (bolson.Bolson, error) {
+	cfg, err := c.config()
+
+	if err != nil {
+		return bolson.Bolson{}, err
+	}
+
+	return bolson.NewFromConfig(cfg)
+}
+
+// listFlag is a flag which could be repeated
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/shopspring/decimal"
+)
+
+// runLine calculates one line with the origin indicated by command
+func runLine(command string, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var (
+		cfg    configFlags
+		value  = fs.String("value", "", "unit value, brute or brute without discount, depending on the command")
+		qty    = fs.String("qty", "1", "quantity")
+		maxD   = fs.String("max-discount", "100", "max discount percentage allowed")
+		from   = fs.String("from", "uv", "explain only: known value, uv, brute or brute-wd")
+		format = fs.String("format", "table", "output format, table or json")
+		scale  = fs.Int("scale", -1, "decimal places to round the results, not rounded when negative")
+	)
+
+	cfg.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	b, err := cfg.bolson()
+
+	if err != nil {
+		return err
+	}
+
+	line := bolson.Line{}
+
+	if line.Value, err = decimal.NewFromString(*value); err != nil {
+		return fmt.Errorf("invalid -value %q: %w", *value, err)
+	}
+
+	if line.Qty, err = decimal.NewFromString(*qty); err != nil {
+		return fmt.Errorf("invalid -qty %q: %w", *qty, err)
+	}
+
+	if line.MaxDiscount, err = decimal.NewFromString(*maxD); err != nil {
+		return fmt.Errorf("invalid -max-discount %q: %w", *maxD, err)
+	}
+
+	switch command {
+	case "calc":
+		line.From = bolson.FromUnitValue
+	case "from-brute":
+		line.From = bolson.FromBrute
+	case "from-brute-wd":
+		line.From = bolson.FromBruteWD
+	case "explain":
+		if line.From, err = bolson.ParseOrigin(*from); err != nil {
+			return err
+		}
+
+		explanation, err := b.Explain(line)
+
+		if err != nil {
+			return err
+		}
+
+		if *scale >= 0 {
+			explanation.Bag = explanation.Bag.Round(int32(*scale))
+
+			for i := range explanation.Steps {
+				explanation.Steps[i].Value = explanation.Steps[i].Value.Round(int32(*scale))
+			}
+		}
+
+		return write(stdout, *format, explanation, func(w *tabwriter.Writer) {
+			for _, s := range explanation.Steps {
+				fmt.Fprintf(w, "%s\t%s\n", s.Label, s.Value)
+			}
+		})
+	}
+
+	calc, err := b.CalculateLine(line)
+
+	if err != nil {
+		return err
+	}
+
+	if *scale >= 0 {
+		calc = calc.Round(int32(*scale))
+	}
+
+	return write(stdout, *format, calc, func(w *tabwriter.Writer) {
+		writeBag(w, calc)
+	})
+}
+
+// write writes v as JSON or as the table written by table
+func write(out io.Writer, format string, v any, table func(w *tabwriter.Writer)) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "table":
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		table(w)
+		return w.Flush()
+	}
+
+	return fmt.Errorf("unknown format %q, use table or json", format)
+}
+
+// writeBag writes the values of calc in columns with and without discount
+func writeBag(w *tabwriter.Writer, calc bolson.Bag) {
+	fmt.Fprintln(w, "\twith discount\twithout discount")
+	fmt.Fprintf(w, "unit value\t%s\t%s\n", calc.WithDiscount.UnitValue, calc.WithoutDiscount.UnitValue)
+	fmt.Fprintf(w, "net\t%s\t%s\n", calc.WithDiscount.Net, calc.WithoutDiscount.Net)
+	fmt.Fprintf(w, "tax\t%s\t%s\n", calc.WithDiscount.Tax, calc.WithoutDiscount.Tax)
+	fmt.Fprintf(w, "brute\t%s\t%s\n", calc.WithDiscount.Brute, calc.WithoutDiscount.Brute)
+	fmt.Fprintf(w, "discount %%\t%s\t\n", calc.WithDiscount.Discount)
+	fmt.Fprintf(w, "discounted value\t%s\t\n", calc.WithDiscount.DiscountedValue)
+	fmt.Fprintf(w, "discounted value brute\t%s\t\n", calc.WithDiscount.DiscountedValueBrute)
+}
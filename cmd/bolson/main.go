@@ -0,0 +1,83 @@
+// Command bolson calculates lines from the terminal.
+//
+// Usage:
+//
+//	bolson <command> [flags]
+//
+// The commands are:
+//
+//	calc           calculates a line from its unit value
+//	from-brute     calculates a line from its brute value with discounts
+//	from-brute-wd  calculates a line from its brute value without discounts
+//	explain        shows the intermediate values of a calculation
+//	reprice        recalculates the rows of a CSV or JSON Lines file
+//	serve          serves the calculations as JSON endpoints
+//
+// Taxes are given as -tax value:mode:stage[:base] and discounts as -discount value:mode, both can be
+// repeated, or read with -config from a JSON file with the format of [bolson.Config]. Modes, stages and
+// bases could be given by number or by name:
+//
+//	tax modes       0 percentual, 1 line, 2 unit
+//	tax stages      0 taxable, 1 tax, 2 ignorable
+//	tax bases       0 discounted, 1 list
+//	discount modes  0 percentual, 1 line, 2 unit
+//
+// Example:
+//
+//	bolson calc -value 100 -qty 3 -tax 19:percentual:taxable -discount 10:percentual -format table
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+const usage = `usage: bolson <command> [flags]
+
+commands:
+  calc           calculates a line from its unit value
+  from-brute     calculates a line from its brute value with discounts
+  from-brute-wd  calculates a line from its brute value without discounts
+  explain        shows the intermediate values of a calculation
+  reprice        recalculates the rows of a CSV or JSON Lines file
+  serve          serves the calculations as JSON endpoints
+
+run bolson <command> -h to see the flags of a command
+`
+
+// run executes the command in args, returning the exit code
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	var err error
+
+	switch args[0] {
+	case "calc", "from-brute", "from-brute-wd", "explain":
+		err = runLine(args[0], args[1:], stdout, stderr)
+	case "reprice":
+		err = runReprice(args[1:], stdin, stdout, stderr)
+	case "serve":
+		err = runServe(args[1:], stderr)
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/profe-ajedrez/bolson"
+)
+
+func TestRun(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.json")
+	_ = os.WriteFile(config, []byte(`{"taxes":[{"value":"19","mode":0,"stage":0}]}`), 0o600)
+
+	testCases := []struct {
+		args     []string
+		code     int
+		expected string
+	}{
+		{[]string{"calc", "-value", "100", "-qty", "3", "-tax", "19:percentual:taxable", "-discount", "10:0", "-format", "json"}, 0, `"brute": "321.3"`},
+		{[]string{"from-brute", "-value", "321.3", "-qty", "3", "-config", config, "-discount", "10:percentual", "-scale", "2", "-format", "json"}, 0, `"unitValue": "100"`},
+		{[]string{"from-brute-wd", "-value", "357", "-qty", "3", "-tax", "19:0:0"}, 0, "unit value"},
+		{[]string{"explain", "-value", "100", "-tax", "19:0:0"}, 0, "taxes over taxables"},
+		{[]string{"explain", "-from", "brute-wd", "-value", "119", "-qty", "0", "-tax", "19:0:0"}, 1, "ErrNegativeQty"},
+		{[]string{"calc", "-value", "100", "-tax", "19:0:9"}, 1, "invalid tax"},
+		{[]string{"calc", "-value", "x"}, 1, "invalid -value"},
+		{[]string{"nope"}, 2, "unknown command"},
+		{nil, 2, "usage"},
+	}
+
+	for i, tc := range testCases {
+		var stdout, stderr bytes.Buffer
+
+		code := run(tc.args, nil, &stdout, &stderr)
+
+		if code != tc.code || !strings.Contains(stdout.String()+stderr.String(), tc.expected) {
+			t.Logf("Fail test case[%d] --- expected %d %q --- got %d %s %s", i, tc.code, tc.expected, code, stdout.String(), stderr.String())
+			t.FailNow()
+		}
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := run([]string{"calc", "-value", "100", "-tax", "19:0:0", "-format", "json"}, nil, &stdout, &stderr); code != 0 {
+		t.Log(stderr.String())
+		t.FailNow()
+	}
+
+	var calc bolson.Bag
+
+	if err := json.Unmarshal(stdout.Bytes(), &calc); err != nil || calc.WithDiscount.Brute.String() != "119" {
+		t.Logf("expected brute 119, got %v %v", calc.WithDiscount.Brute, err)
+		t.FailNow()
+	}
+}
+
+func TestRunReprice(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in.csv")
+	out := filepath.Join(dir, "out.jsonl")
+
+	_ = os.WriteFile(in, []byte("value,qty\n100,3\n-1,1\n"), 0o600)
+
+	var stdout, stderr bytes.Buffer
+
+	if code := run([]string{"reprice", "-in", in, "-out", out, "-tax", "19:0:0"}, nil, &stdout, &stderr); code != 0 {
+		t.Log(stderr.String())
+		t.FailNow()
+	}
+
+	data, _ := os.ReadFile(out)
+
+	if !strings.Contains(string(data), `"brute":"357"`) || !strings.Contains(stderr.String(), "2 rows, 1 errors") {
+		t.Logf("unexpected output %s %s", data, stderr.String())
+		t.FailNow()
+	}
+
+	stdout.Reset()
+
+	if code := run([]string{"reprice", "-input-format", "jsonl", "-output-format", "csv"}, strings.NewReader(`{"value":"10"}`), &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "unit_value_wd") {
+		t.Logf("unexpected output %s %s", stdout.String(), stderr.String())
+		t.FailNow()
+	}
+}
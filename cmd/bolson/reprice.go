@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/profe-ajedrez/bolson/reprice"
+)
+
+// runReprice recalculates the rows of a CSV or JSON Lines file
+func runReprice(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("reprice", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var (
+		cfg          configFlags
+		in           = fs.String("in", "-", "input file, - for stdin")
+		out          = fs.String("out", "-", "output file, - for stdout")
+		inputFormat  = fs.String("input-format", "", "csv or jsonl, by default from the extension of -in, or csv")
+		outputFormat = fs.String("output-format", "", "csv or jsonl, by default from the extension of -out, or the input format")
+		profiles     = fs.String("profiles", "", "JSON file with the configurations by profile name")
+		scale        = fs.Int("scale", -1, "decimal places to round the results, not rounded when negative")
+	)
+
+	cfg.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	opts := reprice.Options{Profiles: reprice.Profiles{}, Round: *scale >= 0, Scale: int32(*scale)}
+
+	if *profiles != "" {
+		data, err := os.ReadFile(*profiles)
+
+		if err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal(data, &opts.Profiles); err != nil {
+			return fmt.Errorf("reading %s: %w", *profiles, err)
+		}
+	}
+
+	def, err := cfg.config()
+
+	if err != nil {
+		return err
+	}
+
+	if len(def.Taxes) > 0 || len(def.Discounts) > 0 {
+		opts.Profiles[""] = def
+	}
+
+	inFormat := format(*inputFormat, *in, "csv")
+	outFormat := format(*outputFormat, *out, inFormat)
+
+	src := stdin
+
+	if *in != "-" {
+		f, err := os.Open(*in)
+
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+		src = f
+	}
+
+	dst := stdout
+
+	if *out != "-" {
+		f, err := os.Create(*out)
+
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+		dst = f
+	}
+
+	var r reprice.Reader
+
+	switch inFormat {
+	case "csv":
+		if r, err = reprice.NewCSVReader(src); err != nil {
+			return err
+		}
+	case "jsonl":
+		r = reprice.NewJSONLReader(src)
+	default:
+		return reprice.ErrUnknownFormat(inFormat)
+	}
+
+	var w reprice.Writer
+
+	switch outFormat {
+	case "csv":
+		w = reprice.NewCSVWriter(dst)
+	case "jsonl":
+		w = reprice.NewJSONLWriter(dst)
+	default:
+		return reprice.ErrUnknownFormat(outFormat)
+	}
+
+	sum, err := reprice.Reprice(context.Background(), r, w, opts)
+
+	fmt.Fprintf(stderr, "%d rows, %d errors\n", sum.Rows, sum.Errors)
+
+	return err
+}
+
+// format returns the explicit format, or the one of the extension of file, or def
+func format(explicit string, file string, def string) string {
+	if explicit != "" {
+		return strings.ToLower(explicit)
+	}
+
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".csv":
+		return "csv"
+	case ".jsonl", ".ndjson":
+		return "jsonl"
+	}
+
+	return def
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/profe-ajedrez/bolson/httpapi"
+)
+
+// runServe serves the JSON endpoints of the httpapi package until failing
+func runServe(args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	var (
+		addr    = fs.String("addr", ":8080", "address to listen")
+		prefix  = fs.String("prefix", "", "path prefix of the endpoints, as /api")
+		maxBody = fs.Int64("max-body", httpapi.DefaultMaxBodyBytes, "max size of the requests in bytes")
+	)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var handler http.Handler = httpapi.NewHandler(httpapi.Options{MaxBodyBytes: *maxBody})
+
+	if *prefix != "" {
+		handler = http.StripPrefix(*prefix, handler)
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+
+	fmt.Fprintf(stderr, "listening on %s\n", *addr)
+
+	return srv.ListenAndServe()
+}
@@ -0,0 +1,132 @@
+package bolson
+
+import (
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// TaxDetail is the amount of a tax registered with a code, as the taxes identified by a tax authority
+type TaxDetail struct {
+	Code  string          `json:"code"`
+	Value decimal.Decimal `json:"value"`
+	Mode  tax.Mode        `json:"mode"`
+	Stage tax.Stage       `json:"stage"`
+	Base  tax.Base        `json:"base"`
+
+	// Taxable is the value of the line over which the tax was calculated
+	Taxable decimal.Decimal `json:"taxable"`
+	Amount  decimal.Decimal `json:"amount"`
+
+	// TaxableWD and AmountWD are the same values without discount
+	TaxableWD decimal.Decimal `json:"taxableWD"`
+	AmountWD  decimal.Decimal `json:"amountWD"`
+}
+
+// Round rounds the values of d to scale
+func (d TaxDetail) Round(scale int32) TaxDetail {
+	d.Taxable = d.Taxable.Round(scale)
+	d.Amount = d.Amount.Round(scale)
+	d.TaxableWD = d.TaxableWD.Round(scale)
+	d.AmountWD = d.AmountWD.Round(scale)
+
+	return d
+}
+
+// AddCodedTax registers a tax identified by code, whose amount is reported apart in [Bag.Taxes] besides
+// being added to the taxes of the line. The conditional taxes are reported too when they are applied
+//
+//	err := b.AddCodedTax("14", decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase)
+func (b Bolson) AddCodedTax(code string, value decimal.Decimal, mode tax.Mode, stage tax.Stage, base tax.Base) error {
+	return b.addCoded(tax.Rule{Code: code, Value: value, Mode: mode, Stage: stage, Base: base})
+}
+
+func (b Bolson) addCoded(rule tax.Rule) error {
+	if rule.Code == "" {
+		return tax.ErrInvalidTaxCode(rule.Code)
+	}
+
+	if err := b.AddTaxOverBase(rule.Value, rule.Mode, rule.Stage, rule.Base); err != nil {
+		return err
+	}
+
+	b.settings.coded = append(b.settings.coded, rule)
+
+	return nil
+}
+
+// detailed returns calculation adding to its results the details of the coded taxes
+func detailed(qty decimal.Decimal, calculation func(Bolson) (Bag, error)) func(Bolson) (Bag, error) {
+	return func(p Bolson) (Bag, error) {
+		calc, err := calculation(p)
+
+		if err != nil || len(p.settings.coded) == 0 {
+			return calc, err
+		}
+
+		calc.Taxes = p.details(calc, qty)
+
+		return calc, nil
+	}
+}
+
+// details calculates each coded tax over the unit values of calc
+func (b Bolson) details(calc Bag, qty decimal.Decimal) []TaxDetail {
+	details := make([]TaxDetail, 0, len(b.settings.coded))
+
+	for _, r := range b.settings.coded {
+		h, uv := b.taxHandler, calc.WithDiscount.UnitValue
+
+		if r.Base == tax.ListPriceBase {
+			h, uv = b.listTaxHandler, calc.WithoutDiscount.UnitValue
+		}
+
+		d := TaxDetail{Code: r.Code, Value: r.Value, Mode: r.Mode, Stage: r.Stage, Base: r.Base}
+
+		d.Taxable, d.Amount = codedTax(h, r, uv, qty)
+		d.TaxableWD, d.AmountWD = codedTax(h, r, calc.WithoutDiscount.UnitValue, qty)
+
+		details = append(details, d)
+	}
+
+	return details
+}
+
+// codedTax calculates the tax of r alone over the unit value uv, as its stage of h does
+func codedTax(h *tax.Handler, r tax.Rule, uv decimal.Decimal, qty decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	taxable := uv
+
+	if r.Stage == tax.OverTax {
+		overTaxables, _ := h.OverTaxables.Clone().Tax(uv, qty)
+		taxable = uv.Add(overTaxables.Div(qty))
+	}
+
+	switch r.Mode {
+	case tax.PercentualMode:
+		return taxable.Mul(qty), taxable.Mul(r.Value).Div(numbers.Hundred).Mul(qty)
+	case tax.AmountUnitMode:
+		return taxable.Mul(qty), r.Value.Mul(qty)
+	}
+
+	return taxable.Mul(qty), r.Value
+}
+
+// mapDetails returns a copy of details with its money values transformed by f
+func mapDetails(details []TaxDetail, f func(decimal.Decimal) decimal.Decimal) []TaxDetail {
+	if details == nil {
+		return nil
+	}
+
+	r := make([]TaxDetail, len(details))
+
+	for i, d := range details {
+		d.Taxable = f(d.Taxable)
+		d.Amount = f(d.Amount)
+		d.TaxableWD = f(d.TaxableWD)
+		d.AmountWD = f(d.AmountWD)
+
+		r[i] = d
+	}
+
+	return r
+}
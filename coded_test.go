@@ -0,0 +1,107 @@
+package bolson
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+func TestAddCodedTax(t *testing.T) {
+	b := New()
+
+	_ = b.AddCodedTax("14", decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase)
+	_ = b.AddCodedTax("27", decimal.NewFromInt(10), tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase)
+	_ = b.AddCodedTax("ot", decimal.NewFromInt(5), tax.PercentualMode, tax.OverTax, tax.DiscountedBase)
+	_ = b.AddCodedTax("unit", decimal.NewFromInt(2), tax.AmountUnitMode, tax.OverTaxIgnorable, tax.DiscountedBase)
+	_ = b.AddCodedTax("list", decimal.NewFromInt(3), tax.PercentualMode, tax.OverTaxable, tax.ListPriceBase)
+	_ = b.AddTax(decimal.NewFromInt(1), tax.AmountLineMode, tax.OverTaxIgnorable)
+	_ = b.AddDiscount(decimal.NewFromInt(10), discount.Percentual)
+
+	calc, err := b.Calculate(decimal.NewFromInt(100), decimal.NewFromInt(3), decimal.NewFromInt(100))
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	calc = calc.Round(6)
+
+	expected := map[string][2]string{
+		"14":   {"270", "51.3"},
+		"27":   {"270", "27"},
+		"ot":   {"348.3", "17.415"},
+		"unit": {"270", "6"},
+		"list": {"300", "9"},
+	}
+
+	sum := decimal.NewFromInt(1) // the tax without code
+
+	for _, d := range calc.Taxes {
+		e := expected[d.Code]
+
+		if d.Taxable.String() != e[0] || d.Amount.String() != e[1] {
+			t.Logf("expected %s with taxable %s and amount %s, got %v", d.Code, e[0], e[1], d)
+			t.FailNow()
+		}
+
+		sum = sum.Add(d.Amount)
+	}
+
+	if len(calc.Taxes) != len(expected) || !sum.Equal(calc.WithDiscount.Tax) {
+		t.Logf("expected the details adding %v, got %v", calc.WithDiscount.Tax, sum)
+		t.FailNow()
+	}
+
+	if err := b.AddCodedTax("", decimal.NewFromInt(1), tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase); !errors.Is(err, tax.ErrInvalidCode) {
+		t.Logf("expected error for empty code, got %v", err)
+		t.FailNow()
+	}
+}
+
+func TestCodedTaxesFromBruteAndConditions(t *testing.T) {
+	b := New()
+
+	_ = b.AddCodedTax("14", decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase)
+	_ = b.AddConditionalTax(tax.Rule{Code: "lux", Value: decimal.NewFromInt(15), Mode: tax.PercentualMode, Stage: tax.OverTaxable, When: tax.UnitValueOver(decimal.NewFromInt(900))})
+
+	calc, err := b.CalculateFromBrute(decimal.NewFromInt(2680), decimal.NewFromInt(2), decimal.NewFromInt(100))
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	calc = calc.Round(2)
+
+	js, _ := json.Marshal(calc.Taxes)
+
+	if string(js) != `[{"code":"14","value":"19","mode":0,"stage":0,"base":0,"taxable":"2000","amount":"380","taxableWD":"2000","amountWD":"380"},{"code":"lux","value":"15","mode":0,"stage":0,"base":0,"taxable":"2000","amount":"300","taxableWD":"2000","amountWD":"300"}]` {
+		t.Logf("unexpected details %s", js)
+		t.FailNow()
+	}
+
+	// the conditional tax is not left registered
+	if len(b.settings.coded) != 1 {
+		t.Logf("expected one coded tax registered, got %d", len(b.settings.coded))
+		t.FailNow()
+	}
+
+	doc := NewDocument(calc, calc)
+
+	if len(doc.Totals.Taxes) != 2 || doc.Totals.Taxes[0].Amount.String() != "760" || doc.Totals.Taxes[1].Taxable.String() != "4000" {
+		t.Logf("unexpected totals %v", doc.Totals.Taxes)
+		t.FailNow()
+	}
+
+	cn, _ := NewCreditNote(calc, decimal.NewFromInt(2), 0)
+	reversal, _ := cn.ReturnQty(decimal.NewFromInt(1))
+
+	if reversal.Taxes[0].Amount.String() != "190" || cn.Remaining().Taxes[1].Amount.String() != "150" {
+		t.Logf("unexpected reversal %v", reversal.Taxes)
+		t.FailNow()
+	}
+}
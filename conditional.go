@@ -0,0 +1,121 @@
+package bolson
+
+import (
+	"strings"
+
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// AddConditionalTax registers a tax which is only applied in the calculations where its predicate holds.
+//
+// The codes of the conditional taxes applied in a calculation are reported in [Bag.Fired]
+//
+//	 b := Bolson.New()
+//
+//	 // a luxury tax of 15% when the unit value is over 2500000
+//	 err := b.AddConditionalTax(tax.Rule{
+//		    Code:  "luxury",
+//		    Value: decimal.NewFromInt(15),
+//		    Mode:  tax.PercentualMode,
+//		    Stage: tax.OverTaxable,
+//		    When:  tax.UnitValueOver(decimal.NewFromInt(2500000)),
+//	 })
+func (b Bolson) AddConditionalTax(rule tax.Rule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	b.settings.rules = append(b.settings.rules, rule)
+	return nil
+}
+
+// SetAttributes sets the attributes of the lines to calculate, like category, customer segment
+// or date, which are used to evaluate the conditional taxes.
+//
+// UnitValue and Qty are ignored, because they are taken from each calculation
+func (b Bolson) SetAttributes(attrs tax.Attributes) {
+	b.settings.attributes = attrs
+}
+
+// conditioned runs calculation over a copy of b with the conditional taxes whose predicates hold registered.
+//
+// When the unit value is unknown, as when calculating from brute values, it depends on the conditional
+// taxes registered, so they are evaluated over the unit value each set of them gives until the set agrees
+// with it. Sets which alternate without agreeing are rejected
+func (b Bolson) conditioned(unitValue *decimal.Decimal, qty decimal.Decimal, calculation func(Bolson) (Bag, error)) (Bag, error) {
+	if len(b.settings.rules) == 0 {
+		return calculation(b)
+	}
+
+	attrs := b.settings.attributes
+	attrs.Qty = qty
+
+	if unitValue != nil {
+		attrs.UnitValue = *unitValue
+
+		return b.fired(tax.Fired(b.settings.rules, attrs), calculation)
+	}
+
+	calc, err := calculation(b)
+	key := strings.Repeat("0", len(b.settings.rules))
+	seen := map[string]bool{key: true}
+
+	for err == nil {
+		attrs.UnitValue = calc.WithoutDiscount.UnitValue
+		fired, next := b.fire(attrs)
+
+		if next == key {
+			return calc, nil
+		}
+
+		if seen[next] {
+			return Bag{}, ErrUnstableConditions(attrs.UnitValue)
+		}
+
+		seen[next], key = true, next
+		calc, err = b.fired(fired, calculation)
+	}
+
+	return calc, err
+}
+
+// fired runs calculation over a copy of b with the rules fired registered
+func (b Bolson) fired(fired []tax.Rule, calculation func(Bolson) (Bag, error)) (Bag, error) {
+	if len(fired) == 0 {
+		return calculation(b)
+	}
+
+	p := b.clone()
+	codes := make([]string, 0, len(fired))
+
+	for _, rule := range fired {
+		if err := p.addCoded(rule); err != nil {
+			return Bag{}, err
+		}
+
+		codes = append(codes, rule.Code)
+	}
+
+	calc, err := calculation(p)
+	calc.Fired = codes
+
+	return calc, err
+}
+
+// fire returns the rules of b whose predicates hold for attrs, and a key identifying them
+func (b Bolson) fire(attrs tax.Attributes) ([]tax.Rule, string) {
+	fired := make([]tax.Rule, 0, len(b.settings.rules))
+	key := make([]byte, len(b.settings.rules))
+
+	for i, r := range b.settings.rules {
+		key[i] = '0'
+
+		if r.When(attrs) {
+			key[i] = '1'
+			fired = append(fired, r)
+		}
+	}
+
+	return fired, string(key)
+}
@@ -0,0 +1,163 @@
+package bolson
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+func TestConditionalTax(t *testing.T) {
+	b := New()
+
+	_ = b.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable)
+
+	err := b.AddConditionalTax(tax.Rule{
+		Code:  "luxury",
+		Value: decimal.NewFromInt(15),
+		Mode:  tax.PercentualMode,
+		Stage: tax.OverTaxable,
+		When: tax.All(
+			tax.UnitValueOver(decimal.NewFromInt(1000)),
+			tax.InCategory("jewelry"),
+			tax.Between(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}),
+		),
+	})
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if err = b.AddConditionalTax(tax.Rule{Code: "no predicate", Value: decimal.NewFromInt(1)}); err == nil {
+		t.Log("this should be failed because a rule without predicate was passed to AddConditionalTax")
+		t.FailNow()
+	}
+
+	if err = b.AddConditionalTax(tax.Rule{Value: decimal.NewFromInt(1), When: tax.QtyOver(decimal.Zero)}); !errors.Is(err, tax.ErrInvalidCode) {
+		t.Logf("this should be failed with %v because a rule without code was passed to AddConditionalTax --- got %v", tax.ErrInvalidCode, err)
+		t.FailNow()
+	}
+
+	b.SetAttributes(tax.Attributes{Category: "jewelry", Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)})
+
+	qty := decimal.NewFromInt(2)
+	maxDiscount := decimal.NewFromInt(100)
+
+	testCases := []struct {
+		unitValue int64
+		tax       string
+		fired     int
+	}{
+		{unitValue: 1000, tax: "380", fired: 0},
+		{unitValue: 2000, tax: "1360", fired: 1},
+	}
+
+	for i, tc := range testCases {
+		calc, err := b.Calculate(decimal.NewFromInt(tc.unitValue), qty, maxDiscount)
+
+		if err != nil {
+			t.Logf("Fail test case[%d] --- %v", i, err)
+			t.FailNow()
+		}
+
+		if calc.WithDiscount.Tax.String() != tc.tax || len(calc.Fired) != tc.fired {
+			t.Logf("Fail test case[%d] --- expected tax %v fired %d --- got %v %v", i, tc.tax, tc.fired, calc.WithDiscount.Tax, calc.Fired)
+			t.FailNow()
+		}
+
+		// the conditional tax must not remain registered after the calculation
+		if !b.OverTaxables().Percent().Equal(decimal.NewFromInt(19)) {
+			t.Logf("Fail test case[%d] --- conditional tax leaked into the registry", i)
+			t.FailNow()
+		}
+	}
+
+	calc, err := b.CalculateFromBrute(decimal.NewFromInt(5360), qty, maxDiscount)
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if !calc.WithoutDiscount.UnitValue.Round(6).Equal(decimal.NewFromInt(2000)) || len(calc.Fired) != 1 {
+		t.Logf("Fail from brute --- expected unit value 2000 with luxury tax --- got %v %v", calc.WithoutDiscount.UnitValue, calc.Fired)
+		t.FailNow()
+	}
+}
+
+func TestConditionalTaxThresholdFromBrute(t *testing.T) {
+	b := New()
+
+	_ = b.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable)
+	_ = b.AddConditionalTax(tax.Rule{
+		Code:  "luxury",
+		Value: decimal.NewFromInt(15),
+		Mode:  tax.PercentualMode,
+		Stage: tax.OverTaxable,
+		When:  tax.UnitValueOver(decimal.NewFromInt(1000)),
+	})
+
+	qty := decimal.NewFromInt(1)
+	maxDiscount := decimal.NewFromInt(100)
+
+	// 1400 / 1.34 is 1044.78, over the threshold with the luxury tax
+	calc, err := b.CalculateFromBrute(decimal.NewFromInt(1400), qty, maxDiscount)
+
+	if err != nil || len(calc.Fired) != 1 || !calc.WithoutDiscount.UnitValue.GreaterThan(decimal.NewFromInt(1000)) {
+		t.Logf("Fail --- expected the luxury tax over 1000 --- got %v %v %v", err, calc.WithoutDiscount.UnitValue, calc.Fired)
+		t.FailNow()
+	}
+
+	// 1250 / 1.19 is 1050.42 without the luxury tax, but 1250 / 1.34 is 932.84 with it
+	if _, err = b.CalculateFromBrute(decimal.NewFromInt(1250), qty, maxDiscount); err == nil || !strings.Contains(err.Error(), "[ErrUnstableConditions]") {
+		t.Logf("Fail --- expected unstable conditions --- got %v", err)
+		t.FailNow()
+	}
+
+	// 1100 / 1.19 is 924.37, under the threshold without it
+	calc, err = b.CalculateFromBrute(decimal.NewFromInt(1100), qty, maxDiscount)
+
+	if err != nil || len(calc.Fired) != 0 {
+		t.Logf("Fail --- expected no luxury tax --- got %v %v", err, calc.Fired)
+		t.FailNow()
+	}
+}
+
+func TestConditionalTaxLineAttributes(t *testing.T) {
+	b := New()
+
+	_ = b.AddConditionalTax(tax.Rule{
+		Code:  "luxury",
+		Value: decimal.NewFromInt(15),
+		Mode:  tax.PercentualMode,
+		Stage: tax.OverTaxable,
+		When:  tax.InCategory("jewelry"),
+	})
+
+	b.SetAttributes(tax.Attributes{Category: "jewelry"})
+
+	line := func(category string) Line {
+		l := Line{Value: decimal.NewFromInt(100), Qty: decimal.NewFromInt(1), MaxDiscount: decimal.NewFromInt(100)}
+
+		if category != "" {
+			l.Attributes = &tax.Attributes{Category: category}
+		}
+
+		return l
+	}
+
+	results := b.CalculateBatch(context.Background(), []Line{line("food"), line("jewelry"), line("")}, 2)
+	expected := []int{0, 1, 1}
+
+	for i, r := range results {
+		if r.Err != nil || len(r.Bag.Fired) != expected[i] {
+			t.Logf("Fail line %d --- expected %d fired --- got %v %v", i, expected[i], r.Err, r.Bag.Fired)
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package bolson
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// TaxConfig describes a tax to be registered
+type TaxConfig struct {
+	// Code identifies the tax in [Bag.Taxes] when given
+	Code  string          `json:"code,omitempty"`
+	Value decimal.Decimal `json:"value"`
+	Mode  tax.Mode        `json:"mode"`
+	Stage tax.Stage       `json:"stage"`
+	Base  tax.Base        `json:"base,omitempty"`
+}
+
+// DiscountConfig describes a discount to be registered
+type DiscountConfig struct {
+	Value decimal.Decimal `json:"value"`
+	Mode  discount.Mode   `json:"mode"`
+}
+
+// Config is a serializable configuration of taxes and discounts, as the ones read from files or received
+// by services
+type Config struct {
+	Taxes     []TaxConfig      `json:"taxes,omitempty"`
+	Discounts []DiscountConfig `json:"discounts,omitempty"`
+}
+
+// NewFromConfig returns a [Bolson] with the taxes and discounts of c registered
+func NewFromConfig(c Config) (Bolson, error) {
+	b := New()
+
+	if err := b.Configure(c); err != nil {
+		return b, err
+	}
+
+	return b, nil
+}
+
+// Configure registers the taxes and discounts of c, stopping at the first one failing
+func (b Bolson) Configure(c Config) error {
+	for _, t := range c.Taxes {
+		add := b.AddTaxOverBase
+
+		if t.Code != "" {
+			add = func(value decimal.Decimal, mode tax.Mode, stage tax.Stage, base tax.Base) error {
+				return b.AddCodedTax(t.Code, value, mode, stage, base)
+			}
+		}
+
+		if err := add(t.Value, t.Mode, t.Stage, t.Base); err != nil {
+			return err
+		}
+	}
+
+	for _, d := range c.Discounts {
+		if err := b.AddDiscount(d.Value, d.Mode); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var (
+	taxModeNames      = map[string]string{"percentual": "0", "line": "1", "unit": "2"}
+	taxStageNames     = map[string]string{"taxable": "0", "tax": "1", "ignorable": "2"}
+	taxBaseNames      = map[string]string{"discounted": "0", "list": "1"}
+	discountModeNames = map[string]string{"percentual": "0", "line": "1", "unit": "2"}
+	originNames       = map[string]string{"uv": "0", "brute": "1", "brute-wd": "2"}
+)
+
+// named returns the number named by v in names, or v when it is not a name
+func named(names map[string]string, v string) string {
+	if n, ok := names[strings.ToLower(strings.TrimSpace(v))]; ok {
+		return n
+	}
+
+	return strings.TrimSpace(v)
+}
+
+// ParseTaxConfig parses a tax written as value:mode:stage[:base], where mode, stage and base could be
+// given by number or by name
+//
+//	modes   0 percentual, 1 line, 2 unit
+//	stages  0 taxable, 1 tax, 2 ignorable
+//	bases   0 discounted, 1 list
+func ParseTaxConfig(v string) (TaxConfig, error) {
+	var t TaxConfig
+
+	parts := strings.Split(v, ":")
+
+	if len(parts) < 3 || len(parts) > 4 {
+		return t, fmt.Errorf("invalid tax %q, expected value:mode:stage[:base]", v)
+	}
+
+	var err error
+
+	if t.Value, err = decimal.NewFromString(strings.TrimSpace(parts[0])); err != nil {
+		return t, fmt.Errorf("invalid tax %q: %w", v, err)
+	}
+
+	if t.Mode, err = tax.NewModeFromString(named(taxModeNames, parts[1])); err != nil {
+		return t, fmt.Errorf("invalid tax %q: %w", v, err)
+	}
+
+	if t.Stage, err = tax.NewStageFromString(named(taxStageNames, parts[2])); err != nil {
+		return t, fmt.Errorf("invalid tax %q: %w", v, err)
+	}
+
+	if len(parts) == 4 {
+		n, err := strconv.Atoi(named(taxBaseNames, parts[3]))
+
+		if err != nil {
+			return t, fmt.Errorf("invalid tax %q: %w", v, tax.ErrInvalidTaxBase(parts[3]))
+		}
+
+		if t.Base, err = tax.NewBaseFromInt(n); err != nil {
+			return t, fmt.Errorf("invalid tax %q: %w", v, err)
+		}
+	}
+
+	return t, nil
+}
+
+// ParseDiscountConfig parses a discount written as value:mode, where mode could be given by number or by
+// name: 0 percentual, 1 line, 2 unit
+func ParseDiscountConfig(v string) (DiscountConfig, error) {
+	var d DiscountConfig
+
+	parts := strings.Split(v, ":")
+
+	if len(parts) != 2 {
+		return d, fmt.Errorf("invalid discount %q, expected value:mode", v)
+	}
+
+	var err error
+
+	if d.Value, err = decimal.NewFromString(strings.TrimSpace(parts[0])); err != nil {
+		return d, fmt.Errorf("invalid discount %q: %w", v, err)
+	}
+
+	if d.Mode, err = discount.NewFromString(named(discountModeNames, parts[1])); err != nil {
+		return d, fmt.Errorf("invalid discount %q: %w", v, err)
+	}
+
+	return d, nil
+}
+
+// ParseOrigin parses an origin given by number or by name: 0 uv, 1 brute, 2 brute-wd
+func ParseOrigin(v string) (Origin, error) {
+	n, err := strconv.Atoi(named(originNames, v))
+
+	if err != nil || n < int(FromUnitValue) || n > int(FromBruteWD) {
+		return InvalidOrigin, ErrInvalidOrigin(v)
+	}
+
+	return Origin(n), nil
+}
@@ -0,0 +1,48 @@
+package bolson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewFromConfig(t *testing.T) {
+	var cfg Config
+
+	err := json.Unmarshal([]byte(`{"taxes":[{"value":"19","mode":0,"stage":0},{"value":"3","mode":0,"stage":0,"base":1}],"discounts":[{"value":"10","mode":0}]}`), &cfg)
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	b, err := NewFromConfig(cfg)
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	expected := New()
+	_ = expected.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable)
+	_ = expected.AddTaxOverBase(decimal.NewFromInt(3), tax.PercentualMode, tax.OverTaxable, tax.ListPriceBase)
+	_ = expected.AddDiscount(decimal.NewFromInt(10), discount.Percentual)
+
+	want, _ := expected.Calculate(decimal.NewFromInt(100), decimal.NewFromInt(3), decimal.NewFromInt(100))
+	got, _ := b.Calculate(decimal.NewFromInt(100), decimal.NewFromInt(3), decimal.NewFromInt(100))
+
+	if !got.WithDiscount.Brute.Equal(want.WithDiscount.Brute) {
+		t.Logf("expected %v got %v", want, got)
+		t.FailNow()
+	}
+
+	_, err = NewFromConfig(Config{Taxes: []TaxConfig{{Value: decimal.NewFromInt(1), Mode: tax.PercentualMode, Stage: tax.InvalidStage}}})
+
+	if err == nil {
+		t.Log("expected error for invalid stage")
+		t.FailNow()
+	}
+}
@@ -0,0 +1,153 @@
+package bolson
+
+import (
+	"fmt"
+
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/shopspring/decimal"
+)
+
+// CreditNote reverses a previously calculated line, fully or partially, in proportion to the returned
+// quantity or amount, so the credit notes match what was originally charged even when amount discounts
+// or rounding are involved.
+//
+// The reversals are obtained as the difference between the rounded proportion of the original for
+// all the returns made and the already reversed values, so their sum never goes over the original
+// and returning everything reverses exactly the original values rounded to the scale of the notes
+//
+//	cn, err := bolson.NewCreditNote(calc, decimal.NewFromInt(10), 0)
+//
+//	// the customer returns 3 of the 10 units
+//	reversal, err := cn.ReturnQty(decimal.NewFromInt(3))
+type CreditNote struct {
+	original Bag
+
+	// total is the original rounded to scale, the most which could be reversed
+	total    Bag
+	qty      decimal.Decimal
+	scale    int32
+	returned decimal.Decimal
+	reversed Bag
+}
+
+// NewCreditNote returns a new pointer to a [CreditNote] of the original calculation made over qty units,
+// whose reversals are rounded to scale decimal places
+func NewCreditNote(original Bag, qty decimal.Decimal, scale int32) (*CreditNote, error) {
+	if !qty.IsPositive() {
+		return nil, ErrNegativeReturn(fmt.Sprintf("original quantity %v", qty))
+	}
+
+	return &CreditNote{
+		original: original,
+		total:    proportion(original, numbers.One, scale),
+		qty:      qty,
+		scale:    scale,
+		returned: numbers.Zero.Copy(),
+		reversed: proportion(original, numbers.Zero, scale),
+	}, nil
+}
+
+// ReturnQty returns the reversal for qty returned units
+func (cn *CreditNote) ReturnQty(qty decimal.Decimal) (Bag, error) {
+	if !qty.IsPositive() {
+		return Bag{}, ErrNegativeReturn(fmt.Sprintf("returned quantity %v", qty))
+	}
+
+	return cn.reverse(qty.Div(cn.qty), fmt.Sprintf("returned quantity %v", qty))
+}
+
+// ReturnAmount returns the reversal for the returned amount, expressed as brute with discount
+func (cn *CreditNote) ReturnAmount(brute decimal.Decimal) (Bag, error) {
+	if !brute.IsPositive() {
+		return Bag{}, ErrNegativeReturn(fmt.Sprintf("returned amount %v", brute))
+	}
+
+	remaining := cn.Remaining().WithDiscount.Brute
+
+	if brute.Equal(remaining) {
+		return cn.reverse(numbers.One.Sub(cn.returned), fmt.Sprintf("returned amount %v", brute))
+	}
+
+	if brute.GreaterThan(remaining) {
+		return Bag{}, ErrOverReturn(fmt.Sprintf("returned amount %v   remaining amount %v", brute, remaining))
+	}
+
+	return cn.reverse(brute.Div(cn.total.WithDiscount.Brute), fmt.Sprintf("returned amount %v", brute))
+}
+
+// Reversed returns the sum of the reversals made
+func (cn *CreditNote) Reversed() Bag {
+	return cn.reversed
+}
+
+// Remaining returns the values which could still be reversed
+func (cn *CreditNote) Remaining() Bag {
+	return subtract(cn.total, cn.reversed)
+}
+
+// reverse adds ratio to the returned proportion of the original and returns its reversal
+func (cn *CreditNote) reverse(ratio decimal.Decimal, info string) (Bag, error) {
+	returned := cn.returned.Add(ratio)
+
+	if returned.GreaterThan(numbers.One) {
+		return Bag{}, ErrOverReturn(fmt.Sprintf("%s   already returned %v%% of the original", info, cn.returned.Mul(numbers.Hundred)))
+	}
+
+	target := proportion(cn.original, returned, cn.scale)
+	reversal := subtract(target, cn.reversed)
+
+	cn.returned = returned
+	cn.reversed = target
+
+	return reversal, nil
+}
+
+// proportion returns the money values of b multiplied by ratio and rounded to scale.
+// The brutes are obtained adding the rounded nets and taxes
+func proportion(b Bag, ratio decimal.Decimal, scale int32) Bag {
+	p := b
+
+	p.WithDiscount.Net = b.WithDiscount.Net.Mul(ratio).Round(scale)
+	p.WithDiscount.Tax = b.WithDiscount.Tax.Mul(ratio).Round(scale)
+	p.WithDiscount.Brute = p.WithDiscount.Net.Add(p.WithDiscount.Tax)
+	p.WithDiscount.DiscountedValue = b.WithDiscount.DiscountedValue.Mul(ratio).Round(scale)
+
+	p.WithoutDiscount.Net = b.WithoutDiscount.Net.Mul(ratio).Round(scale)
+	p.WithoutDiscount.Tax = b.WithoutDiscount.Tax.Mul(ratio).Round(scale)
+	p.WithoutDiscount.Brute = p.WithoutDiscount.Net.Add(p.WithoutDiscount.Tax)
+
+	p.WithDiscount.DiscountedValueBrute = p.WithoutDiscount.Brute.Sub(p.WithDiscount.Brute)
+
+	p.Taxes = mapDetails(b.Taxes, func(d decimal.Decimal) decimal.Decimal { return d.Mul(ratio).Round(scale) })
+
+	return p
+}
+
+// subtract returns the money values of a minus the ones of b, keeping the unit values and discount of a
+func subtract(a Bag, b Bag) Bag {
+	r := a
+
+	r.WithDiscount.Net = a.WithDiscount.Net.Sub(b.WithDiscount.Net)
+	r.WithDiscount.Brute = a.WithDiscount.Brute.Sub(b.WithDiscount.Brute)
+	r.WithDiscount.Tax = a.WithDiscount.Tax.Sub(b.WithDiscount.Tax)
+	r.WithDiscount.DiscountedValue = a.WithDiscount.DiscountedValue.Sub(b.WithDiscount.DiscountedValue)
+	r.WithDiscount.DiscountedValueBrute = a.WithDiscount.DiscountedValueBrute.Sub(b.WithDiscount.DiscountedValueBrute)
+
+	r.WithoutDiscount.Net = a.WithoutDiscount.Net.Sub(b.WithoutDiscount.Net)
+	r.WithoutDiscount.Brute = a.WithoutDiscount.Brute.Sub(b.WithoutDiscount.Brute)
+	r.WithoutDiscount.Tax = a.WithoutDiscount.Tax.Sub(b.WithoutDiscount.Tax)
+
+	// the details of b are in the same order, as both come from the same original
+	r.Taxes = mapDetails(a.Taxes, func(d decimal.Decimal) decimal.Decimal { return d })
+
+	for i := range r.Taxes {
+		if i < len(b.Taxes) {
+			r.Taxes[i].Taxable = a.Taxes[i].Taxable.Sub(b.Taxes[i].Taxable)
+			r.Taxes[i].Amount = a.Taxes[i].Amount.Sub(b.Taxes[i].Amount)
+			r.Taxes[i].TaxableWD = a.Taxes[i].TaxableWD.Sub(b.Taxes[i].TaxableWD)
+			r.Taxes[i].AmountWD = a.Taxes[i].AmountWD.Sub(b.Taxes[i].AmountWD)
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,85 @@
+package bolson
+
+import (
+	"testing"
+
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+func TestCreditNote(t *testing.T) {
+	b := New()
+
+	_ = b.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable)
+	_ = b.AddDiscount(decimal.NewFromInt(333), discount.AmountLine)
+
+	qty := decimal.NewFromInt(10)
+	original, err := b.Calculate(decimal.NewFromInt(1000), qty, decimal.NewFromInt(100))
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	cn, err := NewCreditNote(original, qty, 0)
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	testCases := []struct {
+		returned int64
+		net      string
+		tax      string
+	}{
+		{returned: 3, net: "2900", tax: "551"},
+		{returned: 3, net: "2900", tax: "551"},
+		{returned: 4, net: "3867", tax: "735"},
+	}
+
+	for i, tc := range testCases {
+		reversal, err := cn.ReturnQty(decimal.NewFromInt(tc.returned))
+
+		if err != nil {
+			t.Logf("Fail test case[%d] --- %v", i, err)
+			t.FailNow()
+		}
+
+		if reversal.WithDiscount.Net.String() != tc.net || reversal.WithDiscount.Tax.String() != tc.tax {
+			t.Logf("Fail test case[%d] --- expected net %v tax %v --- got %v %v", i, tc.net, tc.tax, reversal.WithDiscount.Net, reversal.WithDiscount.Tax)
+			t.FailNow()
+		}
+	}
+
+	// the notes add up the original rounded to their scale, 9667 + 1837
+	if !cn.Reversed().WithDiscount.Brute.Equal(decimal.NewFromInt(11504)) || !cn.Remaining().WithDiscount.Brute.IsZero() {
+		t.Logf("Fail --- expected the original to be fully reversed --- got %v", cn.Reversed())
+		t.FailNow()
+	}
+
+	if _, err = cn.ReturnQty(decimal.NewFromInt(1)); err == nil {
+		t.Log("this should be failed because everything was already returned")
+		t.FailNow()
+	}
+
+	cn, _ = NewCreditNote(original, qty, 0)
+
+	if _, err = cn.ReturnAmount(decimal.NewFromInt(5000)); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if _, err = cn.ReturnAmount(decimal.NewFromInt(7000)); err == nil {
+		t.Log("this should be failed because the returned amount goes over the original")
+		t.FailNow()
+	}
+
+	reversal, err := cn.ReturnAmount(cn.Remaining().WithDiscount.Brute)
+
+	if err != nil || !cn.Remaining().WithDiscount.Net.IsZero() {
+		t.Logf("Fail --- expected the remaining amount to be reversed --- got %v %v", reversal, err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,136 @@
+package bolson
+
+import (
+	"github.com/profe-ajedrez/bolson/currency"
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// Report contains a calculation in the transaction currency and its values in the reporting currency
+type Report struct {
+	// Transaction contains the values in the transaction currency, rounded with its rules
+	Transaction Bag `json:"transaction"`
+
+	// Reporting contains the values in the reporting currency, rounded with its rules
+	Reporting Bag `json:"reporting"`
+
+	// Rate is the exchange rate used
+	Rate decimal.Decimal `json:"rate"`
+
+	TransactionCurrency string `json:"transactionCurrency"`
+	ReportingCurrency   string `json:"reportingCurrency"`
+}
+
+// RoundIn rounds the values of the bag with the rules of cur.
+//
+// The brutes are obtained adding the rounded nets and taxes, so they keep being consistent
+// after rounding. The discount percentage is not a money value, so it is not rounded
+func (b Bag) RoundIn(cur currency.Currency) Bag {
+	r := b
+
+	r.WithDiscount.Net = cur.Round(b.WithDiscount.Net)
+	r.WithDiscount.Tax = cur.Round(b.WithDiscount.Tax)
+	r.WithDiscount.Brute = r.WithDiscount.Net.Add(r.WithDiscount.Tax)
+	r.WithDiscount.DiscountedValue = cur.Round(b.WithDiscount.DiscountedValue)
+	r.WithDiscount.UnitValue = cur.Round(b.WithDiscount.UnitValue)
+
+	r.WithoutDiscount.Net = cur.Round(b.WithoutDiscount.Net)
+	r.WithoutDiscount.Tax = cur.Round(b.WithoutDiscount.Tax)
+	r.WithoutDiscount.Brute = r.WithoutDiscount.Net.Add(r.WithoutDiscount.Tax)
+	r.WithoutDiscount.UnitValue = cur.Round(b.WithoutDiscount.UnitValue)
+
+	r.WithDiscount.DiscountedValueBrute = r.WithoutDiscount.Brute.Sub(r.WithDiscount.Brute)
+
+	r.Taxes = mapDetails(b.Taxes, cur.Round)
+
+	return r
+}
+
+// Convert converts a calculation made by b in the transaction currency to the reporting currency of conv.
+//
+// When conv.TaxInReporting is set, the line is calculated again over the net without discounts converted
+// and rounded to the reporting currency, with the registered amounts converted too, so the taxes are
+// calculated directly in the reporting currency. Otherwise the values of calc are converted
+func (b Bolson) Convert(calc Bag, qty decimal.Decimal, conv currency.Conversion) (Report, error) {
+	rate, err := conv.Rate()
+
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{
+		Transaction:         calc.RoundIn(conv.From),
+		Rate:                rate,
+		TransactionCurrency: conv.From.Code,
+		ReportingCurrency:   conv.To.Code,
+	}
+
+	if !conv.TaxInReporting {
+		report.Reporting = calc.Scale(rate).RoundIn(conv.To)
+		return report, nil
+	}
+
+	unitValue := calc.WithoutDiscount.UnitValue
+	reportingUnitValue := conv.To.Round(calc.WithoutDiscount.Net.Mul(rate)).Div(qty)
+
+	// calc already was checked against its max discount, and converting doesnt change the discount percentage.
+	// The coded taxes are detailed with the amounts converted too, so all of them are in the reporting currency
+	reporting, err := b.withSettings(&unitValue, qty, func(p Bolson) (Bag, error) {
+		return detailed(qty, func(s Bolson) (Bag, error) {
+			return s.calculate(reportingUnitValue, qty, numbers.Hundred, FromUnitValue)
+		})(p.scaled(rate))
+	})
+
+	if err != nil {
+		return Report{}, err
+	}
+
+	report.Reporting = reporting.RoundIn(conv.To)
+
+	return report, nil
+}
+
+// Scale returns a copy of the bag with its money values multiplied by rate
+func (b Bag) Scale(rate decimal.Decimal) Bag {
+	r := b
+
+	r.WithDiscount.Net = b.WithDiscount.Net.Mul(rate)
+	r.WithDiscount.Brute = b.WithDiscount.Brute.Mul(rate)
+	r.WithDiscount.Tax = b.WithDiscount.Tax.Mul(rate)
+	r.WithDiscount.DiscountedValue = b.WithDiscount.DiscountedValue.Mul(rate)
+	r.WithDiscount.DiscountedValueBrute = b.WithDiscount.DiscountedValueBrute.Mul(rate)
+	r.WithDiscount.UnitValue = b.WithDiscount.UnitValue.Mul(rate)
+
+	r.WithoutDiscount.Net = b.WithoutDiscount.Net.Mul(rate)
+	r.WithoutDiscount.Brute = b.WithoutDiscount.Brute.Mul(rate)
+	r.WithoutDiscount.Tax = b.WithoutDiscount.Tax.Mul(rate)
+	r.WithoutDiscount.UnitValue = b.WithoutDiscount.UnitValue.Mul(rate)
+
+	r.Taxes = mapDetails(b.Taxes, func(d decimal.Decimal) decimal.Decimal { return d.Mul(rate) })
+
+	for i, d := range r.Taxes {
+		if d.Mode != tax.PercentualMode {
+			r.Taxes[i].Value = d.Value.Mul(rate)
+		}
+	}
+
+	return r
+}
+
+// scaled returns a copy of b with the registered amounts, including the ones of the coded taxes, multiplied by rate
+func (b Bolson) scaled(rate decimal.Decimal) Bolson {
+	c := b
+	c.taxHandler = b.taxHandler.Scale(rate)
+	c.listTaxHandler = b.listTaxHandler.Scale(rate)
+	c.discountHandler = b.discountHandler.Scale(rate)
+	c.settings = b.settings.clone()
+
+	for i, r := range c.settings.coded {
+		if r.Mode != tax.PercentualMode {
+			c.settings.coded[i].Value = r.Value.Mul(rate)
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,125 @@
+// Package currency contains utils to convert sales values between currencies,
+// rounding them with the rules of each currency
+package currency
+
+import (
+	"time"
+
+	"github.com/profe-ajedrez/bolson/index"
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/shopspring/decimal"
+)
+
+// Currency describes a currency and its rounding rules
+type Currency struct {
+	// Code is the ISO 4217 code of the currency
+	Code string `json:"code"`
+
+	// Scale is the number of decimal places used by the currency
+	Scale int32 `json:"scale"`
+
+	// Rounding is the direction used to round the values of the currency
+	Rounding numbers.Rounding `json:"rounding"`
+}
+
+// Round rounds d with the rules of the currency
+func (c Currency) Round(d decimal.Decimal) decimal.Decimal {
+	return numbers.Round(d, c.Scale, c.Rounding)
+}
+
+var (
+	CLP = Currency{Code: "CLP", Scale: 0, Rounding: numbers.HalfUp}
+	USD = Currency{Code: "USD", Scale: 2, Rounding: numbers.HalfUp}
+	EUR = Currency{Code: "EUR", Scale: 2, Rounding: numbers.HalfUp}
+	MXN = Currency{Code: "MXN", Scale: 2, Rounding: numbers.HalfUp}
+)
+
+// Source is something able to give the exchange rate between two currencies at a date,
+// so that an amount in from multiplied by the rate is the amount in to
+type Source interface {
+	Rate(from string, to string, date time.Time) (decimal.Decimal, error)
+}
+
+// Conversion describes how to convert a calculation made in the transaction currency
+// to the reporting currency
+type Conversion struct {
+	// From is the currency of the transaction
+	From Currency
+
+	// To is the currency in which the values are reported
+	To Currency
+
+	// Source gives the exchange rate used
+	Source Source
+
+	// Date is the date of the exchange rate
+	Date time.Time
+
+	// TaxInReporting indicates the taxes should be calculated directly over the values converted
+	// to the reporting currency, instead of converting the taxes calculated in the transaction currency
+	TaxInReporting bool
+}
+
+// Rate returns the exchange rate of the conversion
+func (c Conversion) Rate() (decimal.Decimal, error) {
+	if c.From.Code == c.To.Code {
+		return numbers.One.Copy(), nil
+	}
+
+	if c.Source == nil {
+		return numbers.Zero.Copy(), ErrNoSource(c.From.Code + "/" + c.To.Code)
+	}
+
+	return c.Source.Rate(c.From.Code, c.To.Code, c.Date)
+}
+
+var _ Source = &Table{}
+
+// Table implements [Source] using a local table of exchange rates.
+//
+// Each registered rate is valid from its date until the date of the next registered rate
+// of the same pair. When only the inverse pair is registered its inverse rate is used
+type Table struct {
+	rates *index.Table
+}
+
+// NewTable returns a new pointer to an empty [Table]
+func NewTable() *Table {
+	return &Table{
+		rates: index.NewTable(),
+	}
+}
+
+// Set registers the exchange rate from -> to valid from the received date
+func (t *Table) Set(from string, to string, date time.Time, rate decimal.Decimal) error {
+	if err := t.rates.Set(pair(from, to), date, rate); err != nil {
+		return ErrInvalidRate(err)
+	}
+
+	return nil
+}
+
+// Rate implements Source.
+func (t *Table) Rate(from string, to string, date time.Time) (decimal.Decimal, error) {
+	if from == to {
+		return numbers.One.Copy(), nil
+	}
+
+	rate, err := t.rates.Rate(pair(from, to), date)
+
+	if err == nil {
+		return rate, nil
+	}
+
+	inverse, ierr := t.rates.Rate(pair(to, from), date)
+
+	if ierr != nil {
+		return numbers.Zero.Copy(), ErrUnknownRate(err)
+	}
+
+	return numbers.One.Div(inverse), nil
+}
+
+func pair(from string, to string) index.Unit {
+	return index.Unit(from + "/" + to)
+}
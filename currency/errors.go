@@ -0,0 +1,18 @@
+package currency
+
+import "fmt"
+
+// ErrUnknownRate there is no exchange rate for the pair of currencies at the requested date
+func ErrUnknownRate(info any) error {
+	return fmt.Errorf("[ErrUnknownExchangeRate] there is no exchange rate for the currencies at the requested date. %v", info)
+}
+
+// ErrInvalidRate the exchange rate is zero or negative
+func ErrInvalidRate(info any) error {
+	return fmt.Errorf("[ErrInvalidExchangeRate] the exchange rate must be positive. %v", info)
+}
+
+// ErrNoSource a conversion between different currencies has no exchange rate source
+func ErrNoSource(info any) error {
+	return fmt.Errorf("[ErrNoExchangeRateSource] there is no source of exchange rates for the conversion. %v", info)
+}
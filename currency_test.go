@@ -0,0 +1,105 @@
+package bolson
+
+import (
+	"testing"
+	"time"
+
+	"github.com/profe-ajedrez/bolson/currency"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+func TestConvert(t *testing.T) {
+	b := New()
+
+	_ = b.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable)
+	_ = b.AddTax(decimal.NewFromInt(1), tax.AmountLineMode, tax.OverTaxIgnorable)
+
+	qty := decimal.NewFromInt(3)
+	calc, err := b.Calculate(decimal.RequireFromString("10.555"), qty, decimal.NewFromInt(100))
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	date := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	fx := currency.NewTable()
+
+	// only the inverse pair is registered
+	_ = fx.Set("CLP", "USD", date, decimal.NewFromInt(1).Div(decimal.RequireFromString("950.37")))
+
+	if _, err = b.Convert(calc, qty, currency.Conversion{From: currency.USD, To: currency.CLP, Date: date}); err == nil {
+		t.Log("this should be failed because there is no exchange rate source")
+		t.FailNow()
+	}
+
+	for _, taxInReporting := range []bool{false, true} {
+		report, err := b.Convert(calc, qty, currency.Conversion{
+			From:           currency.USD,
+			To:             currency.CLP,
+			Source:         fx,
+			Date:           date,
+			TaxInReporting: taxInReporting,
+		})
+
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+
+		tr := report.Transaction.WithDiscount
+		rp := report.Reporting.WithDiscount
+
+		if tr.Net.String() != "31.67" || tr.Tax.String() != "7.02" || tr.Brute.String() != "38.69" {
+			t.Logf("Fail tax in reporting %v --- unexpected transaction values %v", taxInReporting, tr)
+			t.FailNow()
+		}
+
+		if rp.Net.String() != "30093" || rp.Tax.String() != "6668" || rp.Brute.String() != "36761" {
+			t.Logf("Fail tax in reporting %v --- unexpected reporting values %v", taxInReporting, rp)
+			t.FailNow()
+		}
+	}
+}
+
+func TestConvertCodedTaxInReporting(t *testing.T) {
+	b := New()
+
+	_ = b.AddCodedTax("iva", decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase)
+	_ = b.AddCodedTax("stamp", decimal.NewFromInt(2), tax.AmountLineMode, tax.OverTaxIgnorable, tax.DiscountedBase)
+
+	qty := decimal.NewFromInt(3)
+	calc, err := b.Calculate(decimal.NewFromInt(10), qty, decimal.NewFromInt(100))
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	date := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	fx := currency.NewTable()
+	_ = fx.Set("USD", "CLP", date, decimal.NewFromInt(1000))
+
+	report, err := b.Convert(calc, qty, currency.Conversion{From: currency.USD, To: currency.CLP, Source: fx, Date: date, TaxInReporting: true})
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	rp := report.Reporting
+
+	if rp.WithDiscount.Tax.String() != "7700" || len(rp.Taxes) != 2 {
+		t.Logf("Fail --- unexpected reporting values %v", rp)
+		t.FailNow()
+	}
+
+	// the amount of the stamp is converted, so the details add up to the tax in the reporting currency
+	stamp := rp.Taxes[1]
+
+	if stamp.Amount.String() != "2000" || stamp.Value.String() != "2000" || !rp.Taxes[0].Amount.Add(stamp.Amount).Equal(rp.WithDiscount.Tax) {
+		t.Logf("Fail --- unexpected details %v", rp.Taxes)
+		t.FailNow()
+	}
+}
@@ -1,6 +1,7 @@
 package discount
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -69,6 +70,31 @@ func TestDiscounter(t *testing.T) {
 	fmt.Println(discount)
 }
 
+func TestErrors(t *testing.T) {
+	discounter := discounterTest(t)
+
+	_, _, err := discounter.Compute(decimal.NewFromInt32(100), decimal.NewFromInt(10), decimal.NewFromInt(10))
+
+	if !errors.Is(err, ErrOverMax) {
+		t.Logf("expected an over max discount error, got %v", err)
+		t.FailNow()
+	}
+
+	var e *Error
+
+	if !errors.As(err, &e) || e.Code != CodeOverMaxDiscount || e.Op != OpCompute || e.Field != "discount" {
+		t.Logf("expected a structured error with code, operation and field, got %#v", err)
+		t.FailNow()
+	}
+
+	_, _, err = discounter.Compute(decimal.NewFromInt32(100), decimal.NewFromInt(-1), decimal.NewFromInt(100))
+
+	if !errors.Is(err, ErrNegative) || !errors.As(err, &e) || e.Field != "qty" || e.Value.(decimal.Decimal).String() != "-1" {
+		t.Logf("expected a negative quantity error, got %#v", err)
+		t.FailNow()
+	}
+}
+
 func BenchmarkDiscounter(b *testing.B) {
 	discounter := discounterTest(b)
 
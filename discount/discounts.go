@@ -119,12 +119,43 @@ func NewComputedDiscount() *ComputedDiscount {
 	}
 }
 
+// Clone returns a new pointer to a copy of the discounter
+func (cd *ComputedDiscount) Clone() *ComputedDiscount {
+	c := *cd
+	return &c
+}
+
+// Scale returns a new pointer to a copy of the discounter with its amounts multiplied by rate,
+// as when converting them to another currency. Percentuals are kept
+func (cd *ComputedDiscount) Scale(rate decimal.Decimal) *ComputedDiscount {
+	c := cd.Clone()
+	c.amountUnit = c.amountUnit.Mul(rate)
+	c.amountLine = c.amountLine.Mul(rate)
+
+	return c
+}
+
 func (cd *ComputedDiscount) Reset() {
 	cd.amountLine = numbers.Zero.Copy()
 	cd.amountUnit = numbers.Zero.Copy()
 	cd.percentual = numbers.Zero.Copy()
 }
 
+// Percent returns the cummulated percentual discount
+func (cd *ComputedDiscount) Percent() decimal.Decimal {
+	return cd.percentual.Copy()
+}
+
+// AmountUnit returns the cummulated amount by unit discount
+func (cd *ComputedDiscount) AmountUnit() decimal.Decimal {
+	return cd.amountUnit.Copy()
+}
+
+// AmountLine returns the cummulated amount by line discount
+func (cd *ComputedDiscount) AmountLine() decimal.Decimal {
+	return cd.amountLine.Copy()
+}
+
 // AddDiscount adds a discount to the discounter
 func (cd *ComputedDiscount) AddDiscount(d decimal.Decimal, mode Mode) error {
 	switch mode {
@@ -135,7 +166,7 @@ func (cd *ComputedDiscount) AddDiscount(d decimal.Decimal, mode Mode) error {
 	case AmountUnit:
 		cd.amountUnit = cd.amountUnit.Add(d)
 	default:
-		return ErrInvalidDiscountMode(mode)
+		return withOp(ErrInvalidDiscountMode(mode), OpAddDiscount)
 	}
 
 	return nil
@@ -156,7 +187,7 @@ func (cd *ComputedDiscount) AddDiscountFromString(d string, mode Mode) error {
 	v, err := decimal.NewFromString(d)
 
 	if err != nil {
-		return ErrInvalidDecimal(d)
+		return withOp(ErrInvalidDecimal(d), OpAddDiscount)
 	}
 
 	return cd.AddDiscount(v, mode)
@@ -165,11 +196,11 @@ func (cd *ComputedDiscount) AddDiscountFromString(d string, mode Mode) error {
 // Compute calculates the values of the registered discounts over a discountable value
 func (cd *ComputedDiscount) Compute(uv decimal.Decimal, qty decimal.Decimal, maxDiscount decimal.Decimal) (decimal.Decimal, decimal.Decimal, error) {
 	if uv.IsNegative() {
-		return numbers.Zero.Copy(), numbers.Zero.Copy(), ErrNegativeUnitValue(uv)
+		return numbers.Zero.Copy(), numbers.Zero.Copy(), withOp(ErrNegativeUnitValue(uv), OpCompute)
 	}
 
 	if qty.IsNegative() {
-		return numbers.Zero.Copy(), numbers.Zero.Copy(), ErrNegativeQuantity(uv)
+		return numbers.Zero.Copy(), numbers.Zero.Copy(), withOp(ErrNegativeQuantity(qty), OpCompute)
 	}
 
 	if maxDiscount.IsNegative() || maxDiscount.GreaterThan(numbers.Hundred) {
@@ -180,7 +211,7 @@ func (cd *ComputedDiscount) Compute(uv decimal.Decimal, qty decimal.Decimal, max
 	discounted := uv.Mul(cd.percentual).Div(numbers.Hundred).Add(cd.amountUnit).Mul(qty).Add(cd.amountLine)
 
 	if discounted.GreaterThan(maxDiscountValue) {
-		return numbers.Zero.Copy(), numbers.Zero.Copy(), ErrOverMaxDiscount(fmt.Sprintf("discount: %v  max discount: %v", discounted, maxDiscount))
+		return numbers.Zero.Copy(), numbers.Zero.Copy(), withOp(ErrOverMaxDiscount(fmt.Sprintf("discount: %v  max discount: %v", discounted, maxDiscount)), OpCompute)
 	}
 
 	var discount decimal.Decimal
@@ -209,19 +240,19 @@ func (cd *ComputedDiscount) ComputeFromString(uv string, qty string, maxDiscount
 	duv, err := decimal.NewFromString(uv)
 
 	if err != nil {
-		return numbers.Zero.Copy(), numbers.Zero.Copy(), ErrInvalidDecimal(err)
+		return numbers.Zero.Copy(), numbers.Zero.Copy(), withOp(ErrInvalidDecimal(err), OpCompute)
 	}
 
 	dqty, err := decimal.NewFromString(qty)
 
 	if err != nil {
-		return numbers.Zero.Copy(), numbers.Zero.Copy(), ErrInvalidDecimal(err)
+		return numbers.Zero.Copy(), numbers.Zero.Copy(), withOp(ErrInvalidDecimal(err), OpCompute)
 	}
 
 	dmd, err := decimal.NewFromString(maxDiscount)
 
 	if err != nil {
-		return numbers.Zero.Copy(), numbers.Zero.Copy(), ErrInvalidDecimal(err)
+		return numbers.Zero.Copy(), numbers.Zero.Copy(), withOp(ErrInvalidDecimal(err), OpCompute)
 	}
 
 	return cd.Compute(duv, dqty, dmd)
@@ -240,21 +271,21 @@ func (cd *ComputedDiscount) UnDiscount(discounted, originalUndiscounted decimal.
 
 	if original.IsNegative() {
 		return numbers.Zero.Copy(),
-			ErrNegativeDiscountable(
+			withOp(ErrNegativeDiscountable(
 				fmt.Sprintf(
 					`when [un_discounting] amount line discounts. 
 					discounted: %v   amount_line discount: %v`,
 					discounted,
 					cd.amountLine,
 				),
-			)
+			), OpUnDiscount)
 	}
 
 	original = original.Add(cd.amountUnit.Mul(qty))
 
 	if original.IsNegative() {
 		return numbers.Zero.Copy(),
-			ErrNegativeDiscountable(
+			withOp(ErrNegativeDiscountable(
 				fmt.Sprintf(
 					`when [un_discounting] amount unit discounts. 
 					discounted: %v   amount_unit discount: %v   quantity: %v`,
@@ -262,7 +293,7 @@ func (cd *ComputedDiscount) UnDiscount(discounted, originalUndiscounted decimal.
 					cd.amountLine,
 					qty,
 				),
-			)
+			), OpUnDiscount)
 	}
 
 	if !cd.percentual.Equal(numbers.Hundred) {
@@ -300,42 +331,42 @@ func (cd *ComputedDiscount) UnDiscountFromString(discounted, originalUndiscounte
 
 	if err != nil {
 		return numbers.Zero.Copy(),
-			ErrInvalidDecimal(
+			withOp(ErrInvalidDecimal(
 				fmt.Sprintf(
 					`when [un_discounting_from_string] converting string discounted to decimal. 
 					discounted: %v quantity: %v`,
 					discounted,
 					qty,
 				),
-			)
+			), OpUnDiscount)
 	}
 
 	origUndisc, err := decimal.NewFromString(originalUndiscounted)
 
 	if err != nil {
 		return numbers.Zero.Copy(),
-			ErrInvalidDecimal(
+			withOp(ErrInvalidDecimal(
 				fmt.Sprintf(
 					`when [un_discounting_from_string] converting string original undiscounted to decimal. 
 					discounted: %v quantity: %v`,
 					origUndisc,
 					qty,
 				),
-			)
+			), OpUnDiscount)
 	}
 
 	qtydec, err := decimal.NewFromString(qty)
 
 	if err != nil {
 		return numbers.Zero.Copy(),
-			ErrInvalidDecimal(
+			withOp(ErrInvalidDecimal(
 				fmt.Sprintf(
 					`when [un_discounting_from_string] converting string qty to decimal. 
 					discounted: %v quantity: %v`,
 					discounted,
 					qty,
 				),
-			)
+			), OpUnDiscount)
 	}
 
 	return cd.UnDiscount(discted, origUndisc, qtydec)
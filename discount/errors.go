@@ -1,47 +1,142 @@
 package discount
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// Code is a stable identifier of the kind of an [Error]
+type Code string
+
+const (
+	CodeNegativeDiscountable = Code("ErrNegativeDiscountable")
+	CodeNegativeDiscount     = Code("ErrNegativeDiscount")
+	CodeNegativePercent      = Code("ErrNegativePercentDiscount")
+	CodeNegativeAmountByUnit = Code("ErrNegativeAmountByUnitDiscount")
+	CodeNegativeAmountByLine = Code("ErrNegativeAmountByLineDiscount")
+	CodeNegativeQuantity     = Code("ErrNegativeQuantityDiscount")
+	CodeNegativeUnitValue    = Code("ErrNegativeUnitValue")
+	CodeOverMaxDiscount      = Code("ErrOverMaxDiscount")
+	CodeInvalidDecimal       = Code("ErrInvalidDecimal")
+	CodeInvalidDiscountMode  = Code("ErrInvalidDiscountMode")
+	CodeOther                = Code("ErrOtherDiscount")
+)
+
+// Op is the operation in which an [Error] happened
+type Op string
+
+const (
+	OpAddDiscount = Op("AddDiscount")
+	OpCompute     = Op("Compute")
+	OpUnDiscount  = Op("UnDiscount")
+)
+
+// Sentinel errors wrapped by every [Error] of the package, so they can be checked with errors.Is
+var (
+	// ErrNegative a value which cannot be negative is negative
+	ErrNegative = errors.New("negative value")
+
+	// ErrOverMax the discount goes over the max discount
+	ErrOverMax = errors.New("over max discount")
+
+	// ErrInvalidValue a value is not a valid decimal
+	ErrInvalidValue = errors.New("invalid decimal value")
+
+	// ErrInvalidMode the discount mode doesnt exists
+	ErrInvalidMode = errors.New("invalid discount mode")
+
+	// ErrUnknown any other error
+	ErrUnknown = errors.New("discount error")
+)
+
+// Error is the error returned by the discount package.
+//
+// It carries a stable code, the operation in which happened, and the offending field and value,
+// so callers can react to it with errors.As instead of matching its message
+type Error struct {
+	Code  Code
+	Op    Op
+	Field string
+	Value any
+
+	err error
+	msg string
+}
+
+func (e *Error) Error() string {
+	if e.Op != "" {
+		return fmt.Sprintf("%s: %s %v", e.Op, e.msg, e.Value)
+	}
+
+	return fmt.Sprintf("%s %v", e.msg, e.Value)
+}
+
+// Unwrap returns the sentinel error of the kind of e
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func newError(code Code, field string, sentinel error, msg string, info any) *Error {
+	return &Error{
+		Code:  code,
+		Field: field,
+		Value: info,
+		err:   sentinel,
+		msg:   msg,
+	}
+}
+
+// withOp sets the operation of err when is an [Error] without operation
+func withOp(err error, op Op) error {
+	var e *Error
+
+	if errors.As(err, &e) && e.Op == "" {
+		e.Op = op
+	}
+
+	return err
+}
 
 func ErrNegativeDiscountable(info interface{}) error {
-	return fmt.Errorf("[ErrNegativeDiscountable] the value to be discounted is negative. %v", info)
+	return newError(CodeNegativeDiscountable, "discountable", ErrNegative, "[ErrNegativeDiscountable] the value to be discounted is negative.", info)
 }
 
 func ErrNegativeDiscount(info interface{}) error {
-	return fmt.Errorf("[ErrNegativeDiscount] the value to discount is negative. %v", info)
+	return newError(CodeNegativeDiscount, "discount", ErrNegative, "[ErrNegativeDiscount] the value to discount is negative.", info)
 }
 
 func ErrNegativePercent(info interface{}) error {
-	return fmt.Errorf("[ErrNegativePercentDiscount] the percent to discount is negative. %v", info)
+	return newError(CodeNegativePercent, "percent", ErrNegative, "[ErrNegativePercentDiscount] the percent to discount is negative.", info)
 }
 
 func ErrNegativeAmountByUnit(info interface{}) error {
-	return fmt.Errorf("[ErrNegativeAmountByUnitDiscount] the amount by unut discount is negative. %v", info)
+	return newError(CodeNegativeAmountByUnit, "amountUnit", ErrNegative, "[ErrNegativeAmountByUnitDiscount] the amount by unut discount is negative.", info)
 }
 
 func ErrNegativeAmountByLine(info interface{}) error {
-	return fmt.Errorf("[ErrNegativeAmountByLineDiscount] the amount by line discount is negative. %v", info)
+	return newError(CodeNegativeAmountByLine, "amountLine", ErrNegative, "[ErrNegativeAmountByLineDiscount] the amount by line discount is negative.", info)
 }
 
 func ErrNegativeQuantity(info interface{}) error {
-	return fmt.Errorf("[ErrNegativeQuantityDiscount] the quantity is negative. %v", info)
+	return newError(CodeNegativeQuantity, "qty", ErrNegative, "[ErrNegativeQuantityDiscount] the quantity is negative.", info)
 }
 
 func ErrNegativeUnitValue(info interface{}) error {
-	return fmt.Errorf("[ErrNegativeUnitValue] the unit value couldnt be negative. %v", info)
+	return newError(CodeNegativeUnitValue, "unitValue", ErrNegative, "[ErrNegativeUnitValue] the unit value couldnt be negative.", info)
 }
 
 func ErrOverMaxDiscount(info interface{}) error {
-	return fmt.Errorf("[ErrOverMaxDiscount] the value is over the max discount is negative. %v", info)
+	return newError(CodeOverMaxDiscount, "discount", ErrOverMax, "[ErrOverMaxDiscount] the value is over the max discount is negative.", info)
 }
 
 func ErrInvalidDecimal(info interface{}) error {
-	return fmt.Errorf("[ErrInvalidDecimal] the value to discount is invalid as decimal. %v", info)
+	return newError(CodeInvalidDecimal, "value", ErrInvalidValue, "[ErrInvalidDecimal] the value to discount is invalid as decimal.", info)
 }
 
 func ErrInvalidDiscountMode(info interface{}) error {
-	return fmt.Errorf("[ErrInvalidDiscountMode] the mode of the discount is invalid. %v", info)
+	return newError(CodeInvalidDiscountMode, "mode", ErrInvalidMode, "[ErrInvalidDiscountMode] the mode of the discount is invalid.", info)
 }
 
 func ErrDiscountOther(info interface{}) error {
-	return fmt.Errorf("[ErrOther Discount] there was an error. %v", info)
+	return newError(CodeOther, "", ErrUnknown, "[ErrOther Discount] there was an error.", info)
 }
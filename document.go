@@ -0,0 +1,111 @@
+package bolson
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Totals are the sums of the values of the lines of a document
+type Totals struct {
+	Net             decimal.Decimal `json:"net"`
+	Tax             decimal.Decimal `json:"tax"`
+	Brute           decimal.Decimal `json:"brute"`
+	DiscountedValue decimal.Decimal `json:"discountedValue"`
+
+	NetWD   decimal.Decimal `json:"netWD"`
+	TaxWD   decimal.Decimal `json:"taxWD"`
+	BruteWD decimal.Decimal `json:"bruteWD"`
+
+	// Taxes are the amounts of the coded taxes by code, in the order they first appear
+	Taxes []TaxTotal `json:"taxes,omitempty"`
+}
+
+// TaxTotal is the sum of the details of a coded tax in the lines of a document
+type TaxTotal struct {
+	Code    string          `json:"code"`
+	Taxable decimal.Decimal `json:"taxable"`
+	Amount  decimal.Decimal `json:"amount"`
+}
+
+// Document is a group of calculated lines and its totals
+type Document struct {
+	Lines  []Bag  `json:"lines"`
+	Totals Totals `json:"totals"`
+}
+
+// NewDocument returns a document with lines, which could be calculated with different configurations
+func NewDocument(lines ...Bag) Document {
+	d := Document{Lines: lines}
+	d.Totals = total(lines)
+
+	return d
+}
+
+// CalculateDocument calculates every line with the configuration of b, stopping at the first one failing
+func (b Bolson) CalculateDocument(lines ...Line) (Document, error) {
+	bags := make([]Bag, 0, len(lines))
+
+	for i, l := range lines {
+		calc, err := b.CalculateLine(l)
+
+		if err != nil {
+			return Document{}, ErrInvalidLine(i, err)
+		}
+
+		bags = append(bags, calc)
+	}
+
+	return NewDocument(bags...), nil
+}
+
+// Round rounds the lines of d to scale and obtains the totals adding the rounded lines, as they are printed
+func (d Document) Round(scale int32) Document {
+	lines := make([]Bag, len(d.Lines))
+
+	for i, l := range d.Lines {
+		lines[i] = l.Round(scale)
+	}
+
+	return NewDocument(lines...)
+}
+
+func total(lines []Bag) Totals {
+	t := Totals{
+		Net:             decimal.Zero,
+		Tax:             decimal.Zero,
+		Brute:           decimal.Zero,
+		DiscountedValue: decimal.Zero,
+		NetWD:           decimal.Zero,
+		TaxWD:           decimal.Zero,
+		BruteWD:         decimal.Zero,
+	}
+
+	for _, l := range lines {
+		t.Net = t.Net.Add(l.WithDiscount.Net)
+		t.Tax = t.Tax.Add(l.WithDiscount.Tax)
+		t.Brute = t.Brute.Add(l.WithDiscount.Brute)
+		t.DiscountedValue = t.DiscountedValue.Add(l.WithDiscount.DiscountedValue)
+		t.NetWD = t.NetWD.Add(l.WithoutDiscount.Net)
+		t.TaxWD = t.TaxWD.Add(l.WithoutDiscount.Tax)
+		t.BruteWD = t.BruteWD.Add(l.WithoutDiscount.Brute)
+
+		for _, d := range l.Taxes {
+			t.addTax(d)
+		}
+	}
+
+	return t
+}
+
+// addTax adds d to the total of its code
+func (t *Totals) addTax(d TaxDetail) {
+	for i := range t.Taxes {
+		if t.Taxes[i].Code == d.Code {
+			t.Taxes[i].Taxable = t.Taxes[i].Taxable.Add(d.Taxable)
+			t.Taxes[i].Amount = t.Taxes[i].Amount.Add(d.Amount)
+
+			return
+		}
+	}
+
+	t.Taxes = append(t.Taxes, TaxTotal{Code: d.Code, Taxable: d.Taxable, Amount: d.Amount})
+}
@@ -0,0 +1,43 @@
+package bolson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+func TestCalculateDocument(t *testing.T) {
+	b := New()
+	_ = b.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable)
+
+	doc, err := b.CalculateDocument(
+		Line{Value: decimal.RequireFromString("10.333"), Qty: decimal.NewFromInt(3), MaxDiscount: decimal.NewFromInt(100)},
+		Line{Value: decimal.RequireFromString("10.333"), Qty: decimal.NewFromInt(3), MaxDiscount: decimal.NewFromInt(100)},
+	)
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	// 30.999 * 1.19 = 36.88881 by line
+	if !doc.Totals.Brute.Equal(decimal.RequireFromString("73.77762")) {
+		t.Logf("expected total 73.77762, got %v", doc.Totals.Brute)
+		t.FailNow()
+	}
+
+	// rounded lines are 36.89 each
+	if rounded := doc.Round(2); !rounded.Totals.Brute.Equal(decimal.RequireFromString("73.78")) || !rounded.Totals.Net.Equal(decimal.RequireFromString("62")) {
+		t.Logf("expected rounded totals 62 and 73.78, got %v", rounded.Totals)
+		t.FailNow()
+	}
+
+	_, err = b.CalculateDocument(Line{Value: decimal.NewFromInt(1), Qty: decimal.NewFromInt(1)}, Line{Value: decimal.NewFromInt(1), Qty: decimal.Zero})
+
+	if !errors.Is(err, tax.ErrNegative) {
+		t.Logf("expected error of the second line, got %v", err)
+		t.FailNow()
+	}
+}
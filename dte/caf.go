@@ -0,0 +1,231 @@
+package dte
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CAF is the Código de Autorización de Folios given by the SII, which authorizes a range of folios of a
+// type of document and has the key used to stamp them
+type CAF struct {
+	// Issuer is the RUT of the issuer authorized
+	Issuer string
+	Name   string
+	Type   Type
+
+	// From and To are the first and the last folio authorized
+	From int64
+	To   int64
+	Date time.Time
+
+	// IDK identifies the key of the SII which signed the CAF
+	IDK int
+
+	// Signature is FRMA, the signature of the SII over the data of the CAF, checked by [CAF.Authenticate]
+	Signature []byte
+
+	PublicKey  *rsa.PublicKey
+	PrivateKey *rsa.PrivateKey
+
+	// raw is the CAF element, copied into the TED without changes
+	raw *node
+}
+
+// ParseCAF reads a CAF file as downloaded from the SII, the AUTORIZACION element with the CAF and its keys
+func ParseCAF(data []byte) (*CAF, error) {
+	root, err := parse(data)
+
+	if err != nil {
+		return nil, ErrInvalidCAF(err)
+	}
+
+	if root.local != "AUTORIZACION" {
+		return nil, ErrInvalidCAF("the root element is not AUTORIZACION")
+	}
+
+	raw, da := root.child("CAF"), root.path("CAF", "DA")
+
+	if da == nil {
+		return nil, ErrInvalidCAF("missing CAF/DA")
+	}
+
+	c := &CAF{Issuer: text(da, "RE"), Name: text(da, "RS"), raw: raw}
+
+	if c.Issuer == "" {
+		return nil, ErrInvalidCAF("missing RE")
+	}
+
+	td, err1 := strconv.Atoi(text(da, "TD"))
+	from, err2 := strconv.ParseInt(text(da, "RNG", "D"), 10, 64)
+	to, err3 := strconv.ParseInt(text(da, "RNG", "H"), 10, 64)
+	date, err4 := time.Parse(dateLayout, text(da, "FA"))
+	idk, _ := strconv.Atoi(text(da, "IDK"))
+
+	for _, err := range []error{err1, err2, err3, err4} {
+		if err != nil {
+			return nil, ErrInvalidCAF(err)
+		}
+	}
+
+	c.Type, c.From, c.To, c.Date, c.IDK = Type(td), from, to, date, idk
+
+	if c.Signature, err = decodeBase64(text(raw, "FRMA")); err != nil || len(c.Signature) == 0 {
+		return nil, ErrInvalidCAF("missing or invalid FRMA")
+	}
+
+	if c.PublicKey, err = rsaKeyValue(da.child("RSAPK"), "M", "E"); err != nil {
+		return nil, ErrInvalidCAF(err)
+	}
+
+	if c.PrivateKey, err = parsePrivateKey([]byte(text(root, "RSASK"))); err != nil {
+		return nil, ErrInvalidCAF(err)
+	}
+
+	if !c.PrivateKey.PublicKey.Equal(c.PublicKey) {
+		return nil, ErrInvalidCAF("RSASK is not the key of RSAPK")
+	}
+
+	return c, nil
+}
+
+// SIIKeys are the public keys the SII signs the CAFs with, by their IDK. The SII publishes them, they are
+// not included in this package
+type SIIKeys map[int]*rsa.PublicKey
+
+// Authenticate checks the CAF was given by the SII, verifying its FRMA with the key of keys of its IDK
+func (c *CAF) Authenticate(keys SIIKeys) error {
+	if c == nil || c.raw == nil {
+		return ErrInvalidCAF("the CAF must be read with ParseCAF")
+	}
+
+	key, ok := keys.of(c.raw)
+
+	if !ok {
+		return ErrInvalidCAF(fmt.Sprintf("there is no key of the SII with IDK %d", c.IDK))
+	}
+
+	if err := verifyFRMA(c.raw, key); err != nil {
+		return ErrInvalidCAF(err)
+	}
+
+	return nil
+}
+
+// of returns the key of the IDK of the CAF element caf
+func (keys SIIKeys) of(caf *node) (*rsa.PublicKey, bool) {
+	idk, err := strconv.Atoi(text(caf, "DA", "IDK"))
+
+	if err != nil {
+		return nil, false
+	}
+
+	key, ok := keys[idk]
+
+	return key, ok && key != nil
+}
+
+// verifyFRMA checks the signature FRMA of the CAF element caf, SHA1withRSA over its DA without whitespace
+func verifyFRMA(caf *node, key *rsa.PublicKey) error {
+	da := caf.child("DA")
+
+	if da == nil {
+		return ErrInvalidXML("missing CAF/DA")
+	}
+
+	data, err := flatten(da)
+
+	if err != nil {
+		return err
+	}
+
+	value, err := decodeBase64(text(caf, "FRMA"))
+
+	if err != nil {
+		return err
+	}
+
+	digest := sha1.Sum(data)
+
+	return rsa.VerifyPKCS1v15(key, crypto.SHA1, digest[:], value)
+}
+
+// Authorizes indicates if the CAF authorizes the folio of the type of document t
+func (c *CAF) Authorizes(t Type, folio int64) bool {
+	return c.Type == t && folio >= c.From && folio <= c.To
+}
+
+// text returns the trimmed text of the element found following the local names from n
+func text(n *node, locals ...string) string {
+	if n = n.path(locals...); n == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(n.text())
+}
+
+// rsaKeyValue reads a public key written as its modulus and exponent in base64
+func rsaKeyValue(n *node, modulus string, exponent string) (*rsa.PublicKey, error) {
+	if n == nil {
+		return nil, ErrInvalidKey("missing public key")
+	}
+
+	m, err := decodeBase64(text(n, modulus))
+
+	if err != nil {
+		return nil, ErrInvalidKey(err)
+	}
+
+	e, err := decodeBase64(text(n, exponent))
+
+	if err != nil {
+		return nil, ErrInvalidKey(err)
+	}
+
+	if len(m) == 0 || len(e) == 0 || len(e) > 4 {
+		return nil, ErrInvalidKey("invalid modulus or exponent")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(m), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+}
+
+// decodeBase64 decodes s ignoring the line breaks the documents usually have
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
+
+// parsePrivateKey reads a RSA key in PEM, PKCS #1 or PKCS #8
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(bytes.TrimSpace(data))
+
+	if block == nil {
+		return nil, ErrInvalidKey("the key is not PEM")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+
+	if err != nil {
+		return nil, ErrInvalidKey(err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+
+	if !ok {
+		return nil, ErrInvalidKey("the key is not RSA")
+	}
+
+	return rsaKey, nil
+}
@@ -0,0 +1,304 @@
+package dte
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// Calculate calculates the lines and the totals of d.
+//
+// In facturas and notes the prices are net, IVA and the additional taxes are calculated over the net
+// totals, so they are the rounding of the rate over MntNeto as the SII expects. The IVA withheld by the
+// receiver, codes 15, 30 to 41 and 46 to 48, is reported in ImptoReten and subtracted from MntTotal. In
+// boletas the prices include the taxes, the total is the sum of the lines and the net and IVA are obtained
+// from it, so they cannot have withholdings
+func Calculate(d Document) (*Calculated, error) {
+	if !d.Header.Type.valid() {
+		return nil, ErrInvalidType(d.Header.Type)
+	}
+
+	c := &Calculated{Document: d, Lines: make([]Line, len(d.Items))}
+
+	for i, item := range d.Items {
+		l, err := calculateItem(d.Header.Type, item)
+
+		if err != nil {
+			return nil, ErrInvalidItem(i, err)
+		}
+
+		c.Lines[i] = l
+	}
+
+	if err := c.total(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// lineBolson returns the calculator of an item of a document of type t
+func lineBolson(t Type, item Item) (bolson.Bolson, error) {
+	b := bolson.New()
+
+	if !t.exempt() && !item.Exempt {
+		if err := b.AddCodedTax(CodeIVA, RateIVA, tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase); err != nil {
+			return b, err
+		}
+
+		for _, a := range item.Taxes {
+			// the withholdings do not increase the price, they are subtracted from the total of the document
+			if withholding(a.Code) {
+				if t.brute() {
+					return b, ErrInvalidWithholding(fmt.Sprintf("the code %d cannot be withheld from prices which include the taxes", a.Code))
+				}
+
+				continue
+			}
+
+			if err := b.AddCodedTax(strconv.Itoa(a.Code), a.Rate, tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase); err != nil {
+				return b, err
+			}
+		}
+	}
+
+	if item.DiscountPct.IsPositive() {
+		if err := b.AddDiscount(item.DiscountPct, discount.Percentual); err != nil {
+			return b, err
+		}
+	}
+
+	if item.DiscountAmount.IsPositive() {
+		// in boletas the amount includes taxes, so it is applied as its proportion of the line
+		if t.brute() {
+			line := item.Price.Mul(item.Qty)
+
+			if line.IsZero() {
+				return b, discount.ErrNegativeUnitValue(item.Price)
+			}
+
+			return b, b.AddDiscount(item.DiscountAmount.Mul(numbers.Hundred).Div(line), discount.Percentual)
+		}
+
+		return b, b.AddDiscount(item.DiscountAmount, discount.AmountLine)
+	}
+
+	return b, nil
+}
+
+func calculateItem(t Type, item Item) (Line, error) {
+	l := Line{Item: item}
+
+	b, err := lineBolson(t, item)
+
+	if err != nil {
+		return l, err
+	}
+
+	if t.brute() {
+		l.Calc, err = b.CalculateLine(bolson.Line{Value: item.Price.Mul(item.Qty), Qty: item.Qty, MaxDiscount: numbers.Hundred, From: bolson.FromBruteWD})
+
+		if err != nil {
+			return l, err
+		}
+
+		total := l.Calc.WithoutDiscount.Brute.Round(0)
+		l.DiscountTotal = total.Sub(l.Calc.WithDiscount.Brute.Round(0))
+		l.Amount = total.Sub(l.DiscountTotal)
+
+		return l, nil
+	}
+
+	l.Calc, err = b.CalculateLine(bolson.Line{Value: item.Price, Qty: item.Qty, MaxDiscount: numbers.Hundred, From: bolson.FromUnitValue})
+
+	if err != nil {
+		return l, err
+	}
+
+	l.DiscountTotal = l.Calc.WithDiscount.DiscountedValue.Round(0)
+	l.Amount = l.Calc.WithoutDiscount.Net.Round(0).Sub(l.DiscountTotal)
+
+	return l, nil
+}
+
+// adjust applies the adjustments to the subtotals of the affected and exempt lines
+func (c *Calculated) adjust(affected, exempt decimal.Decimal) (decimal.Decimal, decimal.Decimal, error) {
+	c.Adjusted = make([]decimal.Decimal, len(c.Adjustments))
+	adjustedAffected, adjustedExempt := affected, exempt
+
+	for i, a := range c.Adjustments {
+		if a.Value.IsNegative() || (a.Percentual && a.Value.GreaterThan(numbers.Hundred) && !a.Surcharge) {
+			return affected, exempt, ErrInvalidAdjustment(a.Value)
+		}
+
+		subtotal := affected
+
+		if a.Exempt || c.Header.Type.exempt() {
+			subtotal = exempt
+		}
+
+		v := a.Value
+
+		if a.Percentual {
+			v = subtotal.Mul(a.Value).Div(numbers.Hundred).Round(0)
+		}
+
+		if !a.Surcharge {
+			v = v.Neg()
+		}
+
+		c.Adjusted[i] = v
+
+		if a.Exempt || c.Header.Type.exempt() {
+			adjustedExempt = adjustedExempt.Add(v)
+		} else {
+			adjustedAffected = adjustedAffected.Add(v)
+		}
+	}
+
+	if adjustedAffected.IsNegative() || adjustedExempt.IsNegative() {
+		return affected, exempt, ErrInvalidAdjustment("the discounts exceed the lines")
+	}
+
+	return adjustedAffected, adjustedExempt, nil
+}
+
+// total calculates the totals of c from its lines
+func (c *Calculated) total() error {
+	affected, exempt := decimal.Zero, decimal.Zero
+
+	// bases are the values of the affected lines of each additional tax, in order of appearance
+	codes, bases, rates, amounts := []int{}, map[int]decimal.Decimal{}, map[int]decimal.Decimal{}, map[int]decimal.Decimal{}
+
+	for _, l := range c.Lines {
+		if l.Exempt || c.Header.Type.exempt() {
+			exempt = exempt.Add(l.Amount)
+			continue
+		}
+
+		affected = affected.Add(l.Amount)
+
+		for _, a := range l.Taxes {
+			if _, ok := bases[a.Code]; !ok {
+				codes = append(codes, a.Code)
+				bases[a.Code], amounts[a.Code] = decimal.Zero, decimal.Zero
+			}
+
+			bases[a.Code] = bases[a.Code].Add(l.Amount)
+			rates[a.Code] = a.Rate
+		}
+
+		for _, d := range l.Calc.Taxes {
+			if code, err := strconv.Atoi(d.Code); err == nil && d.Code != CodeIVA {
+				amounts[code] = amounts[code].Add(d.Amount)
+			}
+		}
+	}
+
+	adjustedAffected, adjustedExempt, err := c.adjust(affected, exempt)
+
+	if err != nil {
+		return err
+	}
+
+	// ratio distributes the global adjustments over the bases of the additional taxes
+	ratio := decimal.Zero
+
+	if affected.IsPositive() {
+		ratio = adjustedAffected.Div(affected)
+	}
+
+	c.Totals = Totals{Exempt: adjustedExempt}
+
+	if c.Header.Type.exempt() {
+		c.Totals.Total = adjustedExempt
+
+		return nil
+	}
+
+	c.Totals.RateIVA = RateIVA
+
+	if c.Header.Type.brute() {
+		// the prices include the taxes, so the net is what remains after them
+		retained := decimal.Zero
+
+		for _, code := range codes {
+			amount := amounts[code].Mul(ratio).Round(0)
+			retained = retained.Add(amount)
+			c.Totals.Retentions = append(c.Totals.Retentions, Retention{Code: code, Rate: rates[code], Amount: amount})
+		}
+
+		b := bolson.New()
+
+		if err := b.AddCodedTax(CodeIVA, RateIVA, tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase); err != nil {
+			return err
+		}
+
+		calc, err := b.CalculateFromBrute(adjustedAffected.Sub(retained), decimal.NewFromInt(1), numbers.Hundred)
+
+		if err != nil {
+			return err
+		}
+
+		c.Totals.Net = calc.WithDiscount.Net.Round(0)
+		c.Totals.IVA = adjustedAffected.Sub(retained).Sub(c.Totals.Net)
+		c.Totals.Total = adjustedAffected.Add(adjustedExempt)
+
+		return nil
+	}
+
+	c.Totals.Net = adjustedAffected
+
+	iva, err := taxOver(CodeIVA, RateIVA, adjustedAffected)
+
+	if err != nil {
+		return err
+	}
+
+	c.Totals.IVA = iva
+	c.Totals.Total = adjustedAffected.Add(adjustedExempt).Add(iva)
+
+	for _, code := range codes {
+		amount, err := taxOver(strconv.Itoa(code), rates[code], bases[code].Mul(ratio).Round(0))
+
+		if err != nil {
+			return err
+		}
+
+		c.Totals.Retentions = append(c.Totals.Retentions, Retention{Code: code, Rate: rates[code], Amount: amount})
+
+		if withholding(code) {
+			c.Totals.Total = c.Totals.Total.Sub(amount)
+		} else {
+			c.Totals.Total = c.Totals.Total.Add(amount)
+		}
+	}
+
+	return nil
+}
+
+// taxOver calculates the tax identified by code with rate over the net value, rounded to pesos
+func taxOver(code string, rate decimal.Decimal, net decimal.Decimal) (decimal.Decimal, error) {
+	if net.IsZero() {
+		return decimal.Zero, nil
+	}
+
+	b := bolson.New()
+
+	if err := b.AddCodedTax(code, rate, tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase); err != nil {
+		return decimal.Zero, err
+	}
+
+	calc, err := b.Calculate(net, decimal.NewFromInt(1), numbers.Hundred)
+
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return calc.Taxes[0].Amount.Round(0), nil
+}
@@ -0,0 +1,202 @@
+// Package dte generates the XML of the chilean electronic tax documents (DTE) from documents calculated by bolson.
+//
+// The amounts of the lines and the totals, MntNeto, MntExe, IVA, ImptoReten and MntTotal, are obtained
+// from the calculations, so they are always consistent between them:
+//
+//	calc, err := dte.Calculate(doc)
+//	xml, err := calc.XML()
+//
+// [Validate] checks in Go the restrictions of the SII schema DTE_v10.xsd which apply to the generated
+// elements, and the rules the SII applies over the totals. Validating against the schema itself is not part
+// of the API of this package, which does not distribute the official schemas: they can be obtained from the
+// SII and used with tools as xmllint. The tests validate the documents against reduced transcriptions of
+// them in testdata, and against the official ones when the environment variable DTE_SCHEMAS is their directory
+package dte
+
+import (
+	"time"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/shopspring/decimal"
+)
+
+// Type is the code of the type of document given by the SII
+type Type int
+
+const (
+	Factura       = Type(33)
+	FacturaExenta = Type(34)
+	Boleta        = Type(39)
+	BoletaExenta  = Type(41)
+	NotaDebito    = Type(56)
+	NotaCredito   = Type(61)
+)
+
+// CodeIVA is the SII code of IVA, used to identify it in the tax details of the lines
+const CodeIVA = "14"
+
+// RateIVA is the rate of IVA
+var RateIVA = decimal.NewFromInt(19)
+
+// withholding indicates if the additional tax code is IVA withheld by the receiver, 15, 30 to 41 and 46 to
+// 48, which is reported in ImptoReten as the other codes but subtracted from MntTotal
+func withholding(code int) bool {
+	return code == 15 || (code >= 30 && code <= 41) || (code >= 46 && code <= 48)
+}
+
+// exempt indicates if documents of the type only have exempt amounts
+func (t Type) exempt() bool {
+	return t == FacturaExenta || t == BoletaExenta
+}
+
+// brute indicates if the prices of the documents of the type include taxes
+func (t Type) brute() bool {
+	return t == Boleta || t == BoletaExenta
+}
+
+func (t Type) valid() bool {
+	switch t {
+	case Factura, FacturaExenta, Boleta, BoletaExenta, NotaDebito, NotaCredito:
+		return true
+	}
+
+	return false
+}
+
+// Header identifies the document
+type Header struct {
+	Type  Type      `json:"type"`
+	Folio int64     `json:"folio"`
+	Date  time.Time `json:"date"`
+
+	// PaymentMethod is FmaPago, 1 cash, 2 credit, 3 free. Not written when zero
+	PaymentMethod int `json:"paymentMethod,omitempty"`
+}
+
+// Issuer is the Emisor of the document
+type Issuer struct {
+	RUT      string `json:"rut"`
+	Name     string `json:"name"`
+	Activity string `json:"activity"`
+
+	// Acteco are the codes of the economic activities, between 1 and 4
+	Acteco  []int  `json:"acteco"`
+	Address string `json:"address"`
+	Commune string `json:"commune"`
+	City    string `json:"city,omitempty"`
+}
+
+// Receiver is the Receptor of the document
+type Receiver struct {
+	RUT      string `json:"rut"`
+	Name     string `json:"name"`
+	Activity string `json:"activity,omitempty"`
+	Address  string `json:"address,omitempty"`
+	Commune  string `json:"commune,omitempty"`
+	City     string `json:"city,omitempty"`
+}
+
+// AdditionalTax is a tax besides IVA, identified by its SII code, as 27 for non alcoholic drinks
+type AdditionalTax struct {
+	Code int             `json:"code"`
+	Rate decimal.Decimal `json:"rate"`
+}
+
+// Item is a line of the document
+type Item struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Qty         decimal.Decimal `json:"qty"`
+	Unit        string          `json:"unit,omitempty"`
+
+	// Price is the unit value, without taxes except in boletas, where it includes them
+	Price decimal.Decimal `json:"price"`
+
+	// DiscountPct and DiscountAmount are the discounts of the line, the amount is by line
+	DiscountPct    decimal.Decimal `json:"discountPct"`
+	DiscountAmount decimal.Decimal `json:"discountAmount"`
+
+	// Exempt indicates the line is exempt of IVA
+	Exempt bool `json:"exempt,omitempty"`
+
+	// Taxes are the additional taxes of the line, applied over its net as IVA
+	Taxes []AdditionalTax `json:"taxes,omitempty"`
+}
+
+// Adjustment is a global discount or surcharge, DscRcgGlobal
+type Adjustment struct {
+	// Surcharge indicates a recargo instead of a descuento
+	Surcharge bool   `json:"surcharge,omitempty"`
+	Gloss     string `json:"gloss,omitempty"`
+
+	// Percentual indicates Value is a percentage of the lines it applies to instead of an amount
+	Percentual bool            `json:"percentual,omitempty"`
+	Value      decimal.Decimal `json:"value"`
+
+	// Exempt indicates it applies to the exempt lines instead of the affected ones
+	Exempt bool `json:"exempt,omitempty"`
+}
+
+// Reference is a document referenced, required by credit and debit notes
+type Reference struct {
+	Type   string    `json:"type"`
+	Folio  string    `json:"folio"`
+	Date   time.Time `json:"date"`
+	Code   int       `json:"code,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// Document are the data of a DTE before being calculated
+type Document struct {
+	Header      Header       `json:"header"`
+	Issuer      Issuer       `json:"issuer"`
+	Receiver    Receiver     `json:"receiver"`
+	Items       []Item       `json:"items"`
+	Adjustments []Adjustment `json:"adjustments,omitempty"`
+	References  []Reference  `json:"references,omitempty"`
+}
+
+// Retention is a total of an additional tax or a withholding, ImptoReten
+type Retention struct {
+	Code   int             `json:"code"`
+	Rate   decimal.Decimal `json:"rate"`
+	Amount decimal.Decimal `json:"amount"`
+}
+
+// Totals are the Totales of the document, in pesos
+type Totals struct {
+	Net        decimal.Decimal `json:"net"`
+	Exempt     decimal.Decimal `json:"exempt"`
+	RateIVA    decimal.Decimal `json:"rateIVA"`
+	IVA        decimal.Decimal `json:"iva"`
+	Retentions []Retention     `json:"retentions,omitempty"`
+	Total      decimal.Decimal `json:"total"`
+}
+
+// Line is a calculated item
+type Line struct {
+	Item
+
+	// DiscountTotal is DescuentoMonto, the discounts of the line rounded
+	DiscountTotal decimal.Decimal `json:"discountTotal"`
+
+	// Amount is MontoItem, the value of the line after discounts, net or brute as the prices
+	Amount decimal.Decimal `json:"amount"`
+
+	Calc bolson.Bag `json:"calc"`
+}
+
+// Calculated is a document with its lines and totals calculated
+type Calculated struct {
+	Document
+
+	Lines []Line `json:"lines"`
+
+	// Adjusted are the values of the adjustments in pesos
+	Adjusted []decimal.Decimal `json:"adjusted,omitempty"`
+	Totals   Totals            `json:"totals"`
+
+	// ted and stampedAt are set by [Calculated.Stamp]
+	ted       *xmlTED
+	stampedAt time.Time
+}
@@ -0,0 +1,348 @@
+package dte
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func factura() Document {
+	return Document{
+		Header: Header{Type: Factura, Folio: 1, Date: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), PaymentMethod: 1},
+		Issuer: Issuer{
+			RUT: "76086428-5", Name: "Comercial Ñuñoa SpA", Activity: "Venta al por menor",
+			Acteco: []int{472101}, Address: "Irarrázaval 1234", Commune: "Ñuñoa", City: "Santiago",
+		},
+		Receiver: Receiver{
+			RUT: "60803000-K", Name: "Servicio de Impuestos Internos", Activity: "Gobierno",
+			Address: "Teatinos 120", Commune: "Santiago",
+		},
+		Items: []Item{
+			{Name: "Cuaderno", Qty: decimal.NewFromInt(2), Price: decimal.NewFromInt(1000), DiscountPct: decimal.NewFromInt(10)},
+			{Name: "Asesoría", Qty: decimal.NewFromInt(1), Price: decimal.NewFromInt(5000), Exempt: true},
+			{Name: "Bebida", Qty: decimal.NewFromInt(3), Price: decimal.NewFromInt(990), Taxes: []AdditionalTax{{Code: 27, Rate: decimal.NewFromInt(10)}}},
+		},
+		Adjustments: []Adjustment{{Gloss: "Cliente frecuente", Percentual: true, Value: decimal.NewFromInt(5)}},
+	}
+}
+
+func TestCalculateFactura(t *testing.T) {
+	c, err := Calculate(factura())
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	amounts := []int64{1800, 5000, 2970}
+
+	for i, l := range c.Lines {
+		if !l.Amount.Equal(decimal.NewFromInt(amounts[i])) {
+			t.Logf("line %d: expected amount %d. Got %s", i+1, amounts[i], l.Amount)
+			t.FailNow()
+		}
+	}
+
+	if !c.Lines[0].DiscountTotal.Equal(decimal.NewFromInt(200)) {
+		t.Logf("expected discount 200. Got %s", c.Lines[0].DiscountTotal)
+		t.FailNow()
+	}
+
+	// the global discount is 5% of 4770, 239, so the net is 4531 and its IVA 860.89
+	tt := c.Totals
+
+	expected := map[string][2]decimal.Decimal{
+		"net":    {tt.Net, decimal.NewFromInt(4531)},
+		"exempt": {tt.Exempt, decimal.NewFromInt(5000)},
+		"iva":    {tt.IVA, decimal.NewFromInt(861)},
+		"total":  {tt.Total, decimal.NewFromInt(10674)},
+	}
+
+	for name, v := range expected {
+		if !v[0].Equal(v[1]) {
+			t.Logf("%s: expected %s. Got %s", name, v[1], v[0])
+			t.FailNow()
+		}
+	}
+
+	// the additional tax is calculated over its lines reduced by the global discount, 2821
+	if len(tt.Retentions) != 1 || tt.Retentions[0].Code != 27 || !tt.Retentions[0].Amount.Equal(decimal.NewFromInt(282)) {
+		t.Logf("expected the tax 27 of 282. Got %v", tt.Retentions)
+		t.FailNow()
+	}
+
+	if err := Validate(c); err != nil {
+		t.Logf("unexpected validation error %v", err)
+		t.FailNow()
+	}
+}
+
+func TestCalculateWithholding(t *testing.T) {
+	d := factura()
+	d.Items = []Item{{Name: "chatarra", Qty: decimal.NewFromInt(1), Price: decimal.NewFromInt(100000), Taxes: []AdditionalTax{{Code: 38, Rate: decimal.NewFromInt(19)}}}}
+	d.Adjustments = nil
+
+	c, err := Calculate(d)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	// the IVA of 19000 is withheld by the receiver, so it is reported but not paid
+	tt := c.Totals
+
+	if !tt.Total.Equal(decimal.NewFromInt(100000)) || len(tt.Retentions) != 1 || !tt.Retentions[0].Amount.Equal(decimal.NewFromInt(19000)) {
+		t.Logf("expected the total 100000 with the withholding 38 of 19000. Got %s %v", tt.Total, tt.Retentions)
+		t.FailNow()
+	}
+
+	if !c.Lines[0].Calc.WithDiscount.Brute.Equal(decimal.NewFromInt(119000)) {
+		t.Logf("expected the withholding not to increase the brute. Got %s", c.Lines[0].Calc.WithDiscount.Brute)
+		t.FailNow()
+	}
+
+	if err := Validate(c); err != nil {
+		t.Logf("unexpected validation error %v", err)
+		t.FailNow()
+	}
+
+	d.Header.Type = Boleta
+
+	if _, err := Calculate(d); err == nil || !strings.Contains(err.Error(), "ErrInvalidWithholding") {
+		t.Logf("expected ErrInvalidWithholding. Got %v", err)
+		t.FailNow()
+	}
+}
+
+func TestXML(t *testing.T) {
+	c, _ := Calculate(factura())
+
+	out, err := c.XML()
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	s := string(out)
+
+	if !strings.HasPrefix(s, XMLHeader) || !strings.Contains(s, `<Documento ID="T33F1">`) {
+		t.Logf("unexpected start of document %s", s[:120])
+		t.FailNow()
+	}
+
+	// Ñ and ñ are written as single bytes in ISO-8859-1
+	if !strings.Contains(s, "Comercial \xd1u\xf1oa SpA") {
+		t.Logf("expected the name encoded in ISO-8859-1")
+		t.FailNow()
+	}
+
+	// the elements must follow the order of the schema
+	order := []string{
+		"<IdDoc>", "<TipoDTE>33</TipoDTE>", "<Folio>1</Folio>", "<FchEmis>2026-10-18</FchEmis>", "<FmaPago>1</FmaPago>",
+		"<Emisor>", "<RUTEmisor>76086428-5</RUTEmisor>", "<Acteco>472101</Acteco>",
+		"<Receptor>", "<RUTRecep>60803000-K</RUTRecep>",
+		"<Totales>", "<MntNeto>4531</MntNeto>", "<MntExe>5000</MntExe>", "<TasaIVA>19</TasaIVA>", "<IVA>861</IVA>",
+		"<ImptoReten>", "<TipoImp>27</TipoImp>", "<TasaImp>10</TasaImp>", "<MontoImp>282</MontoImp>", "<MntTotal>10674</MntTotal>",
+		"<Detalle>", "<NroLinDet>1</NroLinDet>", "<QtyItem>2</QtyItem>", "<PrcItem>1000</PrcItem>", "<DescuentoPct>10</DescuentoPct>",
+		"<DescuentoMonto>200</DescuentoMonto>", "<MontoItem>1800</MontoItem>",
+		"<NroLinDet>2</NroLinDet>", "<IndExe>1</IndExe>",
+		"<NroLinDet>3</NroLinDet>", "<CodImpAdic>27</CodImpAdic>", "<MontoItem>2970</MontoItem>",
+		"<DscRcgGlobal>", "<TpoMov>D</TpoMov>", "<TpoValor>%</TpoValor>", "<ValorDR>5</ValorDR>",
+	}
+
+	pos := 0
+
+	for _, e := range order {
+		i := strings.Index(s[pos:], e)
+
+		if i < 0 {
+			t.Logf("expected %s after position %d in %s", e, pos, s)
+			t.FailNow()
+		}
+
+		pos += i + len(e)
+	}
+}
+
+func TestXMLEncoding(t *testing.T) {
+	d := factura()
+	d.Items[0].Name = "Cuaderno €"
+
+	c, _ := Calculate(d)
+
+	if _, err := c.XML(); err == nil || !strings.Contains(err.Error(), "ErrEncoding") {
+		t.Logf("expected ErrEncoding. Got %v", err)
+		t.FailNow()
+	}
+}
+
+func TestCalculateBoleta(t *testing.T) {
+	d := factura()
+	d.Header.Type = Boleta
+	d.Adjustments = nil
+	d.Items = []Item{
+		{Name: "Pan", Qty: decimal.NewFromFloat(1.5), Price: decimal.NewFromInt(1990)},
+		{Name: "Leche", Qty: decimal.NewFromInt(2), Price: decimal.NewFromInt(1190), DiscountAmount: decimal.NewFromInt(380)},
+	}
+
+	c, err := Calculate(d)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	// 2985 + 2000, of which 4189 is the net and 796 the IVA
+	if !c.Totals.Total.Equal(decimal.NewFromInt(4985)) || !c.Totals.Net.Equal(decimal.NewFromInt(4189)) || !c.Totals.IVA.Equal(decimal.NewFromInt(796)) {
+		t.Logf("unexpected totals %+v", c.Totals)
+		t.FailNow()
+	}
+
+	if !c.Lines[1].DiscountTotal.Equal(decimal.NewFromInt(380)) {
+		t.Logf("expected discount 380. Got %s", c.Lines[1].DiscountTotal)
+		t.FailNow()
+	}
+
+	if err := Validate(c); err != nil {
+		t.Logf("unexpected validation error %v", err)
+		t.FailNow()
+	}
+
+	out, _ := c.XML()
+
+	for _, e := range []string{"<IndServicio>3</IndServicio>", "<RznSocEmisor>", "<GiroEmisor>"} {
+		if !strings.Contains(string(out), e) {
+			t.Logf("expected %s in %s", e, out)
+			t.FailNow()
+		}
+	}
+
+	if strings.Contains(string(out), "<TasaIVA>") || strings.Contains(string(out), "<Acteco>") || strings.Contains(string(out), "<GiroRecep>") {
+		t.Logf("unexpected elements of facturas in %s", out)
+		t.FailNow()
+	}
+}
+
+func TestCalculateFacturaExenta(t *testing.T) {
+	d := factura()
+	d.Header.Type = FacturaExenta
+	d.Items[2].Taxes = nil
+
+	c, err := Calculate(d)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	// every line is exempt and the global discount applies over all of them
+	if !c.Totals.Exempt.Equal(decimal.NewFromInt(9281)) || !c.Totals.IVA.IsZero() || !c.Totals.Total.Equal(c.Totals.Exempt) {
+		t.Logf("unexpected totals %+v", c.Totals)
+		t.FailNow()
+	}
+
+	if err := Validate(c); err != nil {
+		t.Logf("unexpected validation error %v", err)
+		t.FailNow()
+	}
+}
+
+func TestValidate(t *testing.T) {
+	d := factura()
+	d.Header.Type = NotaCredito
+	d.Issuer.RUT = "76086428-4"
+	d.Issuer.Acteco = nil
+	d.Receiver.Activity = ""
+	d.Items[0].Name = strings.Repeat("x", 81)
+
+	c, err := Calculate(d)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	c.Totals.IVA = c.Totals.IVA.Add(decimal.NewFromInt(1))
+
+	err = Validate(c)
+
+	var ve *ValidationError
+
+	if !errors.As(err, &ve) {
+		t.Logf("expected a validation error. Got %v", err)
+		t.FailNow()
+	}
+
+	expected := []string{
+		"[InvalidRUT] document RUTEmisor",
+		"[OutOfRange] document Acteco",
+		"[Required] document GiroRecep",
+		"[TooLong] line 1 NmbItem",
+		"[Required] document Referencia",
+		"[Inconsistent] document MntTotal",
+		"[Inconsistent] document IVA",
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(err.Error(), e) {
+			t.Logf("expected %s in %v", e, err)
+			t.FailNow()
+		}
+	}
+
+	if len(ve.Violations) != len(expected) {
+		t.Logf("expected %d violations. Got %v", len(expected), ve.Violations)
+		t.FailNow()
+	}
+}
+
+func TestValidRUT(t *testing.T) {
+	cases := map[string]bool{
+		"76086428-5":   true,
+		"60803000-K":   true,
+		"11111111-1":   true,
+		"60803000-k":   false,
+		"76.086.428-5": false,
+		"760864285":    false,
+		"-5":           false,
+		"76086428-0":   false,
+	}
+
+	for rut, expected := range cases {
+		if ValidRUT(rut) != expected {
+			t.Logf("%s: expected %v", rut, expected)
+			t.FailNow()
+		}
+	}
+}
+
+func TestCalculateInvalid(t *testing.T) {
+	d := factura()
+	d.Header.Type = Type(30)
+
+	if _, err := Calculate(d); err == nil || !strings.Contains(err.Error(), "ErrInvalidType") {
+		t.Logf("expected ErrInvalidType. Got %v", err)
+		t.FailNow()
+	}
+
+	d = factura()
+	d.Items[0].Qty = decimal.Zero
+
+	if _, err := Calculate(d); err == nil || !strings.Contains(err.Error(), "ErrInvalidItem") {
+		t.Logf("expected ErrInvalidItem. Got %v", err)
+		t.FailNow()
+	}
+
+	d = factura()
+	d.Adjustments = []Adjustment{{Value: decimal.NewFromInt(100000)}}
+
+	if _, err := Calculate(d); err == nil || !strings.Contains(err.Error(), "ErrInvalidAdjustment") {
+		t.Logf("expected ErrInvalidAdjustment. Got %v", err)
+		t.FailNow()
+	}
+}
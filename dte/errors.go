@@ -0,0 +1,58 @@
+package dte
+
+import "fmt"
+
+// ErrInvalidType the type of document is not supported
+func ErrInvalidType(info any) error {
+	return fmt.Errorf("[ErrInvalidType] the type of document is not supported. %v", info)
+}
+
+// ErrInvalidItem an item could not be calculated
+func ErrInvalidItem(index int, err error) error {
+	return fmt.Errorf("[ErrInvalidItem] the item %d could not be calculated. %w", index+1, err)
+}
+
+// ErrInvalidAdjustment a global discount or surcharge could not be applied
+func ErrInvalidAdjustment(info any) error {
+	return fmt.Errorf("[ErrInvalidAdjustment] the global discount or surcharge could not be applied. %v", info)
+}
+
+// ErrInvalidWithholding a withholding cannot be applied to the document
+func ErrInvalidWithholding(info any) error {
+	return fmt.Errorf("[ErrInvalidWithholding] the withholding cannot be applied to the document. %v", info)
+}
+
+// ErrEncoding the document has characters which cannot be written in ISO-8859-1
+func ErrEncoding(info any) error {
+	return fmt.Errorf("[ErrEncoding] the document has characters which cannot be written in ISO-8859-1. %v", info)
+}
+
+// ErrInvalidXML the document could not be parsed
+func ErrInvalidXML(info any) error {
+	return fmt.Errorf("[ErrInvalidXML] the document could not be parsed. %v", info)
+}
+
+// ErrInvalidCAF the CAF could not be read or does not authorize the document
+func ErrInvalidCAF(info any) error {
+	return fmt.Errorf("[ErrInvalidCAF] the CAF could not be read or does not authorize the document. %v", info)
+}
+
+// ErrInvalidKey the key or the certificate could not be read
+func ErrInvalidKey(info any) error {
+	return fmt.Errorf("[ErrInvalidKey] the key or the certificate could not be read. %v", info)
+}
+
+// ErrNotStamped the document must be stamped with its TED before being signed
+func ErrNotStamped(info any) error {
+	return fmt.Errorf("[ErrNotStamped] the document must be stamped with its TED before being signed. %v", info)
+}
+
+// ErrInvalidSignature the signature of the document is missing or does not match it
+func ErrInvalidSignature(info any) error {
+	return fmt.Errorf("[ErrInvalidSignature] the signature of the document is missing or does not match it. %v", info)
+}
+
+// ErrInvalidStamp the TED of the document is missing or does not match it
+func ErrInvalidStamp(info any) error {
+	return fmt.Errorf("[ErrInvalidStamp] the TED of the document is missing or does not match it. %v", info)
+}
@@ -0,0 +1,44 @@
+package dte
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ValidRUT indicates if rut is written as the SII expects, digits without dots, a hyphen and the check
+// digit, as 76086428-5, and if the check digit is right
+func ValidRUT(rut string) bool {
+	number, dv, ok := strings.Cut(rut, "-")
+
+	if !ok || len(number) == 0 || len(number) > 8 || len(dv) != 1 {
+		return false
+	}
+
+	if _, err := strconv.ParseUint(number, 10, 32); err != nil {
+		return false
+	}
+
+	return CheckDigit(number) == dv
+}
+
+// CheckDigit calculates the check digit of the number of a RUT with the module 11 algorithm
+func CheckDigit(number string) string {
+	sum, factor := 0, 2
+
+	for i := len(number) - 1; i >= 0; i-- {
+		sum += int(number[i]-'0') * factor
+
+		if factor++; factor > 7 {
+			factor = 2
+		}
+	}
+
+	switch dv := 11 - sum%11; dv {
+	case 11:
+		return "0"
+	case 10:
+		return "K"
+	default:
+		return strconv.Itoa(dv)
+	}
+}
@@ -0,0 +1,126 @@
+package dte
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// schemas are the directories of the schemas the documents are validated against: the reduced transcriptions
+// in testdata, and the official schemas of the SII, unmodified, in the directory of the environment variable
+// DTE_SCHEMAS when it is set, with DTE_v10.xsd of facturas at its root and the one of boletas in boleta
+func schemas() []string {
+	dirs := []string{filepath.Join("testdata", "reduced")}
+
+	if dir := os.Getenv("DTE_SCHEMAS"); dir != "" {
+		dirs = append(dirs, dir)
+	}
+
+	return dirs
+}
+
+// xmllint validates doc against the schema, returning the output of xmllint when it is invalid.
+// The test is skipped when xmllint is not installed
+func xmllint(t *testing.T, schema string, doc []byte) string {
+	path, err := exec.LookPath("xmllint")
+
+	if err != nil {
+		t.Skip("xmllint is not installed")
+	}
+
+	file := filepath.Join(t.TempDir(), "dte.xml")
+
+	if err := os.WriteFile(file, doc, 0o600); err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	out, err := exec.Command(path, "--noout", "--schema", schema, file).CombinedOutput()
+
+	if err != nil {
+		return string(out)
+	}
+
+	return ""
+}
+
+func TestSchema(t *testing.T) {
+	notaCredito := factura()
+	notaCredito.Header.Type = NotaCredito
+	notaCredito.References = []Reference{{Type: "33", Folio: "1", Date: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Code: 3, Reason: "Corrige montos"}}
+
+	exenta := factura()
+	exenta.Header.Type = FacturaExenta
+	exenta.Items[2].Taxes = nil
+
+	withheld := factura()
+	withheld.Items[2].Taxes = []AdditionalTax{{Code: 38, Rate: decimal.NewFromInt(19)}}
+
+	boleta := factura()
+	boleta.Header.Type = Boleta
+	boleta.Adjustments = nil
+	boleta.Items = []Item{
+		{Name: "Pan", Qty: decimal.NewFromFloat(1.5), Price: decimal.NewFromInt(1990)},
+		{Name: "Leche", Qty: decimal.NewFromInt(2), Price: decimal.NewFromInt(1190), DiscountAmount: decimal.NewFromInt(380)},
+	}
+
+	testCases := []struct {
+		doc    Document
+		schema string
+	}{
+		{factura(), "DTE_v10.xsd"},
+		{notaCredito, "DTE_v10.xsd"},
+		{exenta, "DTE_v10.xsd"},
+		{withheld, "DTE_v10.xsd"},
+		{boleta, "boleta/DTE_v10.xsd"},
+	}
+
+	signer := testSigner(t)
+
+	for i, tc := range testCases {
+		c, err := Calculate(tc.doc)
+
+		if err != nil {
+			t.Logf("Fail test case[%d] --- %v", i, err)
+			t.FailNow()
+		}
+
+		caf, _ := ParseCAF(testCAF(t, c.Issuer.RUT, c.Header.Type, 1, 100))
+
+		if err := c.Stamp(caf, time.Date(2026, 10, 18, 10, 30, 0, 0, time.UTC)); err != nil {
+			t.Logf("Fail test case[%d] --- %v", i, err)
+			t.FailNow()
+		}
+
+		signed, err := c.Sign(signer)
+
+		if err != nil {
+			t.Logf("Fail test case[%d] --- %v", i, err)
+			t.FailNow()
+		}
+
+		unsigned, _ := c.XML()
+
+		for _, dir := range schemas() {
+			schema := filepath.Join(dir, tc.schema)
+
+			if out := xmllint(t, schema, signed); out != "" {
+				t.Logf("Fail test case[%d] --- the signed document is not valid against %s: %s", i, schema, out)
+				t.FailNow()
+			}
+
+			// the signature is required, so the only error of the document before signing is its absence
+			out := xmllint(t, schema, unsigned)
+
+			if strings.Count(out, "validity error") != 1 || !strings.Contains(out, "}Signature )") {
+				t.Logf("Fail test case[%d] --- expected the signature to be the only missing element of %s: %s", i, schema, out)
+				t.FailNow()
+			}
+		}
+	}
+}
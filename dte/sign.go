@@ -0,0 +1,117 @@
+package dte
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"math/big"
+)
+
+// algorithms of the signatures
+const (
+	NamespaceDSig = "http://www.w3.org/2000/09/xmldsig#"
+	AlgorithmC14N = "http://www.w3.org/TR/2001/REC-xml-c14n-20010315"
+	AlgorithmSHA1 = "http://www.w3.org/2000/09/xmldsig#sha1"
+	AlgorithmRSA  = "http://www.w3.org/2000/09/xmldsig#rsa-sha1"
+)
+
+// Signer signs documents with the key of the certificate of a person authorized by the issuer
+type Signer struct {
+	Key         *rsa.PrivateKey
+	Certificate *x509.Certificate
+}
+
+// NewSigner reads the certificate and its key in PEM. The certificates delivered as PKCS #12 files can be
+// converted with openssl pkcs12 -in cert.pfx -nodes
+func NewSigner(certPEM []byte, keyPEM []byte) (*Signer, error) {
+	block, _ := pem.Decode(certPEM)
+
+	if block == nil {
+		return nil, ErrInvalidKey("the certificate is not PEM")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+
+	if err != nil {
+		return nil, ErrInvalidKey(err)
+	}
+
+	key, err := parsePrivateKey(keyPEM)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if pub, ok := cert.PublicKey.(*rsa.PublicKey); !ok || !pub.Equal(&key.PublicKey) {
+		return nil, ErrInvalidKey("the key is not the one of the certificate")
+	}
+
+	return &Signer{Key: key, Certificate: cert}, nil
+}
+
+// Sign returns the XML of the stamped document c with its XMLDSig signature, which references the
+// Documento element by its ID, encoded in ISO-8859-1 and preceded by [XMLHeader]
+func (c *Calculated) Sign(s *Signer) ([]byte, error) {
+	if c.ted == nil {
+		return nil, ErrNotStamped(c.ID())
+	}
+
+	out, err := xml.MarshalIndent(c.dte(), "", "  ")
+
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := parse(out)
+
+	if err != nil {
+		return nil, err
+	}
+
+	doc := root.child("Documento")
+	digest := sha1.Sum(doc.canonical())
+
+	signedInfo := `<SignedInfo xmlns="` + NamespaceDSig + `">` +
+		`<CanonicalizationMethod Algorithm="` + AlgorithmC14N + `"></CanonicalizationMethod>` +
+		`<SignatureMethod Algorithm="` + AlgorithmRSA + `"></SignatureMethod>` +
+		`<Reference URI="#` + escapeAttr(c.ID()) + `">` +
+		`<Transforms><Transform Algorithm="` + AlgorithmC14N + `"></Transform></Transforms>` +
+		`<DigestMethod Algorithm="` + AlgorithmSHA1 + `"></DigestMethod>` +
+		`<DigestValue>` + base64.StdEncoding.EncodeToString(digest[:]) + `</DigestValue>` +
+		`</Reference></SignedInfo>`
+
+	// SignedInfo is written already canonicalized
+	hashed := sha1.Sum([]byte(signedInfo))
+
+	value, err := rsa.SignPKCS1v15(rand.Reader, s.Key, crypto.SHA1, hashed[:])
+
+	if err != nil {
+		return nil, ErrInvalidKey(err)
+	}
+
+	signature := `<Signature xmlns="` + NamespaceDSig + `">` + signedInfo +
+		`<SignatureValue>` + base64.StdEncoding.EncodeToString(value) + `</SignatureValue>` +
+		`<KeyInfo><KeyValue><RSAKeyValue>` +
+		`<Modulus>` + base64.StdEncoding.EncodeToString(s.Key.N.Bytes()) + `</Modulus>` +
+		`<Exponent>` + base64.StdEncoding.EncodeToString(big.NewInt(int64(s.Key.E)).Bytes()) + `</Exponent>` +
+		`</RSAKeyValue></KeyValue>` +
+		`<X509Data><X509Certificate>` + base64.StdEncoding.EncodeToString(s.Certificate.Raw) + `</X509Certificate></X509Data>` +
+		`</KeyInfo></Signature>`
+
+	end := bytes.LastIndex(out, []byte("</DTE>"))
+	signed := append(append(append([]byte{}, out[:end]...), "  "+signature+"\n"...), out[end:]...)
+
+	latin, err := latin1(signed)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(XMLHeader), latin...), nil
+}
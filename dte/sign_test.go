@@ -0,0 +1,410 @@
+package dte
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testSII is the key which signs the CAFs of the tests in place of the one of the SII
+var testSII, _ = rsa.GenerateKey(rand.Reader, 1024)
+
+// testKeys are the keys of the SII given to Verify by the tests
+var testKeys = SIIKeys{100: &testSII.PublicKey}
+
+// testCAF returns a CAF file as the SII delivers them, with a key generated for the test and signed by testSII
+func testCAF(t *testing.T, issuer string, typ Type, from int64, to int64) []byte {
+	return cafSignedBy(t, testSII, issuer, typ, from, to)
+}
+
+// cafSignedBy returns a CAF file with IDK 100 whose FRMA is signed by sii
+func cafSignedBy(t *testing.T, sii *rsa.PrivateKey, issuer string, typ Type, from int64, to int64) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	private := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	e := big.NewInt(int64(key.E)).Bytes()
+
+	da := fmt.Sprintf(`<DA>
+<RE>%s</RE>
+<RS>COMERCIAL NUNOA SPA</RS>
+<TD>%d</TD>
+<RNG><D>%d</D><H>%d</H></RNG>
+<FA>2026-10-01</FA>
+<RSAPK><M>%s</M><E>%s</E></RSAPK>
+<IDK>100</IDK>
+</DA>`, issuer, typ, from, to, base64.StdEncoding.EncodeToString(key.N.Bytes()), base64.StdEncoding.EncodeToString(e))
+
+	n, _ := parse([]byte(da))
+	data, _ := flatten(n)
+	digest := sha1.Sum(data)
+
+	frma, err := rsa.SignPKCS1v15(rand.Reader, sii, crypto.SHA1, digest[:])
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	return []byte(fmt.Sprintf(`<?xml version="1.0"?>
+<AUTORIZACION>
+<CAF version="1.0">
+%s
+<FRMA algoritmo="SHA1withRSA">%s</FRMA>
+</CAF>
+<RSASK>%s</RSASK>
+</AUTORIZACION>
+`, da, base64.StdEncoding.EncodeToString(frma), private))
+}
+
+// testSigner returns a signer with a self signed certificate
+func testSigner(t *testing.T) *Signer {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Juan Perez", SerialNumber: "11111111-1"},
+		NotBefore:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	s, err := NewSigner(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+	)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	return s
+}
+
+func signed(t *testing.T) []byte {
+	c, _ := Calculate(factura())
+
+	caf, err := ParseCAF(testCAF(t, "76086428-5", Factura, 1, 100))
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if err := c.Stamp(caf, time.Date(2026, 10, 18, 10, 30, 0, 0, time.UTC)); err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	out, err := c.Sign(testSigner(t))
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	return out
+}
+
+func TestSignAndVerify(t *testing.T) {
+	out := signed(t)
+
+	for _, e := range []string{
+		`<DTE xmlns="http://www.sii.cl/SiiDte" version="1.0">`,
+		`<TED version="1.0">`,
+		`<CAF version="1.0"><DA><RE>76086428-5</RE>`,
+		`<TSTED>2026-10-18T10:30:00</TSTED>`,
+		`<FRMT algoritmo="SHA1withRSA">`,
+		`<TmstFirma>2026-10-18T10:30:00</TmstFirma>`,
+		`<Reference URI="#T33F1">`,
+	} {
+		if !bytes.Contains(out, []byte(e)) {
+			t.Logf("expected %s in %s", e, out)
+			t.FailNow()
+		}
+	}
+
+	v, err := Verify(out, testKeys)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if v.ID != "T33F1" || !v.Stamped || v.Unauthenticated || v.Certificate.Subject.CommonName != "Juan Perez" {
+		t.Logf("unexpected verification %+v", v)
+		t.FailNow()
+	}
+
+	// without the key of the SII the TED is verified but not the CAF
+	for _, keys := range []SIIKeys{nil, {200: &testSII.PublicKey}} {
+		v, err := Verify(out, keys)
+
+		if err != nil {
+			t.Logf("unexpected error %v", err)
+			t.FailNow()
+		}
+
+		if v.Stamped || !v.Unauthenticated {
+			t.Logf("expected an unauthenticated stamp. Got %+v", v)
+			t.FailNow()
+		}
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	out := signed(t)
+
+	for _, c := range [][2]string{
+		{"<MntTotal>10674</MntTotal>", "<MntTotal>10675</MntTotal>"},
+		{"<MNT>10674</MNT>", "<MNT>10675</MNT>"},
+	} {
+		tampered := bytes.Replace(out, []byte(c[0]), []byte(c[1]), 1)
+
+		if _, err := Verify(tampered, testKeys); err == nil || !strings.Contains(err.Error(), "ErrInvalidSignature") {
+			t.Logf("expected ErrInvalidSignature. Got %v", err)
+			t.FailNow()
+		}
+	}
+
+	// a signature value which is not of the signed info
+	i := bytes.Index(out, []byte("<SignatureValue>")) + len("<SignatureValue>")
+	tampered := append([]byte{}, out...)
+	tampered[i] ^= 1
+
+	if _, err := Verify(tampered, testKeys); err == nil || !strings.Contains(err.Error(), "ErrInvalidSignature") {
+		t.Logf("expected ErrInvalidSignature. Got %v", err)
+		t.FailNow()
+	}
+
+	c, _ := Calculate(factura())
+	unsigned, _ := c.XML()
+
+	if _, err := Verify(unsigned, testKeys); err == nil || !strings.Contains(err.Error(), "ErrInvalidSignature") {
+		t.Logf("expected ErrInvalidSignature. Got %v", err)
+		t.FailNow()
+	}
+}
+
+func TestVerifyStamp(t *testing.T) {
+	caf, _ := ParseCAF(testCAF(t, "76086428-5", Factura, 1, 100))
+	signer := testSigner(t)
+
+	// documents signed after their TED was altered
+	alter := []func(*xmlTED){
+		func(ted *xmlTED) { ted.DD.MNT = "10675" },
+		func(ted *xmlTED) { ted.DD.IT1 = "Lápiz" },
+	}
+
+	for _, f := range alter {
+		c, _ := Calculate(factura())
+		_ = c.Stamp(caf, time.Now())
+
+		f(c.ted)
+
+		out, _ := c.Sign(signer)
+
+		if _, err := Verify(out, testKeys); err == nil || !strings.Contains(err.Error(), "ErrInvalidStamp") {
+			t.Logf("expected ErrInvalidStamp. Got %v", err)
+			t.FailNow()
+		}
+	}
+}
+
+func TestVerifyForgedCAF(t *testing.T) {
+	forger, _ := rsa.GenerateKey(rand.Reader, 1024)
+	forged := cafSignedBy(t, forger, "76086428-5", Factura, 1, 100)
+
+	// a CAF whose range was extended after the SII signed it
+	extended := bytes.Replace(testCAF(t, "76086428-5", Factura, 1, 100), []byte("<H>100</H>"), []byte("<H>1000</H>"), 1)
+
+	for _, data := range [][]byte{forged, extended} {
+		caf, err := ParseCAF(data)
+
+		if err != nil {
+			t.Logf("unexpected error %v", err)
+			t.FailNow()
+		}
+
+		if err := caf.Authenticate(testKeys); err == nil || !strings.Contains(err.Error(), "ErrInvalidCAF") {
+			t.Logf("expected ErrInvalidCAF. Got %v", err)
+			t.FailNow()
+		}
+
+		c, _ := Calculate(factura())
+
+		if err := c.Stamp(caf, time.Now()); err != nil {
+			t.Logf("unexpected error %v", err)
+			t.FailNow()
+		}
+
+		out, _ := c.Sign(testSigner(t))
+
+		if _, err := Verify(out, testKeys); err == nil || !strings.Contains(err.Error(), "ErrInvalidStamp") {
+			t.Logf("expected ErrInvalidStamp. Got %v", err)
+			t.FailNow()
+		}
+	}
+
+	caf, _ := ParseCAF(testCAF(t, "76086428-5", Factura, 1, 100))
+
+	if err := caf.Authenticate(testKeys); err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if err := caf.Authenticate(nil); err == nil || !strings.Contains(err.Error(), "ErrInvalidCAF") {
+		t.Logf("expected ErrInvalidCAF. Got %v", err)
+		t.FailNow()
+	}
+}
+
+func TestStampErrors(t *testing.T) {
+	c, _ := Calculate(factura())
+
+	if _, err := c.Sign(testSigner(t)); err == nil || !strings.Contains(err.Error(), "ErrNotStamped") {
+		t.Logf("expected ErrNotStamped. Got %v", err)
+		t.FailNow()
+	}
+
+	cafs := [][]byte{
+		testCAF(t, "76086428-5", Factura, 10, 20),
+		testCAF(t, "76086428-5", NotaCredito, 1, 20),
+		testCAF(t, "11111111-1", Factura, 1, 20),
+	}
+
+	for _, data := range cafs {
+		caf, err := ParseCAF(data)
+
+		if err != nil {
+			t.Logf("unexpected error %v", err)
+			t.FailNow()
+		}
+
+		if err := c.Stamp(caf, time.Now()); err == nil || !strings.Contains(err.Error(), "ErrInvalidCAF") {
+			t.Logf("expected ErrInvalidCAF. Got %v", err)
+			t.FailNow()
+		}
+	}
+
+	// CAFs which were not read with ParseCAF
+	for _, caf := range []*CAF{nil, {Issuer: "76086428-5", Type: Factura, From: 1, To: 100}} {
+		if err := c.Stamp(caf, time.Now()); err == nil || !strings.Contains(err.Error(), "ErrInvalidCAF") {
+			t.Logf("expected ErrInvalidCAF. Got %v", err)
+			t.FailNow()
+		}
+	}
+}
+
+func TestParseCAF(t *testing.T) {
+	data := testCAF(t, "76086428-5", Factura, 1, 100)
+
+	caf, err := ParseCAF(data)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if caf.Issuer != "76086428-5" || caf.Type != Factura || caf.From != 1 || caf.To != 100 || caf.IDK != 100 {
+		t.Logf("unexpected CAF %+v", caf)
+		t.FailNow()
+	}
+
+	// the private key of another CAF
+	other := testCAF(t, "76086428-5", Factura, 1, 100)
+	start, end := bytes.Index(data, []byte("<RSASK>")), bytes.Index(data, []byte("</RSASK>"))
+	otherStart, otherEnd := bytes.Index(other, []byte("<RSASK>")), bytes.Index(other, []byte("</RSASK>"))
+
+	mixed := append(append(append([]byte{}, data[:start]...), other[otherStart:otherEnd]...), data[end:]...)
+
+	if _, err := ParseCAF(mixed); err == nil || !strings.Contains(err.Error(), "ErrInvalidCAF") {
+		t.Logf("expected ErrInvalidCAF. Got %v", err)
+		t.FailNow()
+	}
+
+	// a CAF without the signature of the SII
+	start, end = bytes.Index(data, []byte("<FRMA")), bytes.Index(data, []byte("</FRMA>"))+len("</FRMA>")
+	unsigned := append(append([]byte{}, data[:start]...), data[end:]...)
+
+	if _, err := ParseCAF(unsigned); err == nil || !strings.Contains(err.Error(), "ErrInvalidCAF") {
+		t.Logf("expected ErrInvalidCAF. Got %v", err)
+		t.FailNow()
+	}
+
+	if _, err := ParseCAF([]byte("<AUTORIZACION></AUTORIZACION>")); err == nil {
+		t.Logf("expected an error")
+		t.FailNow()
+	}
+}
+
+func TestTED(t *testing.T) {
+	c, _ := Calculate(factura())
+	caf, _ := ParseCAF(testCAF(t, "76086428-5", Factura, 1, 100))
+
+	_ = c.Stamp(caf, time.Date(2026, 10, 18, 10, 30, 0, 0, time.UTC))
+
+	ted, err := c.TED()
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	expected := `<TED version="1.0"><DD><RE>76086428-5</RE><TD>33</TD><F>1</F><FE>2026-10-18</FE><RR>60803000-K</RR>` +
+		`<RSR>Servicio de Impuestos Internos</RSR><MNT>10674</MNT><IT1>Cuaderno</IT1><CAF version="1.0"><DA>`
+
+	if !bytes.HasPrefix(ted, []byte(expected)) || bytes.ContainsAny(ted, "\n\t") {
+		t.Logf("unexpected TED %s", ted)
+		t.FailNow()
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="ISO-8859-1"?>
+<a:Root xmlns:a="urn:a" xmlns="urn:default"><Doc z="1" a:y="2" b="&quot;x&quot;" ID="D1">
+  <Empty/>
+  <Text>1 &lt; 2 &amp; "3" ` + "\xf1" + `</Text>
+  <b:Inner xmlns:b="urn:b" xmlns="urn:default"><Deep xmlns=""/></b:Inner>
+</Doc></a:Root>`
+
+	root, err := parse([]byte(doc))
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	expected := `<Doc xmlns="urn:default" xmlns:a="urn:a" ID="D1" b="&quot;x&quot;" z="1" a:y="2">
+  <Empty></Empty>
+  <Text>1 &lt; 2 &amp; "3" ñ</Text>
+  <b:Inner xmlns:b="urn:b"><Deep xmlns=""></Deep></b:Inner>
+</Doc>`
+
+	if got := string(root.child("Doc").canonical()); got != expected {
+		t.Logf("expected\n%s\nGot\n%s", expected, got)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,162 @@
+package dte
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/xml"
+	"time"
+)
+
+const timestampLayout = "2006-01-02T15:04:05"
+
+// maxStampText is the length of the texts copied into the TED
+const maxStampText = 40
+
+type xmlTED struct {
+	Version string  `xml:"version,attr"`
+	DD      xmlDD   `xml:"DD"`
+	FRMT    xmlFRMT `xml:"FRMT"`
+}
+
+type xmlDD struct {
+	XMLName xml.Name `xml:"DD"`
+	RE      string   `xml:"RE"`
+	TD      int      `xml:"TD"`
+	F       int64    `xml:"F"`
+	FE      string   `xml:"FE"`
+	RR      string   `xml:"RR"`
+	RSR     string   `xml:"RSR"`
+	MNT     string   `xml:"MNT"`
+	IT1     string   `xml:"IT1"`
+	CAF     xmlRaw   `xml:"CAF"`
+	TSTED   string   `xml:"TSTED"`
+}
+
+type xmlFRMT struct {
+	Algorithm string `xml:"algoritmo,attr"`
+	Value     string `xml:",chardata"`
+}
+
+// xmlRaw is an element written as it was read, as the CAF inside the TED
+type xmlRaw struct {
+	Version string `xml:"version,attr"`
+	Inner   string `xml:",innerxml"`
+}
+
+// Stamp builds the TED, the electronic stamp of the document, signing its main data with the key of caf,
+// which must authorize the folio and be read with [ParseCAF]. at is the time of the stamp, also used as TmstFirma
+func (c *Calculated) Stamp(caf *CAF, at time.Time) error {
+	if caf == nil || caf.raw == nil || caf.PrivateKey == nil {
+		return ErrInvalidCAF("the CAF must be read with ParseCAF")
+	}
+
+	if !caf.Authorizes(c.Header.Type, c.Header.Folio) {
+		return ErrInvalidCAF("the CAF does not authorize the type and folio of the document")
+	}
+
+	if caf.Issuer != c.Issuer.RUT {
+		return ErrInvalidCAF("the CAF was given to " + caf.Issuer)
+	}
+
+	var inner bytes.Buffer
+
+	for _, e := range caf.raw.elements() {
+		e.flat(&inner)
+	}
+
+	dd := xmlDD{
+		RE:    c.Issuer.RUT,
+		TD:    int(c.Header.Type),
+		F:     c.Header.Folio,
+		FE:    c.Header.Date.Format(dateLayout),
+		RR:    c.Receiver.RUT,
+		RSR:   truncate(c.Receiver.Name, maxStampText),
+		MNT:   c.Totals.Total.String(),
+		CAF:   xmlRaw{Version: caf.raw.attr("version"), Inner: inner.String()},
+		TSTED: at.Format(timestampLayout),
+	}
+
+	if len(c.Lines) > 0 {
+		dd.IT1 = truncate(c.Lines[0].Name, maxStampText)
+	}
+
+	out, err := xml.Marshal(dd)
+
+	if err != nil {
+		return err
+	}
+
+	n, err := parse(out)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := flatten(n)
+
+	if err != nil {
+		return err
+	}
+
+	digest := sha1.Sum(data)
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, caf.PrivateKey, crypto.SHA1, digest[:])
+
+	if err != nil {
+		return ErrInvalidKey(err)
+	}
+
+	c.ted = &xmlTED{Version: "1.0", DD: dd, FRMT: xmlFRMT{Algorithm: "SHA1withRSA", Value: base64.StdEncoding.EncodeToString(signature)}}
+	c.stampedAt = at
+
+	return nil
+}
+
+// TED returns the TED of a stamped document without whitespace and in ISO-8859-1, as it is printed in the
+// PDF417 barcode of the representation in paper
+func (c *Calculated) TED() ([]byte, error) {
+	if c.ted == nil {
+		return nil, ErrNotStamped(c.ID())
+	}
+
+	out, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"TED"`
+		*xmlTED
+	}{xmlTED: c.ted})
+
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := parse(out)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return flatten(n)
+}
+
+// flatten writes n without whitespace between elements, in ISO-8859-1
+func flatten(n *node) ([]byte, error) {
+	var buf bytes.Buffer
+
+	n.flat(&buf)
+
+	return latin1(buf.Bytes())
+}
+
+// truncate returns the first max characters of s
+func truncate(s string, max int) string {
+	r := []rune(s)
+
+	if len(r) > max {
+		return string(r[:max])
+	}
+
+	return s
+}
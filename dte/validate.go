@@ -0,0 +1,311 @@
+package dte
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/shopspring/decimal"
+)
+
+// DocumentLine is the line of the violations of the document itself instead of one of its items
+const DocumentLine = 0
+
+// Violation is a restriction of the SII not satisfied by a document
+type Violation struct {
+	// Line is NroLinDet, the number of the item starting at 1, or [DocumentLine]
+	Line int `json:"line"`
+
+	// Code identifies the kind of the problem
+	Code string `json:"code"`
+
+	// Field is the offending element, named as in the XML
+	Field string `json:"field"`
+
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Line == DocumentLine {
+		return fmt.Sprintf("[%s] document %s: %s", v.Code, v.Field, v.Message)
+	}
+
+	return fmt.Sprintf("[%s] line %d %s: %s", v.Code, v.Line, v.Field, v.Message)
+}
+
+// ValidationError reports every violation found by [Validate]
+type ValidationError struct {
+	Violations []Violation `json:"violations"`
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+
+	return fmt.Sprintf("[ErrValidation] %d violations found. %s", len(e.Violations), strings.Join(msgs, "; "))
+}
+
+// limits of the schema DTE_v10.xsd
+const (
+	maxItems       = 60
+	maxBoletaItems = 1000
+	maxAdjustments = 20
+	maxReferences  = 40
+	maxTaxCodes    = 2
+	maxAmount      = 18
+)
+
+var (
+	minDate = time.Date(2003, 4, 1, 0, 0, 0, 0, time.UTC)
+	maxDate = time.Date(2050, 12, 31, 0, 0, 0, 0, time.UTC)
+)
+
+type validator struct {
+	violations []Violation
+}
+
+func (v *validator) add(line int, code string, field string, msg string) {
+	v.violations = append(v.violations, Violation{Line: line, Code: code, Field: field, Message: msg})
+}
+
+// text checks the length of a text element, empty texts are only reported when required
+func (v *validator) text(line int, field string, s string, max int, required bool) {
+	n := utf8.RuneCountInString(s)
+
+	if n == 0 && required {
+		v.add(line, "Required", field, "is required")
+	}
+
+	if n > max {
+		v.add(line, "TooLong", field, fmt.Sprintf("has %d characters, the max is %d", n, max))
+	}
+}
+
+func (v *validator) rut(field string, rut string) {
+	if !ValidRUT(rut) {
+		v.add(DocumentLine, "InvalidRUT", field, fmt.Sprintf("%q is not a valid RUT", rut))
+	}
+}
+
+// amount checks an integer amount in pesos
+func (v *validator) amount(line int, field string, d decimal.Decimal) {
+	switch {
+	case d.IsNegative():
+		v.add(line, "Negative", field, "cannot be negative")
+	case !d.Equal(d.Round(0)):
+		v.add(line, "NotInteger", field, "must be an integer amount")
+	case len(d.String()) > maxAmount:
+		v.add(line, "TooLong", field, fmt.Sprintf("has more than %d digits", maxAmount))
+	}
+}
+
+// Validate checks c against the restrictions of the schema DTE_v10.xsd over the elements written by
+// [Calculated.XML] and the rules of the SII over the amounts, reporting every violation found in a
+// [*ValidationError]
+func Validate(c *Calculated) error {
+	v := &validator{}
+	t := c.Header.Type
+
+	if !t.valid() {
+		v.add(DocumentLine, "InvalidType", "TipoDTE", fmt.Sprintf("%d is not a supported type", t))
+	}
+
+	if c.Header.Folio <= 0 || c.Header.Folio > 9999999999 {
+		v.add(DocumentLine, "OutOfRange", "Folio", "must be between 1 and 9999999999")
+	}
+
+	if c.Header.Date.Before(minDate) || c.Header.Date.After(maxDate) {
+		v.add(DocumentLine, "OutOfRange", "FchEmis", "must be between 2003-04-01 and 2050-12-31")
+	}
+
+	v.issuer(c)
+	v.receiver(c)
+	v.items(c)
+	v.adjustments(c)
+	v.references(c)
+	v.totals(c)
+
+	if len(v.violations) > 0 {
+		return &ValidationError{Violations: v.violations}
+	}
+
+	return nil
+}
+
+func (v *validator) issuer(c *Calculated) {
+	i := c.Issuer
+
+	v.rut("RUTEmisor", i.RUT)
+	v.text(DocumentLine, "RznSoc", i.Name, 100, true)
+	v.text(DocumentLine, "GiroEmis", i.Activity, 80, true)
+	v.text(DocumentLine, "DirOrigen", i.Address, 70, false)
+	v.text(DocumentLine, "CmnaOrigen", i.Commune, 20, false)
+	v.text(DocumentLine, "CiudadOrigen", i.City, 20, false)
+
+	if c.Header.Type.brute() {
+		return
+	}
+
+	if len(i.Acteco) == 0 || len(i.Acteco) > 4 {
+		v.add(DocumentLine, "OutOfRange", "Acteco", "must have between 1 and 4 activities")
+	}
+
+	for _, a := range i.Acteco {
+		if a <= 0 || a > 999999 {
+			v.add(DocumentLine, "OutOfRange", "Acteco", fmt.Sprintf("%d is not an activity code", a))
+		}
+	}
+}
+
+func (v *validator) receiver(c *Calculated) {
+	r := c.Receiver
+
+	// boletas can be issued to final consumers, without the data of the receiver
+	required := !c.Header.Type.brute()
+
+	v.rut("RUTRecep", r.RUT)
+	v.text(DocumentLine, "RznSocRecep", r.Name, 100, required)
+	v.text(DocumentLine, "GiroRecep", r.Activity, 40, required)
+	v.text(DocumentLine, "DirRecep", r.Address, 70, required)
+	v.text(DocumentLine, "CmnaRecep", r.Commune, 20, required)
+	v.text(DocumentLine, "CiudadRecep", r.City, 20, false)
+}
+
+func (v *validator) items(c *Calculated) {
+	max := maxItems
+
+	if c.Header.Type.brute() {
+		max = maxBoletaItems
+	}
+
+	if len(c.Lines) == 0 || len(c.Lines) > max {
+		v.add(DocumentLine, "OutOfRange", "Detalle", fmt.Sprintf("must have between 1 and %d lines", max))
+	}
+
+	for i, l := range c.Lines {
+		n := i + 1
+
+		v.text(n, "NmbItem", l.Name, 80, true)
+		v.text(n, "DscItem", l.Description, 1000, false)
+		v.text(n, "UnmdItem", l.Unit, 4, false)
+
+		if !l.Qty.IsPositive() {
+			v.add(n, "NotPositive", "QtyItem", "must be positive")
+		}
+
+		if l.Price.IsNegative() {
+			v.add(n, "Negative", "PrcItem", "cannot be negative")
+		}
+
+		if l.DiscountPct.IsNegative() || l.DiscountPct.GreaterThan(numbers.Hundred) {
+			v.add(n, "OutOfRange", "DescuentoPct", "must be between 0 and 100")
+		}
+
+		if len(l.Taxes) > maxTaxCodes {
+			v.add(n, "OutOfRange", "CodImpAdic", fmt.Sprintf("a line can have at most %d additional taxes", maxTaxCodes))
+		}
+
+		if len(l.Taxes) > 0 && (l.Exempt || c.Header.Type.exempt()) {
+			v.add(n, "ExemptWithTaxes", "CodImpAdic", "an exempt line cannot have additional taxes")
+		}
+
+		v.amount(n, "DescuentoMonto", l.DiscountTotal)
+		v.amount(n, "MontoItem", l.Amount)
+
+		// the SII accepts a difference of one peso between MontoItem and the rounding of its parts
+		expected := l.Qty.Mul(l.Price).Round(0).Sub(l.DiscountTotal)
+
+		if l.Amount.Sub(expected).Abs().GreaterThan(decimal.NewFromInt(1)) {
+			v.add(n, "Inconsistent", "MontoItem", fmt.Sprintf("%s does not match QtyItem * PrcItem - DescuentoMonto = %s", l.Amount, expected))
+		}
+	}
+}
+
+func (v *validator) adjustments(c *Calculated) {
+	if len(c.Adjustments) > maxAdjustments {
+		v.add(DocumentLine, "OutOfRange", "DscRcgGlobal", fmt.Sprintf("can have at most %d global discounts and surcharges", maxAdjustments))
+	}
+
+	for _, a := range c.Adjustments {
+		v.text(DocumentLine, "GlosaDR", a.Gloss, 45, false)
+
+		if a.Value.IsNegative() || (a.Percentual && !a.Surcharge && a.Value.GreaterThan(numbers.Hundred)) {
+			v.add(DocumentLine, "OutOfRange", "ValorDR", fmt.Sprintf("%s is not a valid value", a.Value))
+		}
+	}
+}
+
+func (v *validator) references(c *Calculated) {
+	t := c.Header.Type
+
+	if (t == NotaCredito || t == NotaDebito) && len(c.References) == 0 {
+		v.add(DocumentLine, "Required", "Referencia", "credit and debit notes must reference a document")
+	}
+
+	if len(c.References) > maxReferences {
+		v.add(DocumentLine, "OutOfRange", "Referencia", fmt.Sprintf("can have at most %d references", maxReferences))
+	}
+
+	for _, r := range c.References {
+		v.text(DocumentLine, "TpoDocRef", r.Type, 3, true)
+		v.text(DocumentLine, "FolioRef", r.Folio, 18, true)
+		v.text(DocumentLine, "RazonRef", r.Reason, 90, false)
+
+		if r.Code < 0 || r.Code > 3 {
+			v.add(DocumentLine, "OutOfRange", "CodRef", "must be 1, 2 or 3")
+		}
+
+		if r.Date.Before(minDate) || r.Date.After(maxDate) {
+			v.add(DocumentLine, "OutOfRange", "FchRef", "must be between 2003-04-01 and 2050-12-31")
+		}
+	}
+}
+
+func (v *validator) totals(c *Calculated) {
+	t := c.Totals
+
+	v.amount(DocumentLine, "MntNeto", t.Net)
+	v.amount(DocumentLine, "MntExe", t.Exempt)
+	v.amount(DocumentLine, "IVA", t.IVA)
+	v.amount(DocumentLine, "MntTotal", t.Total)
+
+	total := t.Net.Add(t.Exempt).Add(t.IVA)
+
+	for _, r := range t.Retentions {
+		v.amount(DocumentLine, "MontoImp", r.Amount)
+
+		if withholding(r.Code) {
+			total = total.Sub(r.Amount)
+		} else {
+			total = total.Add(r.Amount)
+		}
+	}
+
+	if !total.Equal(t.Total) {
+		v.add(DocumentLine, "Inconsistent", "MntTotal", fmt.Sprintf("%s is not the sum of the totals %s", t.Total, total))
+	}
+
+	if c.Header.Type.exempt() {
+		if !t.Net.IsZero() || !t.IVA.IsZero() {
+			v.add(DocumentLine, "Inconsistent", "IVA", "an exempt document cannot have affected amounts")
+		}
+
+		return
+	}
+
+	// in boletas IVA is obtained from the total, in other documents it must be the rate over the net
+	if c.Header.Type.brute() {
+		return
+	}
+
+	iva := t.Net.Mul(t.RateIVA).Div(numbers.Hundred).Round(0)
+
+	if !iva.Equal(t.IVA) {
+		v.add(DocumentLine, "Inconsistent", "IVA", fmt.Sprintf("%s is not TasaIVA over MntNeto %s", t.IVA, iva))
+	}
+}
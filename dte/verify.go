@@ -0,0 +1,234 @@
+package dte
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"hash"
+)
+
+// Verification is the result of verifying a signed document
+type Verification struct {
+	// ID is the ID of the Documento element signed
+	ID string
+
+	// Certificate is the certificate included in the signature, when there is one. Verify does not check
+	// its chain of trust, only that its key signed the document
+	Certificate *x509.Certificate
+
+	// Stamped indicates the document has a TED verified with the key of its CAF, and its CAF was signed by
+	// the SII with one of the keys given to Verify
+	Stamped bool
+
+	// Unauthenticated indicates the TED was verified with the key of its CAF, but none of the keys given to
+	// Verify has the IDK of the CAF, so it is unknown if the SII gave it. Stamped is false then
+	Unauthenticated bool
+}
+
+var (
+	digests = map[string]func() hash.Hash{
+		AlgorithmSHA1: sha1.New,
+		"http://www.w3.org/2001/04/xmlenc#sha256": sha256.New,
+	}
+
+	signatureHashes = map[string]crypto.Hash{
+		AlgorithmRSA: crypto.SHA1,
+		"http://www.w3.org/2001/04/xmldsig-more#rsa-sha256": crypto.SHA256,
+	}
+)
+
+// Verify checks the XMLDSig signature of a DTE, as the ones received from other issuers, and its TED when
+// it has one. The signature must reference the Documento element by its ID and be canonicalized with the
+// inclusive canonicalization without comments. The CAF of the TED is authenticated with the key of keys of
+// its IDK, a CAF not signed by that key is rejected
+func Verify(data []byte, keys SIIKeys) (*Verification, error) {
+	root, err := parse(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := root.find(func(n *node) bool {
+		return n.local == "Signature" && n.uri(n.prefix) == NamespaceDSig
+	})
+
+	if len(signatures) == 0 {
+		return nil, ErrInvalidSignature("the document is not signed")
+	}
+
+	v := &Verification{}
+	signature := signatures[0]
+
+	signedInfo := signature.child("SignedInfo")
+
+	if signedInfo == nil {
+		return nil, ErrInvalidSignature("missing SignedInfo")
+	}
+
+	if alg := signedInfo.path("CanonicalizationMethod").attr("Algorithm"); alg != AlgorithmC14N {
+		return nil, ErrInvalidSignature("unsupported canonicalization " + alg)
+	}
+
+	ref := signedInfo.child("Reference")
+
+	if ref == nil || len(ref.attr("URI")) < 2 || ref.attr("URI")[0] != '#' {
+		return nil, ErrInvalidSignature("the signature must reference an element by its ID")
+	}
+
+	v.ID = ref.attr("URI")[1:]
+
+	if transforms := ref.child("Transforms"); transforms != nil {
+		for _, t := range transforms.elements() {
+			if t.attr("Algorithm") != AlgorithmC14N {
+				return nil, ErrInvalidSignature("unsupported transform " + t.attr("Algorithm"))
+			}
+		}
+	}
+
+	signed := root.find(func(n *node) bool { return n.attr("ID") == v.ID })
+
+	if len(signed) != 1 {
+		return nil, ErrInvalidSignature("the element " + v.ID + " must be found once")
+	}
+
+	newHash, ok := digests[ref.path("DigestMethod").attr("Algorithm")]
+
+	if !ok {
+		return nil, ErrInvalidSignature("unsupported digest " + ref.path("DigestMethod").attr("Algorithm"))
+	}
+
+	digest := newHash()
+	digest.Write(signed[0].canonical())
+
+	expected, err := decodeBase64(text(ref, "DigestValue"))
+
+	if err != nil || string(expected) != string(digest.Sum(nil)) {
+		return nil, ErrInvalidSignature("the digest does not match the element " + v.ID)
+	}
+
+	h, ok := signatureHashes[signedInfo.path("SignatureMethod").attr("Algorithm")]
+
+	if !ok {
+		return nil, ErrInvalidSignature("unsupported signature method " + signedInfo.path("SignatureMethod").attr("Algorithm"))
+	}
+
+	key, err := v.key(signature)
+
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := decodeBase64(text(signature, "SignatureValue"))
+
+	if err != nil {
+		return nil, ErrInvalidSignature(err)
+	}
+
+	hashed := h.New()
+	hashed.Write(signedInfo.canonical())
+
+	if err := rsa.VerifyPKCS1v15(key, h, hashed.Sum(nil), value); err != nil {
+		return nil, ErrInvalidSignature(err)
+	}
+
+	if doc := signed[0]; doc.child("TED") != nil {
+		authenticated, err := verifyStamp(doc, keys)
+
+		if err != nil {
+			return nil, err
+		}
+
+		v.Stamped, v.Unauthenticated = authenticated, !authenticated
+	}
+
+	return v, nil
+}
+
+// key returns the key of the certificate of signature, or its RSAKeyValue when it has no certificate
+func (v *Verification) key(signature *node) (*rsa.PublicKey, error) {
+	if cert := text(signature, "KeyInfo", "X509Data", "X509Certificate"); cert != "" {
+		der, err := decodeBase64(cert)
+
+		if err != nil {
+			return nil, ErrInvalidKey(err)
+		}
+
+		if v.Certificate, err = x509.ParseCertificate(der); err != nil {
+			return nil, ErrInvalidKey(err)
+		}
+
+		key, ok := v.Certificate.PublicKey.(*rsa.PublicKey)
+
+		if !ok {
+			return nil, ErrInvalidKey("the key of the certificate is not RSA")
+		}
+
+		return key, nil
+	}
+
+	return rsaKeyValue(signature.path("KeyInfo", "KeyValue", "RSAKeyValue"), "Modulus", "Exponent")
+}
+
+// verifyStamp checks the signature of the TED of doc with the key of its CAF, that it stamps doc, and the
+// signature of the CAF with the key of keys of its IDK. It returns false when keys has no key with the IDK
+func verifyStamp(doc *node, keys SIIKeys) (bool, error) {
+	dd := doc.path("TED", "DD")
+
+	if dd == nil {
+		return false, ErrInvalidStamp("missing TED/DD")
+	}
+
+	header := doc.path("Encabezado")
+
+	stamped := map[string][]string{
+		"RE":  {"Emisor", "RUTEmisor"},
+		"TD":  {"IdDoc", "TipoDTE"},
+		"F":   {"IdDoc", "Folio"},
+		"RR":  {"Receptor", "RUTRecep"},
+		"MNT": {"Totales", "MntTotal"},
+	}
+
+	for field, path := range stamped {
+		if header == nil || text(dd, field) != text(header, path...) {
+			return false, ErrInvalidStamp(field + " does not match the document")
+		}
+	}
+
+	key, err := rsaKeyValue(dd.path("CAF", "DA", "RSAPK"), "M", "E")
+
+	if err != nil {
+		return false, ErrInvalidStamp(err)
+	}
+
+	data, err := flatten(dd)
+
+	if err != nil {
+		return false, ErrInvalidStamp(err)
+	}
+
+	value, err := decodeBase64(text(doc, "TED", "FRMT"))
+
+	if err != nil {
+		return false, ErrInvalidStamp(err)
+	}
+
+	digest := sha1.Sum(data)
+
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA1, digest[:], value); err != nil {
+		return false, ErrInvalidStamp(err)
+	}
+
+	sii, ok := keys.of(dd.child("CAF"))
+
+	if !ok {
+		return false, nil
+	}
+
+	if err := verifyFRMA(dd.child("CAF"), sii); err != nil {
+		return false, ErrInvalidStamp("the CAF was not signed by the key of the SII of its IDK. " + err.Error())
+	}
+
+	return true, nil
+}
@@ -0,0 +1,298 @@
+package dte
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"unicode/utf8"
+)
+
+// XMLHeader is the declaration written at the start of the documents, the SII requires ISO-8859-1
+const XMLHeader = `<?xml version="1.0" encoding="ISO-8859-1"?>` + "\n"
+
+// Namespace is the namespace of the elements of the DTE
+const Namespace = "http://www.sii.cl/SiiDte"
+
+const dateLayout = "2006-01-02"
+
+type xmlDTE struct {
+	XMLName   xml.Name     `xml:"DTE"`
+	Xmlns     string       `xml:"xmlns,attr"`
+	Version   string       `xml:"version,attr"`
+	Documento xmlDocumento `xml:"Documento"`
+}
+
+type xmlDocumento struct {
+	ID           string            `xml:"ID,attr"`
+	Encabezado   xmlEncabezado     `xml:"Encabezado"`
+	Detalle      []xmlDetalle      `xml:"Detalle"`
+	DscRcgGlobal []xmlDscRcgGlobal `xml:"DscRcgGlobal"`
+	Referencia   []xmlReferencia   `xml:"Referencia"`
+	TED          *xmlTED           `xml:"TED,omitempty"`
+	TmstFirma    string            `xml:"TmstFirma,omitempty"`
+}
+
+type xmlEncabezado struct {
+	IdDoc    xmlIdDoc    `xml:"IdDoc"`
+	Emisor   xmlEmisor   `xml:"Emisor"`
+	Receptor xmlReceptor `xml:"Receptor"`
+	Totales  xmlTotales  `xml:"Totales"`
+}
+
+type xmlIdDoc struct {
+	TipoDTE     int    `xml:"TipoDTE"`
+	Folio       int64  `xml:"Folio"`
+	FchEmis     string `xml:"FchEmis"`
+	IndServicio int    `xml:"IndServicio,omitempty"`
+	FmaPago     int    `xml:"FmaPago,omitempty"`
+}
+
+// xmlEmisor has the names of the elements of facturas and the ones of boletas, only one group is filled
+type xmlEmisor struct {
+	RUTEmisor    string `xml:"RUTEmisor"`
+	RznSoc       string `xml:"RznSoc,omitempty"`
+	GiroEmis     string `xml:"GiroEmis,omitempty"`
+	RznSocEmisor string `xml:"RznSocEmisor,omitempty"`
+	GiroEmisor   string `xml:"GiroEmisor,omitempty"`
+	Acteco       []int  `xml:"Acteco,omitempty"`
+	DirOrigen    string `xml:"DirOrigen,omitempty"`
+	CmnaOrigen   string `xml:"CmnaOrigen,omitempty"`
+	CiudadOrigen string `xml:"CiudadOrigen,omitempty"`
+}
+
+type xmlReceptor struct {
+	RUTRecep    string `xml:"RUTRecep"`
+	RznSocRecep string `xml:"RznSocRecep,omitempty"`
+	GiroRecep   string `xml:"GiroRecep,omitempty"`
+	DirRecep    string `xml:"DirRecep,omitempty"`
+	CmnaRecep   string `xml:"CmnaRecep,omitempty"`
+	CiudadRecep string `xml:"CiudadRecep,omitempty"`
+}
+
+type xmlTotales struct {
+	MntNeto    string          `xml:"MntNeto,omitempty"`
+	MntExe     string          `xml:"MntExe,omitempty"`
+	TasaIVA    string          `xml:"TasaIVA,omitempty"`
+	IVA        string          `xml:"IVA,omitempty"`
+	ImptoReten []xmlImptoReten `xml:"ImptoReten"`
+	MntTotal   string          `xml:"MntTotal"`
+}
+
+type xmlImptoReten struct {
+	TipoImp  int    `xml:"TipoImp"`
+	TasaImp  string `xml:"TasaImp"`
+	MontoImp string `xml:"MontoImp"`
+}
+
+type xmlDetalle struct {
+	NroLinDet      int    `xml:"NroLinDet"`
+	IndExe         int    `xml:"IndExe,omitempty"`
+	NmbItem        string `xml:"NmbItem"`
+	DscItem        string `xml:"DscItem,omitempty"`
+	QtyItem        string `xml:"QtyItem"`
+	UnmdItem       string `xml:"UnmdItem,omitempty"`
+	PrcItem        string `xml:"PrcItem"`
+	DescuentoPct   string `xml:"DescuentoPct,omitempty"`
+	DescuentoMonto string `xml:"DescuentoMonto,omitempty"`
+	CodImpAdic     []int  `xml:"CodImpAdic,omitempty"`
+	MontoItem      string `xml:"MontoItem"`
+}
+
+type xmlDscRcgGlobal struct {
+	NroLinDR int    `xml:"NroLinDR"`
+	TpoMov   string `xml:"TpoMov"`
+	GlosaDR  string `xml:"GlosaDR,omitempty"`
+	TpoValor string `xml:"TpoValor"`
+	ValorDR  string `xml:"ValorDR"`
+	IndExeDR int    `xml:"IndExeDR,omitempty"`
+}
+
+type xmlReferencia struct {
+	NroLinRef int    `xml:"NroLinRef"`
+	TpoDocRef string `xml:"TpoDocRef"`
+	FolioRef  string `xml:"FolioRef"`
+	FchRef    string `xml:"FchRef"`
+	CodRef    int    `xml:"CodRef,omitempty"`
+	RazonRef  string `xml:"RazonRef,omitempty"`
+}
+
+// ID returns the identifier of the Documento element, used by the signature to reference it
+func (c *Calculated) ID() string {
+	return fmt.Sprintf("T%dF%d", c.Header.Type, c.Header.Folio)
+}
+
+// XML returns the DTE element of c, encoded in ISO-8859-1 and preceded by [XMLHeader]
+func (c *Calculated) XML() ([]byte, error) {
+	out, err := xml.MarshalIndent(c.dte(), "", "  ")
+
+	if err != nil {
+		return nil, err
+	}
+
+	latin, err := latin1(out)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(XMLHeader), latin...), nil
+}
+
+func (c *Calculated) dte() xmlDTE {
+	t := c.Header.Type
+
+	doc := xmlDocumento{
+		ID: c.ID(),
+		Encabezado: xmlEncabezado{
+			IdDoc: xmlIdDoc{
+				TipoDTE: int(t),
+				Folio:   c.Header.Folio,
+				FchEmis: c.Header.Date.Format(dateLayout),
+				FmaPago: c.Header.PaymentMethod,
+			},
+			Emisor: xmlEmisor{
+				RUTEmisor:    c.Issuer.RUT,
+				Acteco:       c.Issuer.Acteco,
+				DirOrigen:    c.Issuer.Address,
+				CmnaOrigen:   c.Issuer.Commune,
+				CiudadOrigen: c.Issuer.City,
+			},
+			Receptor: xmlReceptor{
+				RUTRecep:    c.Receiver.RUT,
+				RznSocRecep: c.Receiver.Name,
+				GiroRecep:   c.Receiver.Activity,
+				DirRecep:    c.Receiver.Address,
+				CmnaRecep:   c.Receiver.Commune,
+				CiudadRecep: c.Receiver.City,
+			},
+			Totales: c.totales(),
+		},
+	}
+
+	if t.brute() {
+		// boletas name the issuer elements apart, have no activity codes nor activity of the receiver
+		// and indicate the kind of service
+		doc.Encabezado.IdDoc.IndServicio = 3
+		doc.Encabezado.IdDoc.FmaPago = 0
+		doc.Encabezado.Emisor.RznSocEmisor = c.Issuer.Name
+		doc.Encabezado.Emisor.GiroEmisor = c.Issuer.Activity
+		doc.Encabezado.Emisor.Acteco = nil
+		doc.Encabezado.Receptor.GiroRecep = ""
+	} else {
+		doc.Encabezado.Emisor.RznSoc = c.Issuer.Name
+		doc.Encabezado.Emisor.GiroEmis = c.Issuer.Activity
+	}
+
+	for i, l := range c.Lines {
+		d := xmlDetalle{
+			NroLinDet: i + 1,
+			NmbItem:   l.Name,
+			DscItem:   l.Description,
+			QtyItem:   l.Qty.Round(6).String(),
+			UnmdItem:  l.Unit,
+			PrcItem:   l.Price.Round(6).String(),
+			MontoItem: l.Amount.String(),
+		}
+
+		if l.Exempt || t.exempt() {
+			d.IndExe = 1
+		}
+
+		if l.DiscountPct.IsPositive() {
+			d.DescuentoPct = l.DiscountPct.Round(2).String()
+		}
+
+		if l.DiscountTotal.IsPositive() {
+			d.DescuentoMonto = l.DiscountTotal.String()
+		}
+
+		for _, a := range l.Taxes {
+			d.CodImpAdic = append(d.CodImpAdic, a.Code)
+		}
+
+		doc.Detalle = append(doc.Detalle, d)
+	}
+
+	for i, a := range c.Adjustments {
+		d := xmlDscRcgGlobal{NroLinDR: i + 1, TpoMov: "D", GlosaDR: a.Gloss, TpoValor: "$", ValorDR: a.Value.Round(0).String()}
+
+		if a.Surcharge {
+			d.TpoMov = "R"
+		}
+
+		if a.Percentual {
+			d.TpoValor, d.ValorDR = "%", a.Value.Round(2).String()
+		}
+
+		if a.Exempt {
+			d.IndExeDR = 1
+		}
+
+		doc.DscRcgGlobal = append(doc.DscRcgGlobal, d)
+	}
+
+	for i, r := range c.References {
+		doc.Referencia = append(doc.Referencia, xmlReferencia{
+			NroLinRef: i + 1,
+			TpoDocRef: r.Type,
+			FolioRef:  r.Folio,
+			FchRef:    r.Date.Format(dateLayout),
+			CodRef:    r.Code,
+			RazonRef:  r.Reason,
+		})
+	}
+
+	if c.ted != nil {
+		doc.TED = c.ted
+		doc.TmstFirma = c.stampedAt.Format(timestampLayout)
+	}
+
+	return xmlDTE{Xmlns: Namespace, Version: "1.0", Documento: doc}
+}
+
+func (c *Calculated) totales() xmlTotales {
+	t := c.Header.Type
+
+	x := xmlTotales{MntTotal: c.Totals.Total.String()}
+
+	if c.Totals.Exempt.IsPositive() {
+		x.MntExe = c.Totals.Exempt.String()
+	}
+
+	if t.exempt() {
+		return x
+	}
+
+	x.MntNeto = c.Totals.Net.String()
+	x.IVA = c.Totals.IVA.String()
+
+	if t.brute() {
+		return x
+	}
+
+	x.TasaIVA = c.Totals.RateIVA.String()
+
+	for _, r := range c.Totals.Retentions {
+		x.ImptoReten = append(x.ImptoReten, xmlImptoReten{TipoImp: r.Code, TasaImp: r.Rate.String(), MontoImp: r.Amount.String()})
+	}
+
+	return x
+}
+
+// latin1 converts the UTF-8 text b to ISO-8859-1
+func latin1(b []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(b)))
+
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+
+		if r > 0xFF {
+			return nil, ErrEncoding(string(r))
+		}
+
+		out.WriteByte(byte(r))
+		b = b[size:]
+	}
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,342 @@
+package dte
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"sort"
+	"strings"
+)
+
+// node is an element of a parsed document. The names keep their prefixes, so the documents can be written
+// again canonicalized, as the signatures require
+type node struct {
+	prefix, local string
+	attrs         []xml.Attr
+	children      []any // *node or string
+	parent        *node
+}
+
+// parse reads the elements of a document, in UTF-8 or ISO-8859-1, without comments nor processing instructions
+func parse(data []byte) (*node, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.CharsetReader = charsetReader
+
+	var root, current *node
+
+	for {
+		tok, err := d.RawToken()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{prefix: t.Name.Space, local: t.Name.Local, attrs: t.Attr, parent: current}
+
+			if current == nil {
+				if root != nil {
+					return nil, ErrInvalidXML("more than one root element")
+				}
+
+				root = n
+			} else {
+				current.children = append(current.children, n)
+			}
+
+			current = n
+		case xml.EndElement:
+			if current == nil {
+				return nil, ErrInvalidXML("unexpected end element")
+			}
+
+			current = current.parent
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, string(t))
+			}
+		}
+	}
+
+	if root == nil || current != nil {
+		return nil, ErrInvalidXML("the document is incomplete")
+	}
+
+	return root, nil
+}
+
+// charsetReader decodes ISO-8859-1 documents, the encoding used by the SII
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "latin-1":
+		r := bufio.NewReader(input)
+		var out bytes.Buffer
+
+		for {
+			b, err := r.ReadByte()
+
+			if err == io.EOF {
+				return &out, nil
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			out.WriteRune(rune(b))
+		}
+	}
+
+	return nil, ErrInvalidXML("unsupported encoding " + charset)
+}
+
+// name returns the qualified name of n
+func (n *node) name() string {
+	if n.prefix == "" {
+		return n.local
+	}
+
+	return n.prefix + ":" + n.local
+}
+
+// attr returns the value of the attribute without prefix named local, empty when n is nil
+func (n *node) attr(local string) string {
+	if n == nil {
+		return ""
+	}
+
+	for _, a := range n.attrs {
+		if a.Name.Space == "" && a.Name.Local == local {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+// elements returns the children of n which are elements
+func (n *node) elements() []*node {
+	var r []*node
+
+	for _, c := range n.children {
+		if e, ok := c.(*node); ok {
+			r = append(r, e)
+		}
+	}
+
+	return r
+}
+
+// child returns the first child element of n named local
+func (n *node) child(local string) *node {
+	for _, e := range n.elements() {
+		if e.local == local {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// path returns the element found following the local names from n
+func (n *node) path(locals ...string) *node {
+	for _, l := range locals {
+		if n = n.child(l); n == nil {
+			return nil
+		}
+	}
+
+	return n
+}
+
+// text returns the text of n and its descendants
+func (n *node) text() string {
+	var sb strings.Builder
+
+	for _, c := range n.children {
+		switch v := c.(type) {
+		case string:
+			sb.WriteString(v)
+		case *node:
+			sb.WriteString(v.text())
+		}
+	}
+
+	return sb.String()
+}
+
+// find returns the elements of the tree of n for which match is true, in document order
+func (n *node) find(match func(*node) bool) []*node {
+	var r []*node
+
+	if match(n) {
+		r = append(r, n)
+	}
+
+	for _, e := range n.elements() {
+		r = append(r, e.find(match)...)
+	}
+
+	return r
+}
+
+// namespaces returns the namespaces declared over n and by n, by prefix
+func (n *node) namespaces() map[string]string {
+	ns := map[string]string{}
+
+	if n.parent != nil {
+		ns = n.parent.namespaces()
+	}
+
+	for _, a := range n.attrs {
+		switch {
+		case a.Name.Space == "" && a.Name.Local == "xmlns":
+			ns[""] = a.Value
+		case a.Name.Space == "xmlns":
+			ns[a.Name.Local] = a.Value
+		}
+	}
+
+	return ns
+}
+
+// uri returns the namespace of prefix in the scope of n
+func (n *node) uri(prefix string) string {
+	return n.namespaces()[prefix]
+}
+
+// canonical writes n as the inclusive canonicalization of XML 1.0 without comments, the namespaces in
+// scope of n are declared in it
+func (n *node) canonical() []byte {
+	var buf bytes.Buffer
+
+	n.c14n(&buf, map[string]string{})
+
+	return buf.Bytes()
+}
+
+func (n *node) c14n(buf *bytes.Buffer, rendered map[string]string) {
+	ns := n.namespaces()
+
+	prefixes := make([]string, 0, len(ns))
+
+	for p, uri := range ns {
+		if rendered[p] != uri {
+			prefixes = append(prefixes, p)
+		}
+	}
+
+	sort.Strings(prefixes)
+
+	type attr struct{ uri, name, value string }
+
+	attrs := make([]attr, 0, len(n.attrs))
+
+	for _, a := range n.attrs {
+		if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+			continue
+		}
+
+		if a.Name.Space == "" {
+			attrs = append(attrs, attr{name: a.Name.Local, value: a.Value})
+		} else {
+			attrs = append(attrs, attr{uri: ns[a.Name.Space], name: a.Name.Space + ":" + a.Name.Local, value: a.Value})
+		}
+	}
+
+	sort.SliceStable(attrs, func(i, j int) bool {
+		if attrs[i].uri != attrs[j].uri {
+			return attrs[i].uri < attrs[j].uri
+		}
+
+		return attrs[i].name[strings.IndexByte(attrs[i].name, ':')+1:] < attrs[j].name[strings.IndexByte(attrs[j].name, ':')+1:]
+	})
+
+	inner := make(map[string]string, len(ns))
+
+	for p, uri := range rendered {
+		inner[p] = uri
+	}
+
+	buf.WriteString("<" + n.name())
+
+	for _, p := range prefixes {
+		if p == "" {
+			buf.WriteString(` xmlns="` + escapeAttr(ns[p]) + `"`)
+		} else {
+			buf.WriteString(" xmlns:" + p + `="` + escapeAttr(ns[p]) + `"`)
+		}
+
+		inner[p] = ns[p]
+	}
+
+	for _, a := range attrs {
+		buf.WriteString(" " + a.name + `="` + escapeAttr(a.value) + `"`)
+	}
+
+	buf.WriteString(">")
+
+	for _, c := range n.children {
+		switch v := c.(type) {
+		case string:
+			buf.WriteString(escapeText(v))
+		case *node:
+			v.c14n(buf, inner)
+		}
+	}
+
+	buf.WriteString("</" + n.name() + ">")
+}
+
+// flat writes n without the whitespace between elements nor namespace declarations, as the SII
+// serializes the data stamped by the TED
+func (n *node) flat(buf *bytes.Buffer) {
+	buf.WriteString("<" + n.name())
+
+	for _, a := range n.attrs {
+		if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+			continue
+		}
+
+		name := a.Name.Local
+
+		if a.Name.Space != "" {
+			name = a.Name.Space + ":" + name
+		}
+
+		buf.WriteString(" " + name + `="` + escapeFlat(a.Value) + `"`)
+	}
+
+	buf.WriteString(">")
+
+	for _, c := range n.children {
+		switch v := c.(type) {
+		case string:
+			if strings.TrimSpace(v) != "" || len(n.elements()) == 0 {
+				buf.WriteString(escapeFlat(v))
+			}
+		case *node:
+			v.flat(buf)
+		}
+	}
+
+	buf.WriteString("</" + n.name() + ">")
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+	flatEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;")
+)
+
+func escapeText(s string) string { return textEscaper.Replace(s) }
+
+func escapeAttr(s string) string { return attrEscaper.Replace(s) }
+
+func escapeFlat(s string) string { return flatEscaper.Replace(s) }
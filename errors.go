@@ -0,0 +1,111 @@
+package bolson
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/tax"
+)
+
+// Code is a stable identifier of the kind of an [Error]
+type Code string
+
+const (
+	CodeOverReturn         = Code("ErrOverReturn")
+	CodeNegativeReturn     = Code("ErrNegativeReturn")
+	CodeInvalidIncrement   = Code("ErrInvalidIncrement")
+	CodeInvalidOrigin      = Code("ErrInvalidOrigin")
+	CodeNotCompilable      = Code("ErrNotCompilable")
+	CodeInvalidLine        = Code("ErrInvalidLine")
+	CodeUnstableConditions = Code("ErrUnstableConditions")
+	CodeValidation         = Code("ErrValidation")
+)
+
+// Error is the error returned by this package, besides [ValidationError].
+//
+// It carries a stable code and the offending field and value, so callers can react to it with
+// errors.As instead of matching its message
+type Error struct {
+	Code  Code
+	Field string
+	Value any
+
+	err error
+	msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s %v", e.msg, e.Value)
+}
+
+// Unwrap returns the error which caused e, if any
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func newError(code Code, field string, msg string, info any) *Error {
+	return &Error{Code: code, Field: field, Value: info, msg: msg}
+}
+
+// ErrOverReturn the returned quantity or amount goes over what was originally charged
+func ErrOverReturn(info any) error {
+	return newError(CodeOverReturn, "", "[ErrOverReturn] the returned value goes over the original.", info)
+}
+
+// ErrNegativeReturn the returned quantity or amount is zero or negative
+func ErrNegativeReturn(info any) error {
+	return newError(CodeNegativeReturn, "", "[ErrNegativeReturn] the returned value must be positive.", info)
+}
+
+// ErrInvalidIncrement the increment used to round cash payments is zero or negative
+func ErrInvalidIncrement(info any) error {
+	return newError(CodeInvalidIncrement, "increment", "[ErrInvalidIncrement] the rounding increment must be positive.", info)
+}
+
+// ErrInvalidOrigin the origin of a line doesnt exists
+func ErrInvalidOrigin(info any) error {
+	return newError(CodeInvalidOrigin, "from", "[ErrInvalidOrigin] the specified line origin doesnt exists.", info)
+}
+
+// ErrNotCompilable the configuration cannot be compiled into a plan
+func ErrNotCompilable(info any) error {
+	return newError(CodeNotCompilable, "", "[ErrNotCompilable] the configuration cannot be compiled.", info)
+}
+
+// ErrInvalidLine a line of a document could not be calculated
+func ErrInvalidLine(index int, err error) error {
+	e := newError(CodeInvalidLine, "", fmt.Sprintf("[ErrInvalidLine] the line %d could not be calculated.", index), err)
+	e.err = err
+
+	return e
+}
+
+// ErrUnstableConditions the conditional taxes of a line calculated from its brute do not agree with the
+// unit value they give
+func ErrUnstableConditions(info any) error {
+	return newError(CodeUnstableConditions, "", "[ErrUnstableConditions] the conditional taxes do not agree with the unit value they give.", info)
+}
+
+// ErrorCode returns the stable code of err and the offending field, if known. The errors of the tax and
+// discount packages and the ones of this package carry them, so they are found with errors.As.
+// Other errors return an empty code
+func ErrorCode(err error) (code string, field string) {
+	var taxErr *tax.Error
+	var discountErr *discount.Error
+	var bolsonErr *Error
+	var validationErr *ValidationError
+
+	switch {
+	case errors.As(err, &taxErr):
+		return string(taxErr.Code), taxErr.Field
+	case errors.As(err, &discountErr):
+		return string(discountErr.Code), discountErr.Field
+	case errors.As(err, &bolsonErr):
+		return string(bolsonErr.Code), bolsonErr.Field
+	case errors.As(err, &validationErr):
+		return string(CodeValidation), ""
+	}
+
+	return "", ""
+}
@@ -0,0 +1,38 @@
+package bolson
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/profe-ajedrez/bolson/tax"
+)
+
+func TestErrorCode(t *testing.T) {
+	testCases := []struct {
+		err   error
+		code  string
+		field string
+	}{
+		{ErrInvalidLine(2, tax.ErrNegativeQty(0)), "ErrNegativeQty", "qty"},
+		{ErrInvalidLine(2, ErrInvalidOrigin(9)), "ErrInvalidLine", ""},
+		{fmt.Errorf("wrapped: %w", ErrInvalidOrigin(9)), "ErrInvalidOrigin", "from"},
+		{&ValidationError{Violations: []Violation{{Line: 0, Code: "ZeroQuantity"}}}, "ErrValidation", ""},
+		// the messages are not parsed
+		{errors.New("[ErrOverReturn] looks like a code"), "", ""},
+	}
+
+	for i, tc := range testCases {
+		if code, field := ErrorCode(tc.err); code != tc.code || field != tc.field {
+			t.Logf("Fail test case[%d] --- expected %q %q --- got %q %q", i, tc.code, tc.field, code, field)
+			t.FailNow()
+		}
+	}
+
+	var e *Error
+
+	if err := ErrInvalidLine(1, ErrNotCompilable("x")); !errors.As(err, &e) || e.Code != CodeInvalidLine || !errors.As(e.Unwrap(), &e) || e.Code != CodeNotCompilable {
+		t.Logf("expected the line error to wrap the cause, got %v", err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,99 @@
+package bolson
+
+import (
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// Step is one of the intermediate values of a calculation
+type Step struct {
+	Label string          `json:"label"`
+	Value decimal.Decimal `json:"value"`
+}
+
+// Explanation details how the values of a line were obtained
+type Explanation struct {
+	Line  Line   `json:"line"`
+	Steps []Step `json:"steps"`
+	Bag   Bag    `json:"bag"`
+}
+
+// Explain calculates l as [Bolson.CalculateLine] does, returning besides the intermediate values of the
+// calculation: the unit value found, the discounts by mode and the taxes by stage and base.
+// The conditional taxes fired and the indexed amounts converted are considered
+func (b Bolson) Explain(l Line) (Explanation, error) {
+	if !l.Qty.IsPositive() {
+		return Explanation{Line: l}, tax.ErrNegativeQty(l.Qty)
+	}
+
+	var used Bolson
+
+	b = b.attributed(l)
+
+	calculation := func(p Bolson) (Bag, error) {
+		used = p
+
+		return p.calculate(l.Value, l.Qty, l.MaxDiscount, l.From)
+	}
+
+	var (
+		calc Bag
+		err  error
+	)
+
+	if l.From == FromUnitValue {
+		calc, err = b.prepared(&l.Value, l.Qty, calculation)
+	} else {
+		calc, err = b.prepared(nil, l.Qty, calculation)
+	}
+
+	if err != nil {
+		return Explanation{Line: l}, err
+	}
+
+	return Explanation{Line: l, Steps: used.steps(calc, l.Qty), Bag: calc}, nil
+}
+
+// steps decomposes calc using the handlers of b
+func (b Bolson) steps(calc Bag, qty decimal.Decimal) []Step {
+	uv := calc.WithoutDiscount.UnitValue
+	netWD := calc.WithoutDiscount.Net
+
+	steps := []Step{
+		{"unit value", uv},
+		{"qty", qty},
+		{"net without discount", netWD},
+		{"percentual discount", netWD.Mul(b.discountHandler.Percent()).Div(numbers.Hundred)},
+		{"discount by unit", b.discountHandler.AmountUnit().Mul(qty)},
+		{"discount by line", b.discountHandler.AmountLine()},
+		{"discounted value", calc.WithDiscount.DiscountedValue},
+		{"net", calc.WithDiscount.Net},
+	}
+
+	steps = append(steps, handlerSteps("", b.taxHandler.Clone(), calc.WithDiscount.UnitValue, qty)...)
+
+	if !b.listTaxHandler.IsZero() {
+		steps = append(steps, handlerSteps("over list price ", b.listTaxHandler.Clone(), uv, qty)...)
+	}
+
+	return append(steps,
+		Step{"tax", calc.WithDiscount.Tax},
+		Step{"brute", calc.WithDiscount.Brute},
+		Step{"tax without discount", calc.WithoutDiscount.Tax},
+		Step{"brute without discount", calc.WithoutDiscount.Brute},
+	)
+}
+
+// handlerSteps returns the taxes of each stage of h over the unit taxable as [tax.Handler.Tax] calculates them
+func handlerSteps(prefix string, h *tax.Handler, taxable decimal.Decimal, qty decimal.Decimal) []Step {
+	overTaxables, _ := h.OverTaxables.Tax(taxable, qty)
+	overTaxes, _ := h.OverTaxes.Tax(taxable.Add(overTaxables.Div(qty)), qty)
+	overTaxIgnorables, _ := h.OverTaxIgnorables.Tax(taxable, qty)
+
+	return []Step{
+		{prefix + "taxes over taxables", overTaxables},
+		{prefix + "overtaxes", overTaxes},
+		{prefix + "ignorable overtaxes", overTaxIgnorables},
+	}
+}
@@ -0,0 +1,55 @@
+package bolson
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestExplain(t *testing.T) {
+	b := planBolson()
+
+	for _, from := range []Origin{FromUnitValue, FromBrute, FromBruteWD} {
+		l := Line{Value: decimal.NewFromInt(1000), Qty: decimal.NewFromInt(3), MaxDiscount: decimal.NewFromInt(100), From: from}
+
+		e, err := b.Explain(l)
+
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+
+		calc, _ := b.CalculateLine(l)
+
+		if !e.Bag.WithDiscount.Brute.Equal(calc.WithDiscount.Brute) {
+			t.Logf("from %d expected %v got %v", from, calc, e.Bag)
+			t.FailNow()
+		}
+
+		// the taxes by stage add up the tax
+		sum := decimal.Zero
+
+		for _, s := range e.Steps {
+			switch s.Label {
+			case "taxes over taxables", "overtaxes", "ignorable overtaxes", "over list price taxes over taxables", "over list price overtaxes", "over list price ignorable overtaxes":
+				sum = sum.Add(s.Value)
+			}
+		}
+
+		if !sum.Round(10).Equal(e.Bag.WithDiscount.Tax.Round(10)) {
+			t.Logf("from %d expected taxes by stage adding %v got %v", from, e.Bag.WithDiscount.Tax, sum)
+			t.FailNow()
+		}
+	}
+
+	if _, err := b.Explain(Line{Value: decimal.NewFromInt(1), Qty: decimal.NewFromInt(1), From: InvalidOrigin}); err == nil {
+		t.Log("expected error for invalid origin")
+		t.FailNow()
+	}
+	for _, from := range []Origin{FromUnitValue, FromBrute, FromBruteWD} {
+		if _, err := b.Explain(Line{Value: decimal.NewFromInt(119), Qty: decimal.Zero, From: from}); err == nil {
+			t.Logf("from %d expected error for zero quantity", from)
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,1577 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: bolson/v1/bolson.proto
+
+package bolsonpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TaxMode int32
+
+const (
+	TaxMode_TAX_MODE_PERCENTUAL  TaxMode = 0
+	TaxMode_TAX_MODE_AMOUNT_LINE TaxMode = 1
+	TaxMode_TAX_MODE_AMOUNT_UNIT TaxMode = 2
+)
+
+// Enum value maps for TaxMode.
+var (
+	TaxMode_name = map[int32]string{
+		0: "TAX_MODE_PERCENTUAL",
+		1: "TAX_MODE_AMOUNT_LINE",
+		2: "TAX_MODE_AMOUNT_UNIT",
+	}
+	TaxMode_value = map[string]int32{
+		"TAX_MODE_PERCENTUAL":  0,
+		"TAX_MODE_AMOUNT_LINE": 1,
+		"TAX_MODE_AMOUNT_UNIT": 2,
+	}
+)
+
+func (x TaxMode) Enum() *TaxMode {
+	p := new(TaxMode)
+	*p = x
+	return p
+}
+
+func (x TaxMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaxMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_bolson_v1_bolson_proto_enumTypes[0].Descriptor()
+}
+
+func (TaxMode) Type() protoreflect.EnumType {
+	return &file_bolson_v1_bolson_proto_enumTypes[0]
+}
+
+func (x TaxMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaxMode.Descriptor instead.
+func (TaxMode) EnumDescriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{0}
+}
+
+type TaxStage int32
+
+const (
+	TaxStage_TAX_STAGE_OVER_TAXABLE       TaxStage = 0
+	TaxStage_TAX_STAGE_OVER_TAX           TaxStage = 1
+	TaxStage_TAX_STAGE_OVER_TAX_IGNORABLE TaxStage = 2
+)
+
+// Enum value maps for TaxStage.
+var (
+	TaxStage_name = map[int32]string{
+		0: "TAX_STAGE_OVER_TAXABLE",
+		1: "TAX_STAGE_OVER_TAX",
+		2: "TAX_STAGE_OVER_TAX_IGNORABLE",
+	}
+	TaxStage_value = map[string]int32{
+		"TAX_STAGE_OVER_TAXABLE":       0,
+		"TAX_STAGE_OVER_TAX":           1,
+		"TAX_STAGE_OVER_TAX_IGNORABLE": 2,
+	}
+)
+
+func (x TaxStage) Enum() *TaxStage {
+	p := new(TaxStage)
+	*p = x
+	return p
+}
+
+func (x TaxStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaxStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_bolson_v1_bolson_proto_enumTypes[1].Descriptor()
+}
+
+func (TaxStage) Type() protoreflect.EnumType {
+	return &file_bolson_v1_bolson_proto_enumTypes[1]
+}
+
+func (x TaxStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaxStage.Descriptor instead.
+func (TaxStage) EnumDescriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{1}
+}
+
+type TaxBase int32
+
+const (
+	TaxBase_TAX_BASE_DISCOUNTED TaxBase = 0
+	TaxBase_TAX_BASE_LIST_PRICE TaxBase = 1
+)
+
+// Enum value maps for TaxBase.
+var (
+	TaxBase_name = map[int32]string{
+		0: "TAX_BASE_DISCOUNTED",
+		1: "TAX_BASE_LIST_PRICE",
+	}
+	TaxBase_value = map[string]int32{
+		"TAX_BASE_DISCOUNTED": 0,
+		"TAX_BASE_LIST_PRICE": 1,
+	}
+)
+
+func (x TaxBase) Enum() *TaxBase {
+	p := new(TaxBase)
+	*p = x
+	return p
+}
+
+func (x TaxBase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaxBase) Descriptor() protoreflect.EnumDescriptor {
+	return file_bolson_v1_bolson_proto_enumTypes[2].Descriptor()
+}
+
+func (TaxBase) Type() protoreflect.EnumType {
+	return &file_bolson_v1_bolson_proto_enumTypes[2]
+}
+
+func (x TaxBase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaxBase.Descriptor instead.
+func (TaxBase) EnumDescriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{2}
+}
+
+type DiscountMode int32
+
+const (
+	DiscountMode_DISCOUNT_MODE_PERCENTUAL  DiscountMode = 0
+	DiscountMode_DISCOUNT_MODE_AMOUNT_LINE DiscountMode = 1
+	DiscountMode_DISCOUNT_MODE_AMOUNT_UNIT DiscountMode = 2
+)
+
+// Enum value maps for DiscountMode.
+var (
+	DiscountMode_name = map[int32]string{
+		0: "DISCOUNT_MODE_PERCENTUAL",
+		1: "DISCOUNT_MODE_AMOUNT_LINE",
+		2: "DISCOUNT_MODE_AMOUNT_UNIT",
+	}
+	DiscountMode_value = map[string]int32{
+		"DISCOUNT_MODE_PERCENTUAL":  0,
+		"DISCOUNT_MODE_AMOUNT_LINE": 1,
+		"DISCOUNT_MODE_AMOUNT_UNIT": 2,
+	}
+)
+
+func (x DiscountMode) Enum() *DiscountMode {
+	p := new(DiscountMode)
+	*p = x
+	return p
+}
+
+func (x DiscountMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DiscountMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_bolson_v1_bolson_proto_enumTypes[3].Descriptor()
+}
+
+func (DiscountMode) Type() protoreflect.EnumType {
+	return &file_bolson_v1_bolson_proto_enumTypes[3]
+}
+
+func (x DiscountMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DiscountMode.Descriptor instead.
+func (DiscountMode) EnumDescriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{3}
+}
+
+type Origin int32
+
+const (
+	Origin_ORIGIN_UNIT_VALUE Origin = 0
+	Origin_ORIGIN_BRUTE      Origin = 1
+	Origin_ORIGIN_BRUTE_WD   Origin = 2
+)
+
+// Enum value maps for Origin.
+var (
+	Origin_name = map[int32]string{
+		0: "ORIGIN_UNIT_VALUE",
+		1: "ORIGIN_BRUTE",
+		2: "ORIGIN_BRUTE_WD",
+	}
+	Origin_value = map[string]int32{
+		"ORIGIN_UNIT_VALUE": 0,
+		"ORIGIN_BRUTE":      1,
+		"ORIGIN_BRUTE_WD":   2,
+	}
+)
+
+func (x Origin) Enum() *Origin {
+	p := new(Origin)
+	*p = x
+	return p
+}
+
+func (x Origin) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Origin) Descriptor() protoreflect.EnumDescriptor {
+	return file_bolson_v1_bolson_proto_enumTypes[4].Descriptor()
+}
+
+func (Origin) Type() protoreflect.EnumType {
+	return &file_bolson_v1_bolson_proto_enumTypes[4]
+}
+
+func (x Origin) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Origin.Descriptor instead.
+func (Origin) EnumDescriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{4}
+}
+
+type Tax struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Mode          TaxMode                `protobuf:"varint,2,opt,name=mode,proto3,enum=bolson.v1.TaxMode" json:"mode,omitempty"`
+	Stage         TaxStage               `protobuf:"varint,3,opt,name=stage,proto3,enum=bolson.v1.TaxStage" json:"stage,omitempty"`
+	Base          TaxBase                `protobuf:"varint,4,opt,name=base,proto3,enum=bolson.v1.TaxBase" json:"base,omitempty"`
+	Code          string                 `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tax) Reset() {
+	*x = Tax{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tax) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tax) ProtoMessage() {}
+
+func (x *Tax) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tax.ProtoReflect.Descriptor instead.
+func (*Tax) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Tax) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Tax) GetMode() TaxMode {
+	if x != nil {
+		return x.Mode
+	}
+	return TaxMode_TAX_MODE_PERCENTUAL
+}
+
+func (x *Tax) GetStage() TaxStage {
+	if x != nil {
+		return x.Stage
+	}
+	return TaxStage_TAX_STAGE_OVER_TAXABLE
+}
+
+func (x *Tax) GetBase() TaxBase {
+	if x != nil {
+		return x.Base
+	}
+	return TaxBase_TAX_BASE_DISCOUNTED
+}
+
+func (x *Tax) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type Discount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Mode          DiscountMode           `protobuf:"varint,2,opt,name=mode,proto3,enum=bolson.v1.DiscountMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Discount) Reset() {
+	*x = Discount{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Discount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Discount) ProtoMessage() {}
+
+func (x *Discount) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Discount.ProtoReflect.Descriptor instead.
+func (*Discount) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Discount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Discount) GetMode() DiscountMode {
+	if x != nil {
+		return x.Mode
+	}
+	return DiscountMode_DISCOUNT_MODE_PERCENTUAL
+}
+
+type Config struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Taxes         []*Tax                 `protobuf:"bytes,1,rep,name=taxes,proto3" json:"taxes,omitempty"`
+	Discounts     []*Discount            `protobuf:"bytes,2,rep,name=discounts,proto3" json:"discounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Config) GetTaxes() []*Tax {
+	if x != nil {
+		return x.Taxes
+	}
+	return nil
+}
+
+func (x *Config) GetDiscounts() []*Discount {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
+}
+
+type Line struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Qty           string                 `protobuf:"bytes,2,opt,name=qty,proto3" json:"qty,omitempty"`
+	MaxDiscount   string                 `protobuf:"bytes,3,opt,name=max_discount,json=maxDiscount,proto3" json:"max_discount,omitempty"`
+	From          Origin                 `protobuf:"varint,4,opt,name=from,proto3,enum=bolson.v1.Origin" json:"from,omitempty"`
+	Attributes    *Attributes            `protobuf:"bytes,5,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Line) Reset() {
+	*x = Line{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Line) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Line) ProtoMessage() {}
+
+func (x *Line) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Line.ProtoReflect.Descriptor instead.
+func (*Line) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Line) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Line) GetQty() string {
+	if x != nil {
+		return x.Qty
+	}
+	return ""
+}
+
+func (x *Line) GetMaxDiscount() string {
+	if x != nil {
+		return x.MaxDiscount
+	}
+	return ""
+}
+
+func (x *Line) GetFrom() Origin {
+	if x != nil {
+		return x.From
+	}
+	return Origin_ORIGIN_UNIT_VALUE
+}
+
+func (x *Line) GetAttributes() *Attributes {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type Attributes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Segment       string                 `protobuf:"bytes,2,opt,name=segment,proto3" json:"segment,omitempty"`
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attributes) Reset() {
+	*x = Attributes{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attributes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attributes) ProtoMessage() {}
+
+func (x *Attributes) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attributes.ProtoReflect.Descriptor instead.
+func (*Attributes) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Attributes) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Attributes) GetSegment() string {
+	if x != nil {
+		return x.Segment
+	}
+	return ""
+}
+
+func (x *Attributes) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type WithDiscountValues struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Net                  string                 `protobuf:"bytes,1,opt,name=net,proto3" json:"net,omitempty"`
+	Brute                string                 `protobuf:"bytes,2,opt,name=brute,proto3" json:"brute,omitempty"`
+	Tax                  string                 `protobuf:"bytes,3,opt,name=tax,proto3" json:"tax,omitempty"`
+	Discount             string                 `protobuf:"bytes,4,opt,name=discount,proto3" json:"discount,omitempty"`
+	DiscountedValue      string                 `protobuf:"bytes,5,opt,name=discounted_value,json=discountedValue,proto3" json:"discounted_value,omitempty"`
+	DiscountedValueBrute string                 `protobuf:"bytes,6,opt,name=discounted_value_brute,json=discountedValueBrute,proto3" json:"discounted_value_brute,omitempty"`
+	UnitValue            string                 `protobuf:"bytes,7,opt,name=unit_value,json=unitValue,proto3" json:"unit_value,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *WithDiscountValues) Reset() {
+	*x = WithDiscountValues{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithDiscountValues) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithDiscountValues) ProtoMessage() {}
+
+func (x *WithDiscountValues) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithDiscountValues.ProtoReflect.Descriptor instead.
+func (*WithDiscountValues) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WithDiscountValues) GetNet() string {
+	if x != nil {
+		return x.Net
+	}
+	return ""
+}
+
+func (x *WithDiscountValues) GetBrute() string {
+	if x != nil {
+		return x.Brute
+	}
+	return ""
+}
+
+func (x *WithDiscountValues) GetTax() string {
+	if x != nil {
+		return x.Tax
+	}
+	return ""
+}
+
+func (x *WithDiscountValues) GetDiscount() string {
+	if x != nil {
+		return x.Discount
+	}
+	return ""
+}
+
+func (x *WithDiscountValues) GetDiscountedValue() string {
+	if x != nil {
+		return x.DiscountedValue
+	}
+	return ""
+}
+
+func (x *WithDiscountValues) GetDiscountedValueBrute() string {
+	if x != nil {
+		return x.DiscountedValueBrute
+	}
+	return ""
+}
+
+func (x *WithDiscountValues) GetUnitValue() string {
+	if x != nil {
+		return x.UnitValue
+	}
+	return ""
+}
+
+type WithoutDiscountValues struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Net           string                 `protobuf:"bytes,1,opt,name=net,proto3" json:"net,omitempty"`
+	Brute         string                 `protobuf:"bytes,2,opt,name=brute,proto3" json:"brute,omitempty"`
+	Tax           string                 `protobuf:"bytes,3,opt,name=tax,proto3" json:"tax,omitempty"`
+	UnitValue     string                 `protobuf:"bytes,4,opt,name=unit_value,json=unitValue,proto3" json:"unit_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithoutDiscountValues) Reset() {
+	*x = WithoutDiscountValues{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithoutDiscountValues) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithoutDiscountValues) ProtoMessage() {}
+
+func (x *WithoutDiscountValues) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithoutDiscountValues.ProtoReflect.Descriptor instead.
+func (*WithoutDiscountValues) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WithoutDiscountValues) GetNet() string {
+	if x != nil {
+		return x.Net
+	}
+	return ""
+}
+
+func (x *WithoutDiscountValues) GetBrute() string {
+	if x != nil {
+		return x.Brute
+	}
+	return ""
+}
+
+func (x *WithoutDiscountValues) GetTax() string {
+	if x != nil {
+		return x.Tax
+	}
+	return ""
+}
+
+func (x *WithoutDiscountValues) GetUnitValue() string {
+	if x != nil {
+		return x.UnitValue
+	}
+	return ""
+}
+
+type Bag struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WithDiscount    *WithDiscountValues    `protobuf:"bytes,1,opt,name=with_discount,json=withDiscount,proto3" json:"with_discount,omitempty"`
+	WithoutDiscount *WithoutDiscountValues `protobuf:"bytes,2,opt,name=without_discount,json=withoutDiscount,proto3" json:"without_discount,omitempty"`
+	Fired           []string               `protobuf:"bytes,3,rep,name=fired,proto3" json:"fired,omitempty"`
+	Rates           map[string]string      `protobuf:"bytes,4,rep,name=rates,proto3" json:"rates,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Taxes           []*TaxDetail           `protobuf:"bytes,5,rep,name=taxes,proto3" json:"taxes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Bag) Reset() {
+	*x = Bag{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bag) ProtoMessage() {}
+
+func (x *Bag) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bag.ProtoReflect.Descriptor instead.
+func (*Bag) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Bag) GetWithDiscount() *WithDiscountValues {
+	if x != nil {
+		return x.WithDiscount
+	}
+	return nil
+}
+
+func (x *Bag) GetWithoutDiscount() *WithoutDiscountValues {
+	if x != nil {
+		return x.WithoutDiscount
+	}
+	return nil
+}
+
+func (x *Bag) GetFired() []string {
+	if x != nil {
+		return x.Fired
+	}
+	return nil
+}
+
+func (x *Bag) GetRates() map[string]string {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+func (x *Bag) GetTaxes() []*TaxDetail {
+	if x != nil {
+		return x.Taxes
+	}
+	return nil
+}
+
+type TaxDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Mode          TaxMode                `protobuf:"varint,3,opt,name=mode,proto3,enum=bolson.v1.TaxMode" json:"mode,omitempty"`
+	Stage         TaxStage               `protobuf:"varint,4,opt,name=stage,proto3,enum=bolson.v1.TaxStage" json:"stage,omitempty"`
+	Base          TaxBase                `protobuf:"varint,5,opt,name=base,proto3,enum=bolson.v1.TaxBase" json:"base,omitempty"`
+	Taxable       string                 `protobuf:"bytes,6,opt,name=taxable,proto3" json:"taxable,omitempty"`
+	Amount        string                 `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	TaxableWd     string                 `protobuf:"bytes,8,opt,name=taxable_wd,json=taxableWd,proto3" json:"taxable_wd,omitempty"`
+	AmountWd      string                 `protobuf:"bytes,9,opt,name=amount_wd,json=amountWd,proto3" json:"amount_wd,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaxDetail) Reset() {
+	*x = TaxDetail{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaxDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxDetail) ProtoMessage() {}
+
+func (x *TaxDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxDetail.ProtoReflect.Descriptor instead.
+func (*TaxDetail) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TaxDetail) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *TaxDetail) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TaxDetail) GetMode() TaxMode {
+	if x != nil {
+		return x.Mode
+	}
+	return TaxMode_TAX_MODE_PERCENTUAL
+}
+
+func (x *TaxDetail) GetStage() TaxStage {
+	if x != nil {
+		return x.Stage
+	}
+	return TaxStage_TAX_STAGE_OVER_TAXABLE
+}
+
+func (x *TaxDetail) GetBase() TaxBase {
+	if x != nil {
+		return x.Base
+	}
+	return TaxBase_TAX_BASE_DISCOUNTED
+}
+
+func (x *TaxDetail) GetTaxable() string {
+	if x != nil {
+		return x.Taxable
+	}
+	return ""
+}
+
+func (x *TaxDetail) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *TaxDetail) GetTaxableWd() string {
+	if x != nil {
+		return x.TaxableWd
+	}
+	return ""
+}
+
+func (x *TaxDetail) GetAmountWd() string {
+	if x != nil {
+		return x.AmountWd
+	}
+	return ""
+}
+
+type TaxTotal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Taxable       string                 `protobuf:"bytes,2,opt,name=taxable,proto3" json:"taxable,omitempty"`
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaxTotal) Reset() {
+	*x = TaxTotal{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaxTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxTotal) ProtoMessage() {}
+
+func (x *TaxTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxTotal.ProtoReflect.Descriptor instead.
+func (*TaxTotal) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TaxTotal) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *TaxTotal) GetTaxable() string {
+	if x != nil {
+		return x.Taxable
+	}
+	return ""
+}
+
+func (x *TaxTotal) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+type Totals struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Net             string                 `protobuf:"bytes,1,opt,name=net,proto3" json:"net,omitempty"`
+	Tax             string                 `protobuf:"bytes,2,opt,name=tax,proto3" json:"tax,omitempty"`
+	Brute           string                 `protobuf:"bytes,3,opt,name=brute,proto3" json:"brute,omitempty"`
+	DiscountedValue string                 `protobuf:"bytes,4,opt,name=discounted_value,json=discountedValue,proto3" json:"discounted_value,omitempty"`
+	NetWd           string                 `protobuf:"bytes,5,opt,name=net_wd,json=netWd,proto3" json:"net_wd,omitempty"`
+	TaxWd           string                 `protobuf:"bytes,6,opt,name=tax_wd,json=taxWd,proto3" json:"tax_wd,omitempty"`
+	BruteWd         string                 `protobuf:"bytes,7,opt,name=brute_wd,json=bruteWd,proto3" json:"brute_wd,omitempty"`
+	Taxes           []*TaxTotal            `protobuf:"bytes,8,rep,name=taxes,proto3" json:"taxes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Totals) Reset() {
+	*x = Totals{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Totals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Totals) ProtoMessage() {}
+
+func (x *Totals) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Totals.ProtoReflect.Descriptor instead.
+func (*Totals) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Totals) GetNet() string {
+	if x != nil {
+		return x.Net
+	}
+	return ""
+}
+
+func (x *Totals) GetTax() string {
+	if x != nil {
+		return x.Tax
+	}
+	return ""
+}
+
+func (x *Totals) GetBrute() string {
+	if x != nil {
+		return x.Brute
+	}
+	return ""
+}
+
+func (x *Totals) GetDiscountedValue() string {
+	if x != nil {
+		return x.DiscountedValue
+	}
+	return ""
+}
+
+func (x *Totals) GetNetWd() string {
+	if x != nil {
+		return x.NetWd
+	}
+	return ""
+}
+
+func (x *Totals) GetTaxWd() string {
+	if x != nil {
+		return x.TaxWd
+	}
+	return ""
+}
+
+func (x *Totals) GetBruteWd() string {
+	if x != nil {
+		return x.BruteWd
+	}
+	return ""
+}
+
+func (x *Totals) GetTaxes() []*TaxTotal {
+	if x != nil {
+		return x.Taxes
+	}
+	return nil
+}
+
+type CalculateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *Config                `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Line          *Line                  `protobuf:"bytes,2,opt,name=line,proto3" json:"line,omitempty"`
+	Scale         *int32                 `protobuf:"varint,3,opt,name=scale,proto3,oneof" json:"scale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalculateRequest) Reset() {
+	*x = CalculateRequest{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateRequest) ProtoMessage() {}
+
+func (x *CalculateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateRequest.ProtoReflect.Descriptor instead.
+func (*CalculateRequest) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CalculateRequest) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *CalculateRequest) GetLine() *Line {
+	if x != nil {
+		return x.Line
+	}
+	return nil
+}
+
+func (x *CalculateRequest) GetScale() int32 {
+	if x != nil && x.Scale != nil {
+		return *x.Scale
+	}
+	return 0
+}
+
+type CalculateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bag           *Bag                   `protobuf:"bytes,1,opt,name=bag,proto3" json:"bag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalculateResponse) Reset() {
+	*x = CalculateResponse{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateResponse) ProtoMessage() {}
+
+func (x *CalculateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateResponse.ProtoReflect.Descriptor instead.
+func (*CalculateResponse) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CalculateResponse) GetBag() *Bag {
+	if x != nil {
+		return x.Bag
+	}
+	return nil
+}
+
+type DocumentLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          *Line                  `protobuf:"bytes,1,opt,name=line,proto3" json:"line,omitempty"`
+	Config        *Config                `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentLine) Reset() {
+	*x = DocumentLine{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentLine) ProtoMessage() {}
+
+func (x *DocumentLine) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentLine.ProtoReflect.Descriptor instead.
+func (*DocumentLine) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DocumentLine) GetLine() *Line {
+	if x != nil {
+		return x.Line
+	}
+	return nil
+}
+
+func (x *DocumentLine) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type CalculateDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *Config                `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Lines         []*DocumentLine        `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	Scale         *int32                 `protobuf:"varint,3,opt,name=scale,proto3,oneof" json:"scale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalculateDocumentRequest) Reset() {
+	*x = CalculateDocumentRequest{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculateDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateDocumentRequest) ProtoMessage() {}
+
+func (x *CalculateDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateDocumentRequest.ProtoReflect.Descriptor instead.
+func (*CalculateDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CalculateDocumentRequest) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *CalculateDocumentRequest) GetLines() []*DocumentLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *CalculateDocumentRequest) GetScale() int32 {
+	if x != nil && x.Scale != nil {
+		return *x.Scale
+	}
+	return 0
+}
+
+type CalculateDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lines         []*Bag                 `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	Totals        *Totals                `protobuf:"bytes,2,opt,name=totals,proto3" json:"totals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalculateDocumentResponse) Reset() {
+	*x = CalculateDocumentResponse{}
+	mi := &file_bolson_v1_bolson_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculateDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateDocumentResponse) ProtoMessage() {}
+
+func (x *CalculateDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bolson_v1_bolson_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateDocumentResponse.ProtoReflect.Descriptor instead.
+func (*CalculateDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_bolson_v1_bolson_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CalculateDocumentResponse) GetLines() []*Bag {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *CalculateDocumentResponse) GetTotals() *Totals {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+var File_bolson_v1_bolson_proto protoreflect.FileDescriptor
+
+const file_bolson_v1_bolson_proto_rawDesc = "" +
+	"\n" +
+	"\x16bolson/v1/bolson.proto\x12\tbolson.v1\"\xaa\x01\n" +
+	"\x03Tax\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12&\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x12.bolson.v1.TaxModeR\x04mode\x12)\n" +
+	"\x05stage\x18\x03 \x01(\x0e2\x13.bolson.v1.TaxStageR\x05stage\x12&\n" +
+	"\x04base\x18\x04 \x01(\x0e2\x12.bolson.v1.TaxBaseR\x04base\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\"M\n" +
+	"\bDiscount\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12+\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x17.bolson.v1.DiscountModeR\x04mode\"a\n" +
+	"\x06Config\x12$\n" +
+	"\x05taxes\x18\x01 \x03(\v2\x0e.bolson.v1.TaxR\x05taxes\x121\n" +
+	"\tdiscounts\x18\x02 \x03(\v2\x13.bolson.v1.DiscountR\tdiscounts\"\xaf\x01\n" +
+	"\x04Line\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x10\n" +
+	"\x03qty\x18\x02 \x01(\tR\x03qty\x12!\n" +
+	"\fmax_discount\x18\x03 \x01(\tR\vmaxDiscount\x12%\n" +
+	"\x04from\x18\x04 \x01(\x0e2\x11.bolson.v1.OriginR\x04from\x125\n" +
+	"\n" +
+	"attributes\x18\x05 \x01(\v2\x15.bolson.v1.AttributesR\n" +
+	"attributes\"V\n" +
+	"\n" +
+	"Attributes\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x18\n" +
+	"\asegment\x18\x02 \x01(\tR\asegment\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\"\xea\x01\n" +
+	"\x12WithDiscountValues\x12\x10\n" +
+	"\x03net\x18\x01 \x01(\tR\x03net\x12\x14\n" +
+	"\x05brute\x18\x02 \x01(\tR\x05brute\x12\x10\n" +
+	"\x03tax\x18\x03 \x01(\tR\x03tax\x12\x1a\n" +
+	"\bdiscount\x18\x04 \x01(\tR\bdiscount\x12)\n" +
+	"\x10discounted_value\x18\x05 \x01(\tR\x0fdiscountedValue\x124\n" +
+	"\x16discounted_value_brute\x18\x06 \x01(\tR\x14discountedValueBrute\x12\x1d\n" +
+	"\n" +
+	"unit_value\x18\a \x01(\tR\tunitValue\"p\n" +
+	"\x15WithoutDiscountValues\x12\x10\n" +
+	"\x03net\x18\x01 \x01(\tR\x03net\x12\x14\n" +
+	"\x05brute\x18\x02 \x01(\tR\x05brute\x12\x10\n" +
+	"\x03tax\x18\x03 \x01(\tR\x03tax\x12\x1d\n" +
+	"\n" +
+	"unit_value\x18\x04 \x01(\tR\tunitValue\"\xc3\x02\n" +
+	"\x03Bag\x12B\n" +
+	"\rwith_discount\x18\x01 \x01(\v2\x1d.bolson.v1.WithDiscountValuesR\fwithDiscount\x12K\n" +
+	"\x10without_discount\x18\x02 \x01(\v2 .bolson.v1.WithoutDiscountValuesR\x0fwithoutDiscount\x12\x14\n" +
+	"\x05fired\x18\x03 \x03(\tR\x05fired\x12/\n" +
+	"\x05rates\x18\x04 \x03(\v2\x19.bolson.v1.Bag.RatesEntryR\x05rates\x12*\n" +
+	"\x05taxes\x18\x05 \x03(\v2\x14.bolson.v1.TaxDetailR\x05taxes\x1a8\n" +
+	"\n" +
+	"RatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9e\x02\n" +
+	"\tTaxDetail\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12&\n" +
+	"\x04mode\x18\x03 \x01(\x0e2\x12.bolson.v1.TaxModeR\x04mode\x12)\n" +
+	"\x05stage\x18\x04 \x01(\x0e2\x13.bolson.v1.TaxStageR\x05stage\x12&\n" +
+	"\x04base\x18\x05 \x01(\x0e2\x12.bolson.v1.TaxBaseR\x04base\x12\x18\n" +
+	"\ataxable\x18\x06 \x01(\tR\ataxable\x12\x16\n" +
+	"\x06amount\x18\a \x01(\tR\x06amount\x12\x1d\n" +
+	"\n" +
+	"taxable_wd\x18\b \x01(\tR\ttaxableWd\x12\x1b\n" +
+	"\tamount_wd\x18\t \x01(\tR\bamountWd\"P\n" +
+	"\bTaxTotal\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\ataxable\x18\x02 \x01(\tR\ataxable\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\"\xe1\x01\n" +
+	"\x06Totals\x12\x10\n" +
+	"\x03net\x18\x01 \x01(\tR\x03net\x12\x10\n" +
+	"\x03tax\x18\x02 \x01(\tR\x03tax\x12\x14\n" +
+	"\x05brute\x18\x03 \x01(\tR\x05brute\x12)\n" +
+	"\x10discounted_value\x18\x04 \x01(\tR\x0fdiscountedValue\x12\x15\n" +
+	"\x06net_wd\x18\x05 \x01(\tR\x05netWd\x12\x15\n" +
+	"\x06tax_wd\x18\x06 \x01(\tR\x05taxWd\x12\x19\n" +
+	"\bbrute_wd\x18\a \x01(\tR\abruteWd\x12)\n" +
+	"\x05taxes\x18\b \x03(\v2\x13.bolson.v1.TaxTotalR\x05taxes\"\x87\x01\n" +
+	"\x10CalculateRequest\x12)\n" +
+	"\x06config\x18\x01 \x01(\v2\x11.bolson.v1.ConfigR\x06config\x12#\n" +
+	"\x04line\x18\x02 \x01(\v2\x0f.bolson.v1.LineR\x04line\x12\x19\n" +
+	"\x05scale\x18\x03 \x01(\x05H\x00R\x05scale\x88\x01\x01B\b\n" +
+	"\x06_scale\"5\n" +
+	"\x11CalculateResponse\x12 \n" +
+	"\x03bag\x18\x01 \x01(\v2\x0e.bolson.v1.BagR\x03bag\"^\n" +
+	"\fDocumentLine\x12#\n" +
+	"\x04line\x18\x01 \x01(\v2\x0f.bolson.v1.LineR\x04line\x12)\n" +
+	"\x06config\x18\x02 \x01(\v2\x11.bolson.v1.ConfigR\x06config\"\x99\x01\n" +
+	"\x18CalculateDocumentRequest\x12)\n" +
+	"\x06config\x18\x01 \x01(\v2\x11.bolson.v1.ConfigR\x06config\x12-\n" +
+	"\x05lines\x18\x02 \x03(\v2\x17.bolson.v1.DocumentLineR\x05lines\x12\x19\n" +
+	"\x05scale\x18\x03 \x01(\x05H\x00R\x05scale\x88\x01\x01B\b\n" +
+	"\x06_scale\"l\n" +
+	"\x19CalculateDocumentResponse\x12$\n" +
+	"\x05lines\x18\x01 \x03(\v2\x0e.bolson.v1.BagR\x05lines\x12)\n" +
+	"\x06totals\x18\x02 \x01(\v2\x11.bolson.v1.TotalsR\x06totals*V\n" +
+	"\aTaxMode\x12\x17\n" +
+	"\x13TAX_MODE_PERCENTUAL\x10\x00\x12\x18\n" +
+	"\x14TAX_MODE_AMOUNT_LINE\x10\x01\x12\x18\n" +
+	"\x14TAX_MODE_AMOUNT_UNIT\x10\x02*`\n" +
+	"\bTaxStage\x12\x1a\n" +
+	"\x16TAX_STAGE_OVER_TAXABLE\x10\x00\x12\x16\n" +
+	"\x12TAX_STAGE_OVER_TAX\x10\x01\x12 \n" +
+	"\x1cTAX_STAGE_OVER_TAX_IGNORABLE\x10\x02*;\n" +
+	"\aTaxBase\x12\x17\n" +
+	"\x13TAX_BASE_DISCOUNTED\x10\x00\x12\x17\n" +
+	"\x13TAX_BASE_LIST_PRICE\x10\x01*j\n" +
+	"\fDiscountMode\x12\x1c\n" +
+	"\x18DISCOUNT_MODE_PERCENTUAL\x10\x00\x12\x1d\n" +
+	"\x19DISCOUNT_MODE_AMOUNT_LINE\x10\x01\x12\x1d\n" +
+	"\x19DISCOUNT_MODE_AMOUNT_UNIT\x10\x02*F\n" +
+	"\x06Origin\x12\x15\n" +
+	"\x11ORIGIN_UNIT_VALUE\x10\x00\x12\x10\n" +
+	"\fORIGIN_BRUTE\x10\x01\x12\x13\n" +
+	"\x0fORIGIN_BRUTE_WD\x10\x022\x8e\x02\n" +
+	"\x12CalculationService\x12F\n" +
+	"\tCalculate\x12\x1b.bolson.v1.CalculateRequest\x1a\x1c.bolson.v1.CalculateResponse\x12^\n" +
+	"\x11CalculateDocument\x12#.bolson.v1.CalculateDocumentRequest\x1a$.bolson.v1.CalculateDocumentResponse\x12P\n" +
+	"\x0fCalculateStream\x12\x1b.bolson.v1.CalculateRequest\x1a\x1c.bolson.v1.CalculateResponse(\x010\x01B;Z9github.com/profe-ajedrez/bolson/grpcapi/bolsonpb;bolsonpbb\x06proto3"
+
+var (
+	file_bolson_v1_bolson_proto_rawDescOnce sync.Once
+	file_bolson_v1_bolson_proto_rawDescData []byte
+)
+
+func file_bolson_v1_bolson_proto_rawDescGZIP() []byte {
+	file_bolson_v1_bolson_proto_rawDescOnce.Do(func() {
+		file_bolson_v1_bolson_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bolson_v1_bolson_proto_rawDesc), len(file_bolson_v1_bolson_proto_rawDesc)))
+	})
+	return file_bolson_v1_bolson_proto_rawDescData
+}
+
+var file_bolson_v1_bolson_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_bolson_v1_bolson_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_bolson_v1_bolson_proto_goTypes = []any{
+	(TaxMode)(0),                      // 0: bolson.v1.TaxMode
+	(TaxStage)(0),                     // 1: bolson.v1.TaxStage
+	(TaxBase)(0),                      // 2: bolson.v1.TaxBase
+	(DiscountMode)(0),                 // 3: bolson.v1.DiscountMode
+	(Origin)(0),                       // 4: bolson.v1.Origin
+	(*Tax)(nil),                       // 5: bolson.v1.Tax
+	(*Discount)(nil),                  // 6: bolson.v1.Discount
+	(*Config)(nil),                    // 7: bolson.v1.Config
+	(*Line)(nil),                      // 8: bolson.v1.Line
+	(*Attributes)(nil),                // 9: bolson.v1.Attributes
+	(*WithDiscountValues)(nil),        // 10: bolson.v1.WithDiscountValues
+	(*WithoutDiscountValues)(nil),     // 11: bolson.v1.WithoutDiscountValues
+	(*Bag)(nil),                       // 12: bolson.v1.Bag
+	(*TaxDetail)(nil),                 // 13: bolson.v1.TaxDetail
+	(*TaxTotal)(nil),                  // 14: bolson.v1.TaxTotal
+	(*Totals)(nil),                    // 15: bolson.v1.Totals
+	(*CalculateRequest)(nil),          // 16: bolson.v1.CalculateRequest
+	(*CalculateResponse)(nil),         // 17: bolson.v1.CalculateResponse
+	(*DocumentLine)(nil),              // 18: bolson.v1.DocumentLine
+	(*CalculateDocumentRequest)(nil),  // 19: bolson.v1.CalculateDocumentRequest
+	(*CalculateDocumentResponse)(nil), // 20: bolson.v1.CalculateDocumentResponse
+	nil,                               // 21: bolson.v1.Bag.RatesEntry
+}
+var file_bolson_v1_bolson_proto_depIdxs = []int32{
+	0,  // 0: bolson.v1.Tax.mode:type_name -> bolson.v1.TaxMode
+	1,  // 1: bolson.v1.Tax.stage:type_name -> bolson.v1.TaxStage
+	2,  // 2: bolson.v1.Tax.base:type_name -> bolson.v1.TaxBase
+	3,  // 3: bolson.v1.Discount.mode:type_name -> bolson.v1.DiscountMode
+	5,  // 4: bolson.v1.Config.taxes:type_name -> bolson.v1.Tax
+	6,  // 5: bolson.v1.Config.discounts:type_name -> bolson.v1.Discount
+	4,  // 6: bolson.v1.Line.from:type_name -> bolson.v1.Origin
+	9,  // 7: bolson.v1.Line.attributes:type_name -> bolson.v1.Attributes
+	10, // 8: bolson.v1.Bag.with_discount:type_name -> bolson.v1.WithDiscountValues
+	11, // 9: bolson.v1.Bag.without_discount:type_name -> bolson.v1.WithoutDiscountValues
+	21, // 10: bolson.v1.Bag.rates:type_name -> bolson.v1.Bag.RatesEntry
+	13, // 11: bolson.v1.Bag.taxes:type_name -> bolson.v1.TaxDetail
+	0,  // 12: bolson.v1.TaxDetail.mode:type_name -> bolson.v1.TaxMode
+	1,  // 13: bolson.v1.TaxDetail.stage:type_name -> bolson.v1.TaxStage
+	2,  // 14: bolson.v1.TaxDetail.base:type_name -> bolson.v1.TaxBase
+	14, // 15: bolson.v1.Totals.taxes:type_name -> bolson.v1.TaxTotal
+	7,  // 16: bolson.v1.CalculateRequest.config:type_name -> bolson.v1.Config
+	8,  // 17: bolson.v1.CalculateRequest.line:type_name -> bolson.v1.Line
+	12, // 18: bolson.v1.CalculateResponse.bag:type_name -> bolson.v1.Bag
+	8,  // 19: bolson.v1.DocumentLine.line:type_name -> bolson.v1.Line
+	7,  // 20: bolson.v1.DocumentLine.config:type_name -> bolson.v1.Config
+	7,  // 21: bolson.v1.CalculateDocumentRequest.config:type_name -> bolson.v1.Config
+	18, // 22: bolson.v1.CalculateDocumentRequest.lines:type_name -> bolson.v1.DocumentLine
+	12, // 23: bolson.v1.CalculateDocumentResponse.lines:type_name -> bolson.v1.Bag
+	15, // 24: bolson.v1.CalculateDocumentResponse.totals:type_name -> bolson.v1.Totals
+	16, // 25: bolson.v1.CalculationService.Calculate:input_type -> bolson.v1.CalculateRequest
+	19, // 26: bolson.v1.CalculationService.CalculateDocument:input_type -> bolson.v1.CalculateDocumentRequest
+	16, // 27: bolson.v1.CalculationService.CalculateStream:input_type -> bolson.v1.CalculateRequest
+	17, // 28: bolson.v1.CalculationService.Calculate:output_type -> bolson.v1.CalculateResponse
+	20, // 29: bolson.v1.CalculationService.CalculateDocument:output_type -> bolson.v1.CalculateDocumentResponse
+	17, // 30: bolson.v1.CalculationService.CalculateStream:output_type -> bolson.v1.CalculateResponse
+	28, // [28:31] is the sub-list for method output_type
+	25, // [25:28] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_bolson_v1_bolson_proto_init() }
+func file_bolson_v1_bolson_proto_init() {
+	if File_bolson_v1_bolson_proto != nil {
+		return
+	}
+	file_bolson_v1_bolson_proto_msgTypes[11].OneofWrappers = []any{}
+	file_bolson_v1_bolson_proto_msgTypes[14].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bolson_v1_bolson_proto_rawDesc), len(file_bolson_v1_bolson_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bolson_v1_bolson_proto_goTypes,
+		DependencyIndexes: file_bolson_v1_bolson_proto_depIdxs,
+		EnumInfos:         file_bolson_v1_bolson_proto_enumTypes,
+		MessageInfos:      file_bolson_v1_bolson_proto_msgTypes,
+	}.Build()
+	File_bolson_v1_bolson_proto = out.File
+	file_bolson_v1_bolson_proto_goTypes = nil
+	file_bolson_v1_bolson_proto_depIdxs = nil
+}
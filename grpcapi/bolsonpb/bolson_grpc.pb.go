@@ -0,0 +1,192 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: bolson/v1/bolson.proto
+
+package bolsonpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CalculationService_Calculate_FullMethodName         = "/bolson.v1.CalculationService/Calculate"
+	CalculationService_CalculateDocument_FullMethodName = "/bolson.v1.CalculationService/CalculateDocument"
+	CalculationService_CalculateStream_FullMethodName   = "/bolson.v1.CalculationService/CalculateStream"
+)
+
+// CalculationServiceClient is the client API for CalculationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CalculationServiceClient interface {
+	Calculate(ctx context.Context, in *CalculateRequest, opts ...grpc.CallOption) (*CalculateResponse, error)
+	CalculateDocument(ctx context.Context, in *CalculateDocumentRequest, opts ...grpc.CallOption) (*CalculateDocumentResponse, error)
+	CalculateStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CalculateRequest, CalculateResponse], error)
+}
+
+type calculationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCalculationServiceClient(cc grpc.ClientConnInterface) CalculationServiceClient {
+	return &calculationServiceClient{cc}
+}
+
+func (c *calculationServiceClient) Calculate(ctx context.Context, in *CalculateRequest, opts ...grpc.CallOption) (*CalculateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalculateResponse)
+	err := c.cc.Invoke(ctx, CalculationService_Calculate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculationServiceClient) CalculateDocument(ctx context.Context, in *CalculateDocumentRequest, opts ...grpc.CallOption) (*CalculateDocumentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalculateDocumentResponse)
+	err := c.cc.Invoke(ctx, CalculationService_CalculateDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculationServiceClient) CalculateStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CalculateRequest, CalculateResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CalculationService_ServiceDesc.Streams[0], CalculationService_CalculateStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CalculateRequest, CalculateResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalculationService_CalculateStreamClient = grpc.BidiStreamingClient[CalculateRequest, CalculateResponse]
+
+// CalculationServiceServer is the server API for CalculationService service.
+// All implementations must embed UnimplementedCalculationServiceServer
+// for forward compatibility.
+type CalculationServiceServer interface {
+	Calculate(context.Context, *CalculateRequest) (*CalculateResponse, error)
+	CalculateDocument(context.Context, *CalculateDocumentRequest) (*CalculateDocumentResponse, error)
+	CalculateStream(grpc.BidiStreamingServer[CalculateRequest, CalculateResponse]) error
+	mustEmbedUnimplementedCalculationServiceServer()
+}
+
+// UnimplementedCalculationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCalculationServiceServer struct{}
+
+func (UnimplementedCalculationServiceServer) Calculate(context.Context, *CalculateRequest) (*CalculateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Calculate not implemented")
+}
+func (UnimplementedCalculationServiceServer) CalculateDocument(context.Context, *CalculateDocumentRequest) (*CalculateDocumentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CalculateDocument not implemented")
+}
+func (UnimplementedCalculationServiceServer) CalculateStream(grpc.BidiStreamingServer[CalculateRequest, CalculateResponse]) error {
+	return status.Error(codes.Unimplemented, "method CalculateStream not implemented")
+}
+func (UnimplementedCalculationServiceServer) mustEmbedUnimplementedCalculationServiceServer() {}
+func (UnimplementedCalculationServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeCalculationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CalculationServiceServer will
+// result in compilation errors.
+type UnsafeCalculationServiceServer interface {
+	mustEmbedUnimplementedCalculationServiceServer()
+}
+
+func RegisterCalculationServiceServer(s grpc.ServiceRegistrar, srv CalculationServiceServer) {
+	// If the following call panics, it indicates UnimplementedCalculationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CalculationService_ServiceDesc, srv)
+}
+
+func _CalculationService_Calculate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculationServiceServer).Calculate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculationService_Calculate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculationServiceServer).Calculate(ctx, req.(*CalculateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculationService_CalculateDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculationServiceServer).CalculateDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CalculationService_CalculateDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculationServiceServer).CalculateDocument(ctx, req.(*CalculateDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculationService_CalculateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculationServiceServer).CalculateStream(&grpc.GenericServerStream[CalculateRequest, CalculateResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CalculationService_CalculateStreamServer = grpc.BidiStreamingServer[CalculateRequest, CalculateResponse]
+
+// CalculationService_ServiceDesc is the grpc.ServiceDesc for CalculationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CalculationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bolson.v1.CalculationService",
+	HandlerType: (*CalculationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Calculate",
+			Handler:    _CalculationService_Calculate_Handler,
+		},
+		{
+			MethodName: "CalculateDocument",
+			Handler:    _CalculationService_CalculateDocument_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CalculateStream",
+			Handler:       _CalculationService_CalculateStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "bolson/v1/bolson.proto",
+}
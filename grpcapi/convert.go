@@ -0,0 +1,263 @@
+package grpcapi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/grpcapi/bolsonpb"
+	"github.com/profe-ajedrez/bolson/index"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// ConfigFromProto converts c to [bolson.Config]
+func ConfigFromProto(c *bolsonpb.Config) (bolson.Config, error) {
+	var cfg bolson.Config
+
+	for i, t := range c.GetTaxes() {
+		value, err := parseDecimal(fmt.Sprintf("taxes[%d].value", i), t.GetValue(), decimal.Zero)
+
+		if err != nil {
+			return cfg, err
+		}
+
+		cfg.Taxes = append(cfg.Taxes, bolson.TaxConfig{
+			Code:  t.GetCode(),
+			Value: value,
+			Mode:  tax.Mode(t.GetMode()),
+			Stage: tax.Stage(t.GetStage()),
+			Base:  tax.Base(t.GetBase()),
+		})
+	}
+
+	for i, d := range c.GetDiscounts() {
+		value, err := parseDecimal(fmt.Sprintf("discounts[%d].value", i), d.GetValue(), decimal.Zero)
+
+		if err != nil {
+			return cfg, err
+		}
+
+		cfg.Discounts = append(cfg.Discounts, bolson.DiscountConfig{Value: value, Mode: discount.Mode(d.GetMode())})
+	}
+
+	return cfg, nil
+}
+
+// ConfigToProto converts c to its message
+func ConfigToProto(c bolson.Config) *bolsonpb.Config {
+	pc := &bolsonpb.Config{}
+
+	for _, t := range c.Taxes {
+		pc.Taxes = append(pc.Taxes, &bolsonpb.Tax{
+			Code:  t.Code,
+			Value: t.Value.String(),
+			Mode:  bolsonpb.TaxMode(t.Mode),
+			Stage: bolsonpb.TaxStage(t.Stage),
+			Base:  bolsonpb.TaxBase(t.Base),
+		})
+	}
+
+	for _, d := range c.Discounts {
+		pc.Discounts = append(pc.Discounts, &bolsonpb.Discount{Value: d.Value.String(), Mode: bolsonpb.DiscountMode(d.Mode)})
+	}
+
+	return pc
+}
+
+// LineFromProto converts l to [bolson.Line], with qty 1 and max discount 100 when they are empty
+func LineFromProto(l *bolsonpb.Line) (bolson.Line, error) {
+	line := bolson.Line{From: bolson.Origin(l.GetFrom())}
+
+	var err error
+
+	if line.Value, err = parseDecimal("value", l.GetValue(), decimal.Zero); err != nil {
+		return line, err
+	}
+
+	if line.Qty, err = parseDecimal("qty", l.GetQty(), decimal.NewFromInt(1)); err != nil {
+		return line, err
+	}
+
+	if line.MaxDiscount, err = parseDecimal("max_discount", l.GetMaxDiscount(), decimal.NewFromInt(100)); err != nil {
+		return line, err
+	}
+
+	if a := l.GetAttributes(); a != nil {
+		line.Attributes = &tax.Attributes{Category: a.GetCategory(), Segment: a.GetSegment()}
+
+		if a.GetDate() != "" {
+			if line.Attributes.Date, err = time.Parse(time.RFC3339, a.GetDate()); err != nil {
+				return line, ErrInvalidField("attributes.date", a.GetDate())
+			}
+		}
+	}
+
+	return line, nil
+}
+
+// BagToProto converts b to its message
+func BagToProto(b bolson.Bag) *bolsonpb.Bag {
+	pb := &bolsonpb.Bag{
+		WithDiscount: &bolsonpb.WithDiscountValues{
+			Net:                  b.WithDiscount.Net.String(),
+			Brute:                b.WithDiscount.Brute.String(),
+			Tax:                  b.WithDiscount.Tax.String(),
+			Discount:             b.WithDiscount.Discount.String(),
+			DiscountedValue:      b.WithDiscount.DiscountedValue.String(),
+			DiscountedValueBrute: b.WithDiscount.DiscountedValueBrute.String(),
+			UnitValue:            b.WithDiscount.UnitValue.String(),
+		},
+		WithoutDiscount: &bolsonpb.WithoutDiscountValues{
+			Net:       b.WithoutDiscount.Net.String(),
+			Brute:     b.WithoutDiscount.Brute.String(),
+			Tax:       b.WithoutDiscount.Tax.String(),
+			UnitValue: b.WithoutDiscount.UnitValue.String(),
+		},
+		Fired: b.Fired,
+	}
+
+	for _, d := range b.Taxes {
+		pb.Taxes = append(pb.Taxes, &bolsonpb.TaxDetail{
+			Code:      d.Code,
+			Value:     d.Value.String(),
+			Mode:      bolsonpb.TaxMode(d.Mode),
+			Stage:     bolsonpb.TaxStage(d.Stage),
+			Base:      bolsonpb.TaxBase(d.Base),
+			Taxable:   d.Taxable.String(),
+			Amount:    d.Amount.String(),
+			TaxableWd: d.TaxableWD.String(),
+			AmountWd:  d.AmountWD.String(),
+		})
+	}
+
+	if len(b.Rates) > 0 {
+		pb.Rates = make(map[string]string, len(b.Rates))
+
+		for unit, rate := range b.Rates {
+			pb.Rates[string(unit)] = rate.String()
+		}
+	}
+
+	return pb
+}
+
+// BagFromProto converts b to [bolson.Bag]
+func BagFromProto(b *bolsonpb.Bag) (bolson.Bag, error) {
+	bag := bolson.Bag{Fired: b.GetFired()}
+
+	wd, wod := b.GetWithDiscount(), b.GetWithoutDiscount()
+
+	for _, f := range []struct {
+		name  string
+		value string
+		to    *decimal.Decimal
+	}{
+		{"with_discount.net", wd.GetNet(), &bag.WithDiscount.Net},
+		{"with_discount.brute", wd.GetBrute(), &bag.WithDiscount.Brute},
+		{"with_discount.tax", wd.GetTax(), &bag.WithDiscount.Tax},
+		{"with_discount.discount", wd.GetDiscount(), &bag.WithDiscount.Discount},
+		{"with_discount.discounted_value", wd.GetDiscountedValue(), &bag.WithDiscount.DiscountedValue},
+		{"with_discount.discounted_value_brute", wd.GetDiscountedValueBrute(), &bag.WithDiscount.DiscountedValueBrute},
+		{"with_discount.unit_value", wd.GetUnitValue(), &bag.WithDiscount.UnitValue},
+		{"without_discount.net", wod.GetNet(), &bag.WithoutDiscount.Net},
+		{"without_discount.brute", wod.GetBrute(), &bag.WithoutDiscount.Brute},
+		{"without_discount.tax", wod.GetTax(), &bag.WithoutDiscount.Tax},
+		{"without_discount.unit_value", wod.GetUnitValue(), &bag.WithoutDiscount.UnitValue},
+	} {
+		d, err := parseDecimal(f.name, f.value, decimal.Zero)
+
+		if err != nil {
+			return bag, err
+		}
+
+		*f.to = d
+	}
+
+	for i, pd := range b.GetTaxes() {
+		d := bolson.TaxDetail{
+			Code:  pd.GetCode(),
+			Mode:  tax.Mode(pd.GetMode()),
+			Stage: tax.Stage(pd.GetStage()),
+			Base:  tax.Base(pd.GetBase()),
+		}
+
+		for _, f := range []struct {
+			name  string
+			value string
+			to    *decimal.Decimal
+		}{
+			{"value", pd.GetValue(), &d.Value},
+			{"taxable", pd.GetTaxable(), &d.Taxable},
+			{"amount", pd.GetAmount(), &d.Amount},
+			{"taxable_wd", pd.GetTaxableWd(), &d.TaxableWD},
+			{"amount_wd", pd.GetAmountWd(), &d.AmountWD},
+		} {
+			v, err := parseDecimal(fmt.Sprintf("taxes[%d].%s", i, f.name), f.value, decimal.Zero)
+
+			if err != nil {
+				return bag, err
+			}
+
+			*f.to = v
+		}
+
+		bag.Taxes = append(bag.Taxes, d)
+	}
+
+	if len(b.GetRates()) > 0 {
+		bag.Rates = make(map[index.Unit]decimal.Decimal, len(b.GetRates()))
+
+		for unit, rate := range b.GetRates() {
+			d, err := parseDecimal("rates."+unit, rate, decimal.Zero)
+
+			if err != nil {
+				return bag, err
+			}
+
+			bag.Rates[index.Unit(unit)] = d
+		}
+	}
+
+	return bag, nil
+}
+
+// TotalsToProto converts t to its message
+func TotalsToProto(t bolson.Totals) *bolsonpb.Totals {
+	return &bolsonpb.Totals{
+		Net:             t.Net.String(),
+		Tax:             t.Tax.String(),
+		Brute:           t.Brute.String(),
+		DiscountedValue: t.DiscountedValue.String(),
+		NetWd:           t.NetWD.String(),
+		TaxWd:           t.TaxWD.String(),
+		BruteWd:         t.BruteWD.String(),
+		Taxes:           taxTotalsToProto(t.Taxes),
+	}
+}
+
+func taxTotalsToProto(totals []bolson.TaxTotal) []*bolsonpb.TaxTotal {
+	var pt []*bolsonpb.TaxTotal
+
+	for _, t := range totals {
+		pt = append(pt, &bolsonpb.TaxTotal{Code: t.Code, Taxable: t.Taxable.String(), Amount: t.Amount.String()})
+	}
+
+	return pt
+}
+
+// parseDecimal parses the field named name, returning def when it is empty
+func parseDecimal(name string, value string, def decimal.Decimal) (decimal.Decimal, error) {
+	if value == "" {
+		return def, nil
+	}
+
+	d, err := decimal.NewFromString(value)
+
+	if err != nil {
+		return d, ErrInvalidField(name, value)
+	}
+
+	return d, nil
+}
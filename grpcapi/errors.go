@@ -0,0 +1,70 @@
+package grpcapi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/profe-ajedrez/bolson"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is the domain of the ErrorInfo details of the errors
+const Domain = "bolson"
+
+// Codes of the errors not coming from the calculations
+const (
+	CodeInvalidRequest = "ErrInvalidRequest"
+	CodeCalculation    = "ErrCalculation"
+)
+
+// FieldError is the error of a field of the request which could not be parsed
+type FieldError struct {
+	Field string
+	Value string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("[%s] the field could not be parsed. %s %q", CodeInvalidRequest, e.Field, e.Value)
+}
+
+// ErrInvalidField the field of the request could not be parsed from value
+func ErrInvalidField(field string, value string) error {
+	return &FieldError{Field: field, Value: value}
+}
+
+// statusError converts err into an InvalidArgument status with an ErrorInfo detail, whose reason is the
+// code of err and whose metadata has the field and the line failing, when known
+func statusError(err error, line int) error {
+	code, field := bolson.ErrorCode(err)
+
+	var fieldErr *FieldError
+
+	if errors.As(err, &fieldErr) {
+		code, field = CodeInvalidRequest, fieldErr.Field
+	}
+
+	if code == "" {
+		code = CodeCalculation
+	}
+
+	info := &errdetails.ErrorInfo{Reason: code, Domain: Domain, Metadata: map[string]string{}}
+
+	if field != "" {
+		info.Metadata["field"] = field
+	}
+
+	if line >= 0 {
+		info.Metadata["line"] = strconv.Itoa(line)
+	}
+
+	st := status.New(codes.InvalidArgument, err.Error())
+
+	if detailed, derr := st.WithDetails(info); derr == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
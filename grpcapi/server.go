@@ -0,0 +1,160 @@
+// Package grpcapi implements the gRPC service of bolson.
+//
+// The messages are defined in proto/bolson/v1/bolson.proto and generated in the bolsonpb package. It is a
+// module apart from bolson, so the library doesnt depend on gRPC.
+package grpcapi
+
+//go:generate protoc -I proto --go_out=. --go_opt=module=github.com/profe-ajedrez/bolson/grpcapi --go-grpc_out=. --go-grpc_opt=module=github.com/profe-ajedrez/bolson/grpcapi bolson/v1/bolson.proto
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/grpcapi/bolsonpb"
+	"google.golang.org/grpc"
+)
+
+// Server implements [bolsonpb.CalculationServiceServer]
+type Server struct {
+	bolsonpb.UnimplementedCalculationServiceServer
+}
+
+// NewServer returns the implementation of the service
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Register registers the service in s
+func Register(s grpc.ServiceRegistrar) {
+	bolsonpb.RegisterCalculationServiceServer(s, NewServer())
+}
+
+// Calculate calculates a line from the value indicated by its origin
+func (s *Server) Calculate(ctx context.Context, req *bolsonpb.CalculateRequest) (*bolsonpb.CalculateResponse, error) {
+	calc, err := calculate(req)
+
+	if err != nil {
+		return nil, statusError(err, -1)
+	}
+
+	return &bolsonpb.CalculateResponse{Bag: BagToProto(calc)}, nil
+}
+
+// CalculateDocument calculates the lines of a document and its totals
+func (s *Server) CalculateDocument(ctx context.Context, req *bolsonpb.CalculateDocumentRequest) (*bolsonpb.CalculateDocumentResponse, error) {
+	cfg, err := ConfigFromProto(req.GetConfig())
+
+	if err != nil {
+		return nil, statusError(err, -1)
+	}
+
+	b, err := bolson.NewFromConfig(cfg)
+
+	if err != nil {
+		return nil, statusError(err, -1)
+	}
+
+	bags := make([]bolson.Bag, 0, len(req.GetLines()))
+
+	for i, l := range req.GetLines() {
+		lb := b
+
+		if l.GetConfig() != nil {
+			if cfg, err = ConfigFromProto(l.GetConfig()); err == nil {
+				lb, err = bolson.NewFromConfig(cfg)
+			}
+
+			if err != nil {
+				return nil, statusError(err, i)
+			}
+		}
+
+		line, err := LineFromProto(l.GetLine())
+
+		if err != nil {
+			return nil, statusError(err, i)
+		}
+
+		calc, err := lb.CalculateLine(line)
+
+		if err != nil {
+			return nil, statusError(err, i)
+		}
+
+		bags = append(bags, calc)
+	}
+
+	doc := bolson.NewDocument(bags...)
+
+	if req.Scale != nil {
+		doc = doc.Round(req.GetScale())
+	}
+
+	res := &bolsonpb.CalculateDocumentResponse{Totals: TotalsToProto(doc.Totals)}
+
+	for _, l := range doc.Lines {
+		res.Lines = append(res.Lines, BagToProto(l))
+	}
+
+	return res, nil
+}
+
+// CalculateStream calculates each line received, answering in the same order. A line failing ends the stream
+// with its error, being its line the position in the stream
+func (s *Server) CalculateStream(stream grpc.BidiStreamingServer[bolsonpb.CalculateRequest, bolsonpb.CalculateResponse]) error {
+	for i := 0; ; i++ {
+		req, err := stream.Recv()
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		calc, err := calculate(req)
+
+		if err != nil {
+			return statusError(err, i)
+		}
+
+		if err := stream.Send(&bolsonpb.CalculateResponse{Bag: BagToProto(calc)}); err != nil {
+			return err
+		}
+	}
+}
+
+func calculate(req *bolsonpb.CalculateRequest) (bolson.Bag, error) {
+	cfg, err := ConfigFromProto(req.GetConfig())
+
+	if err != nil {
+		return bolson.Bag{}, err
+	}
+
+	b, err := bolson.NewFromConfig(cfg)
+
+	if err != nil {
+		return bolson.Bag{}, err
+	}
+
+	line, err := LineFromProto(req.GetLine())
+
+	if err != nil {
+		return bolson.Bag{}, err
+	}
+
+	calc, err := b.CalculateLine(line)
+
+	if err != nil {
+		return calc, err
+	}
+
+	if req.Scale != nil {
+		calc = calc.Round(req.GetScale())
+	}
+
+	return calc, nil
+}
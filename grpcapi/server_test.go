@@ -0,0 +1,192 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/grpcapi/bolsonpb"
+	"github.com/shopspring/decimal"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+func client(t *testing.T) bolsonpb.CalculationServiceClient {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	Register(srv)
+
+	go func() { _ = srv.Serve(lis) }()
+
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	t.Cleanup(func() { conn.Close() })
+
+	return bolsonpb.NewCalculationServiceClient(conn)
+}
+
+var vat = &bolsonpb.Config{Taxes: []*bolsonpb.Tax{{Value: "19", Mode: bolsonpb.TaxMode_TAX_MODE_PERCENTUAL, Stage: bolsonpb.TaxStage_TAX_STAGE_OVER_TAXABLE}}}
+
+func TestCalculate(t *testing.T) {
+	c := client(t)
+
+	cfg := proto.Clone(vat).(*bolsonpb.Config)
+	cfg.Discounts = []*bolsonpb.Discount{{Value: "10", Mode: bolsonpb.DiscountMode_DISCOUNT_MODE_PERCENTUAL}}
+
+	res, err := c.Calculate(context.Background(), &bolsonpb.CalculateRequest{Config: cfg, Line: &bolsonpb.Line{Value: "100", Qty: "3"}})
+
+	if err != nil || res.GetBag().GetWithDiscount().GetBrute() != "321.3" {
+		t.Logf("expected brute 321.3, got %v %v", res, err)
+		t.FailNow()
+	}
+
+	res, err = c.Calculate(context.Background(), &bolsonpb.CalculateRequest{Config: vat, Line: &bolsonpb.Line{Value: "119", From: bolsonpb.Origin_ORIGIN_BRUTE}, Scale: proto.Int32(2)})
+
+	if err != nil || res.GetBag().GetWithoutDiscount().GetUnitValue() != "100" {
+		t.Logf("expected unit value 100, got %v %v", res, err)
+		t.FailNow()
+	}
+}
+
+func TestCalculateErrors(t *testing.T) {
+	c := client(t)
+
+	testCases := []struct {
+		req    *bolsonpb.CalculateRequest
+		reason string
+		field  string
+	}{
+		{&bolsonpb.CalculateRequest{Config: vat, Line: &bolsonpb.Line{Value: "x"}}, CodeInvalidRequest, "value"},
+		{&bolsonpb.CalculateRequest{Config: vat, Line: &bolsonpb.Line{Value: "1", Qty: "0"}}, "ErrNegativeQty", "qty"},
+		{&bolsonpb.CalculateRequest{Config: &bolsonpb.Config{Taxes: []*bolsonpb.Tax{{Value: "1", Stage: 9}}}, Line: &bolsonpb.Line{Value: "1"}}, "ErrInvalidTaxStage", "stage"},
+	}
+
+	for i, tc := range testCases {
+		_, err := c.Calculate(context.Background(), tc.req)
+
+		st, _ := status.FromError(err)
+
+		if st.Code() != codes.InvalidArgument || len(st.Details()) != 1 || st.Details()[0].(*errdetails.ErrorInfo).GetReason() != tc.reason ||
+			st.Details()[0].(*errdetails.ErrorInfo).GetMetadata()["field"] != tc.field {
+			t.Logf("Fail test case[%d] --- expected %s --- got %v %v", i, tc.reason, st, st.Details())
+			t.FailNow()
+		}
+	}
+}
+
+func TestCalculateDocument(t *testing.T) {
+	c := client(t)
+
+	res, err := c.CalculateDocument(context.Background(), &bolsonpb.CalculateDocumentRequest{
+		Config: vat,
+		Lines: []*bolsonpb.DocumentLine{
+			{Line: &bolsonpb.Line{Value: "100", Qty: "3"}},
+			{Line: &bolsonpb.Line{Value: "50"}, Config: &bolsonpb.Config{}},
+		},
+	})
+
+	if err != nil || len(res.GetLines()) != 2 || res.GetTotals().GetBrute() != "407" {
+		t.Logf("expected total 407, got %v %v", res, err)
+		t.FailNow()
+	}
+
+	_, err = c.CalculateDocument(context.Background(), &bolsonpb.CalculateDocumentRequest{Lines: []*bolsonpb.DocumentLine{{Line: &bolsonpb.Line{Value: "1"}}, {Line: &bolsonpb.Line{Value: "-1"}}}})
+
+	st, _ := status.FromError(err)
+
+	if len(st.Details()) != 1 || st.Details()[0].(*errdetails.ErrorInfo).GetMetadata()["line"] != "1" {
+		t.Logf("expected error in line 1, got %v", st)
+		t.FailNow()
+	}
+}
+
+func TestCalculateStream(t *testing.T) {
+	stream, err := client(t).CalculateStream(context.Background())
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	for _, v := range []string{"100", "200", "300"} {
+		if err := stream.Send(&bolsonpb.CalculateRequest{Config: vat, Line: &bolsonpb.Line{Value: v}}); err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+
+		res, err := stream.Recv()
+
+		if err != nil || res.GetBag().GetWithoutDiscount().GetNet() != v {
+			t.Logf("expected net %s, got %v %v", v, res, err)
+			t.FailNow()
+		}
+	}
+
+	_ = stream.CloseSend()
+}
+
+// TestMappingsCoverFields fails when a field is added to the bags without adding it to the messages
+func TestMappingsCoverFields(t *testing.T) {
+	for _, tc := range []struct {
+		value   any
+		message proto.Message
+	}{
+		{bolson.WithDiscountValues{}, &bolsonpb.WithDiscountValues{}},
+		{bolson.WithoutDiscountValues{}, &bolsonpb.WithoutDiscountValues{}},
+		{bolson.Bag{}, &bolsonpb.Bag{}},
+		{bolson.Totals{}, &bolsonpb.Totals{}},
+		{bolson.TaxDetail{}, &bolsonpb.TaxDetail{}},
+		{bolson.TaxTotal{}, &bolsonpb.TaxTotal{}},
+		{bolson.TaxConfig{}, &bolsonpb.Tax{}},
+		{bolson.DiscountConfig{}, &bolsonpb.Discount{}},
+		{bolson.Config{}, &bolsonpb.Config{}},
+		{bolson.Line{}, &bolsonpb.Line{}},
+	} {
+		fields := reflect.TypeOf(tc.value).NumField()
+		messageFields := tc.message.ProtoReflect().Descriptor().Fields().Len()
+
+		if fields != messageFields {
+			t.Logf("%T has %d fields but %T has %d", tc.value, fields, tc.message, messageFields)
+			t.FailNow()
+		}
+	}
+
+	bag := bolson.Bag{Fired: []string{"lux"}, Taxes: []bolson.TaxDetail{{Code: "14", Value: decimal.NewFromInt(19), Amount: decimal.RequireFromString("19.5")}}}
+	back, err := BagFromProto(BagToProto(bag))
+
+	if err != nil || !reflect.DeepEqual(back.Fired, bag.Fired) || len(back.Taxes) != 1 || !back.Taxes[0].Amount.Equal(bag.Taxes[0].Amount) {
+		t.Logf("expected %v back, got %v %v", bag, back, err)
+		t.FailNow()
+	}
+}
+
+func TestLineAttributesFromProto(t *testing.T) {
+	l, err := LineFromProto(&bolsonpb.Line{Value: "10", Attributes: &bolsonpb.Attributes{Category: "jewelry", Date: "2024-05-02T00:00:00Z"}})
+
+	if err != nil || l.Attributes == nil || l.Attributes.Category != "jewelry" || l.Attributes.Date.Day() != 2 {
+		t.Logf("unexpected line %v %v", l, err)
+		t.FailNow()
+	}
+
+	if _, err := LineFromProto(&bolsonpb.Line{Attributes: &bolsonpb.Attributes{Date: "yesterday"}}); err == nil {
+		t.Logf("expected an invalid date")
+		t.FailNow()
+	}
+}
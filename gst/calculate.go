@@ -0,0 +1,196 @@
+package gst
+
+import (
+	"strconv"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// paise are the decimals of the amounts
+const paise = 2
+
+var two = decimal.NewFromInt(2)
+
+// order is the order of the components in the totals
+var order = []Component{CGST, SGST, UTGST, IGST, Cess, CessSpecific}
+
+// Calculate calculates the GST of items supplied as s
+func Calculate(s Supply, items ...Item) (*Invoice, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	inv := &Invoice{Supply: s, Lines: make([]Line, 0, len(items))}
+	totals := map[Component]decimal.Decimal{}
+	summary := map[string]int{}
+
+	for i, it := range items {
+		l, err := s.line(it)
+
+		if err != nil {
+			return nil, ErrInvalidItem(i, err)
+		}
+
+		inv.Lines = append(inv.Lines, l)
+		inv.Taxable = inv.Taxable.Add(l.Taxable)
+
+		for _, a := range l.Taxes {
+			totals[a.Component] = totals[a.Component].Add(a.Amount)
+		}
+
+		key := it.HSN + "|" + it.Rate.GST.String()
+		k, ok := summary[key]
+
+		if !ok {
+			k, summary[key] = len(inv.HSN), len(inv.HSN)
+			inv.HSN = append(inv.HSN, Summary{HSN: it.HSN, Rate: it.Rate.GST})
+		}
+
+		inv.HSN[k].add(l)
+	}
+
+	inv.Total = inv.Taxable
+
+	for _, c := range order {
+		if amount, ok := totals[c]; ok {
+			inv.Taxes = append(inv.Taxes, Amount{Component: c, Amount: amount})
+			inv.Total = inv.Total.Add(amount)
+		}
+	}
+
+	inv.Payable = inv.Total.Round(0)
+	inv.RoundOff = inv.Payable.Sub(inv.Total)
+
+	return inv, nil
+}
+
+// line calculates the components of the item
+func (s Supply) line(it Item) (Line, error) {
+	l := Line{Item: it}
+
+	b := bolson.New()
+
+	if !it.Discount.IsZero() {
+		if err := b.AddDiscount(it.Discount, discount.AmountLine); err != nil {
+			return l, err
+		}
+	}
+
+	rates := s.components(it.Rate)
+
+	for _, a := range rates {
+		mode := tax.PercentualMode
+
+		if a.Component == CessSpecific {
+			mode = tax.AmountUnitMode
+		}
+
+		if err := b.AddCodedTax(string(a.Component), a.Rate, mode, tax.OverTaxable, tax.DiscountedBase); err != nil {
+			return l, err
+		}
+	}
+
+	calc, err := b.CalculateLine(bolson.Line{Value: it.Value, Qty: it.Qty, MaxDiscount: numbers.Hundred, From: bolson.FromUnitValue})
+
+	if err != nil {
+		return l, err
+	}
+
+	l.Calc = calc
+	l.Taxable = calc.WithDiscount.Net.Round(paise)
+	l.Total = l.Taxable
+
+	for i, d := range calc.Taxes {
+		a := rates[i]
+		a.Amount = d.Amount.Round(paise)
+
+		l.Taxes = append(l.Taxes, a)
+		l.Total = l.Total.Add(a.Amount)
+	}
+
+	return l, nil
+}
+
+// components returns the components levied over r, without amounts. The exports under a letter of
+// undertaking are zero rated
+func (s Supply) components(r Rate) []Amount {
+	if s.PlaceOfSupply == Export && s.LUT {
+		return nil
+	}
+
+	var c []Amount
+
+	switch {
+	case r.GST.IsZero():
+	case s.Interstate():
+		c = append(c, Amount{Component: IGST, Rate: r.GST})
+	default:
+		state := SGST
+
+		if unionTerritories[s.Supplier] {
+			state = UTGST
+		}
+
+		half := r.GST.Div(two)
+		c = append(c, Amount{Component: CGST, Rate: half}, Amount{Component: state, Rate: half})
+	}
+
+	if r.Cess.IsPositive() {
+		c = append(c, Amount{Component: Cess, Rate: r.Cess})
+	}
+
+	if r.CessSpecific.IsPositive() {
+		c = append(c, Amount{Component: CessSpecific, Rate: r.CessSpecific})
+	}
+
+	return c
+}
+
+// validate checks the state codes of s, from 01 to 38, 97 for other territory and 96 for the exports
+// as place of supply
+func (s Supply) validate() error {
+	if !validState(s.Supplier) {
+		return ErrInvalidState("supplier " + s.Supplier)
+	}
+
+	if !validState(s.PlaceOfSupply) && s.PlaceOfSupply != Export {
+		return ErrInvalidState("place of supply " + s.PlaceOfSupply)
+	}
+
+	return nil
+}
+
+func validState(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+
+	n, err := strconv.Atoi(code)
+
+	return err == nil && (n >= 1 && n <= 38 || n == 97)
+}
+
+// add adds the values of l to s
+func (s *Summary) add(l Line) {
+	s.Qty = s.Qty.Add(l.Qty)
+	s.Taxable = s.Taxable.Add(l.Taxable)
+
+	for _, a := range l.Taxes {
+		found := false
+
+		for i := range s.Taxes {
+			if s.Taxes[i].Component == a.Component {
+				s.Taxes[i].Amount = s.Taxes[i].Amount.Add(a.Amount)
+				found = true
+			}
+		}
+
+		if !found {
+			s.Taxes = append(s.Taxes, a)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package gst
+
+import (
+	"fmt"
+
+	"github.com/profe-ajedrez/bolson/internal/csvtable"
+)
+
+// ErrInvalidState the state code is not valid
+func ErrInvalidState(info any) error {
+	return fmt.Errorf("[ErrInvalidState] the state code is not valid. %v", info)
+}
+
+// ErrInvalidItem an item could not be calculated
+func ErrInvalidItem(index int, err error) error {
+	return fmt.Errorf("[ErrInvalidItem] the item %d could not be calculated. %w", index+1, err)
+}
+
+// ErrUnknownHSN the rate table has no rate for the HSN code
+func ErrUnknownHSN(info any) error {
+	return fmt.Errorf("[ErrUnknownHSN] the rate table has no rate for the HSN code. %v", info)
+}
+
+// ErrInvalidTable the rate table could not be read
+func ErrInvalidTable(row int, err error) error {
+	return csvtable.ErrInvalidTable(row, err)
+}
@@ -0,0 +1,139 @@
+// Package gst calculates the indian GST of invoices, split by the place of supply in CGST and SGST, or
+// UTGST, inside a state and IGST between states, with the compensation cess on top.
+//
+// Each component is a coded tax of bolson, so the lines report them apart and the invoice adds them up,
+// with the HSN summary:
+//
+//	invoice, err := gst.Calculate(gst.Supply{Supplier: "27", PlaceOfSupply: "29"}, items...)
+package gst
+
+import (
+	"github.com/profe-ajedrez/bolson"
+	"github.com/shopspring/decimal"
+)
+
+// Component is a tax of the GST
+type Component string
+
+const (
+	CGST  = Component("CGST")
+	SGST  = Component("SGST")
+	UTGST = Component("UTGST")
+	IGST  = Component("IGST")
+
+	// Cess is the ad valorem compensation cess, CessSpecific the one by unit
+	Cess         = Component("CESS")
+	CessSpecific = Component("CESS-SPECIFIC")
+)
+
+// Export is the code of the place of supply of the exports
+const Export = "96"
+
+// unionTerritories are the state codes of the union territories which levy UTGST instead of SGST
+var unionTerritories = map[string]bool{
+	"04": true, // Chandigarh
+	"26": true, // Dadra and Nagar Haveli and Daman and Diu
+	"31": true, // Lakshadweep
+	"35": true, // Andaman and Nicobar Islands
+	"38": true, // Ladakh
+}
+
+// Rate is the GST of a HSN or SAC code
+type Rate struct {
+	// GST is the whole rate, a percentage, split in halves inside a state
+	GST decimal.Decimal `json:"gst"`
+
+	// Cess is the ad valorem cess, a percentage, and CessSpecific an amount by unit
+	Cess         decimal.Decimal `json:"cess"`
+	CessSpecific decimal.Decimal `json:"cessSpecific"`
+}
+
+// Supply are the places which determine the components of the GST
+type Supply struct {
+	// Supplier is the state code of the GSTIN of the supplier, as 27
+	Supplier string `json:"supplier"`
+
+	// PlaceOfSupply is the state code of the place of supply, or [Export]
+	PlaceOfSupply string `json:"placeOfSupply"`
+
+	// LUT indicates the exports are supplied under a letter of undertaking, without IGST
+	LUT bool `json:"lut,omitempty"`
+}
+
+// Interstate indicates if the supply is between states, an export included, so it levies IGST
+func (s Supply) Interstate() bool {
+	return s.Supplier != s.PlaceOfSupply
+}
+
+// Item is a line of the invoice
+type Item struct {
+	// HSN is the HSN code of the goods or SAC of the services
+	HSN         string          `json:"hsn"`
+	Description string          `json:"description,omitempty"`
+	Qty         decimal.Decimal `json:"qty"`
+
+	// Value is the unit value, Discount an amount of the line
+	Value    decimal.Decimal `json:"value"`
+	Discount decimal.Decimal `json:"discount"`
+
+	Rate Rate `json:"rate"`
+}
+
+// Amount is a component of the GST of a line or of the invoice
+type Amount struct {
+	Component Component       `json:"component"`
+	Rate      decimal.Decimal `json:"rate"`
+	Amount    decimal.Decimal `json:"amount"`
+}
+
+// Line is a calculated item
+type Line struct {
+	Item
+
+	// Taxable is the value of the line after discount
+	Taxable decimal.Decimal `json:"taxable"`
+	Taxes   []Amount        `json:"taxes"`
+	Total   decimal.Decimal `json:"total"`
+
+	Calc bolson.Bag `json:"calc"`
+}
+
+// Summary are the values of an HSN code and rate, as the HSN summary of the invoices
+type Summary struct {
+	HSN     string          `json:"hsn"`
+	Rate    decimal.Decimal `json:"rate"`
+	Qty     decimal.Decimal `json:"qty"`
+	Taxable decimal.Decimal `json:"taxable"`
+
+	// Taxes are the components, in the order they appear
+	Taxes []Amount `json:"taxes"`
+}
+
+// Invoice is the calculated invoice
+type Invoice struct {
+	Supply Supply `json:"supply"`
+	Lines  []Line `json:"lines"`
+
+	HSN []Summary `json:"hsn"`
+
+	Taxable decimal.Decimal `json:"taxable"`
+
+	// Taxes are the totals of each component
+	Taxes []Amount        `json:"taxes"`
+	Total decimal.Decimal `json:"total"`
+
+	// RoundOff rounds Total to the rupee, as Payable
+	RoundOff decimal.Decimal `json:"roundOff"`
+	Payable  decimal.Decimal `json:"payable"`
+}
+
+// Tax returns the total of the component c in the invoice
+func (inv *Invoice) Tax(c Component) decimal.Decimal {
+	for _, a := range inv.Taxes {
+		if a.Component == c {
+			return a.Amount
+		}
+	}
+
+	return decimal.Zero
+}
@@ -0,0 +1,197 @@
+package gst
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+const rates = `hsn,gst,cess,cess_specific
+8471,18,,
+8703,28,15,
+2402,28,5,2.1
+0401,0,,
+998314,18,,
+`
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func table(t *testing.T) *Table {
+	tb, err := LoadTable(strings.NewReader(rates))
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	return tb
+}
+
+// components returns the components of taxes with their amounts
+func components(taxes []Amount) string {
+	c := make([]string, len(taxes))
+
+	for i, a := range taxes {
+		c[i] = string(a.Component) + "=" + a.Amount.StringFixed(2)
+	}
+
+	return strings.Join(c, ",")
+}
+
+func TestIntrastate(t *testing.T) {
+	inv, err := table(t).Calculate(Supply{Supplier: "27", PlaceOfSupply: "27"},
+		Item{HSN: "84713010", Qty: dec("2"), Value: dec("1000"), Discount: dec("100")},
+	)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	l := inv.Lines[0]
+
+	if !l.Taxable.Equal(dec("1900")) || components(l.Taxes) != "CGST=171.00,SGST=171.00" || !l.Total.Equal(dec("2242")) {
+		t.Logf("unexpected line %v %s %v", l.Taxable, components(l.Taxes), l.Total)
+		t.FailNow()
+	}
+
+	if !l.Taxes[0].Rate.Equal(dec("9")) || !inv.Tax(CGST).Equal(dec("171")) || !inv.Tax(IGST).IsZero() {
+		t.Logf("unexpected split %v %v", l.Taxes[0].Rate, inv.Taxes)
+		t.FailNow()
+	}
+}
+
+func TestInterstate(t *testing.T) {
+	inv, err := table(t).Calculate(Supply{Supplier: "27", PlaceOfSupply: "29"},
+		Item{HSN: "84713010", Qty: dec("2"), Value: dec("1000"), Discount: dec("100")},
+	)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if components(inv.Taxes) != "IGST=342.00" || !inv.Total.Equal(dec("2242")) {
+		t.Logf("unexpected taxes %s %v", components(inv.Taxes), inv.Total)
+		t.FailNow()
+	}
+}
+
+func TestUnionTerritory(t *testing.T) {
+	inv, err := table(t).Calculate(Supply{Supplier: "04", PlaceOfSupply: "04"},
+		Item{HSN: "998314", Qty: dec("1"), Value: dec("5000")},
+	)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if components(inv.Taxes) != "CGST=450.00,UTGST=450.00" {
+		t.Logf("unexpected taxes %s", components(inv.Taxes))
+		t.FailNow()
+	}
+}
+
+func TestCess(t *testing.T) {
+	inv, err := table(t).Calculate(Supply{Supplier: "07", PlaceOfSupply: "09"},
+		Item{HSN: "24022010", Qty: dec("10"), Value: dec("100")},
+		Item{HSN: "87032291", Qty: dec("1"), Value: dec("500000")},
+	)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if c := components(inv.Lines[0].Taxes); c != "IGST=280.00,CESS=50.00,CESS-SPECIFIC=21.00" {
+		t.Logf("unexpected taxes of the cigarettes %s", c)
+		t.FailNow()
+	}
+
+	if c := components(inv.Taxes); c != "IGST=140280.00,CESS=75050.00,CESS-SPECIFIC=21.00" {
+		t.Logf("unexpected taxes %s", c)
+		t.FailNow()
+	}
+
+	if len(inv.HSN) != 2 || !inv.HSN[1].Taxable.Equal(dec("500000")) || !inv.Total.Equal(dec("716351")) {
+		t.Logf("unexpected summary %v %v", inv.HSN, inv.Total)
+		t.FailNow()
+	}
+}
+
+func TestExport(t *testing.T) {
+	item := Item{HSN: "8471", Qty: dec("1"), Value: dec("1000")}
+
+	inv, err := table(t).Calculate(Supply{Supplier: "27", PlaceOfSupply: Export}, item)
+
+	if err != nil || components(inv.Taxes) != "IGST=180.00" {
+		t.Logf("unexpected export with payment of IGST %v %v", err, inv)
+		t.FailNow()
+	}
+
+	inv, err = table(t).Calculate(Supply{Supplier: "27", PlaceOfSupply: Export, LUT: true}, item)
+
+	if err != nil || len(inv.Taxes) != 0 || !inv.Total.Equal(dec("1000")) {
+		t.Logf("unexpected export under LUT %v %v", err, inv)
+		t.FailNow()
+	}
+}
+
+func TestSummaryAndRoundOff(t *testing.T) {
+	inv, err := table(t).Calculate(Supply{Supplier: "27", PlaceOfSupply: "27"},
+		Item{HSN: "8471", Qty: dec("1"), Value: dec("99.99")},
+		Item{HSN: "8471", Qty: dec("3"), Value: dec("10.50")},
+		Item{HSN: "0401", Qty: dec("2"), Value: dec("60")},
+	)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	s := inv.HSN[0]
+
+	if len(inv.HSN) != 2 || !s.Qty.Equal(dec("4")) || !s.Taxable.Equal(dec("131.49")) || components(s.Taxes) != "CGST=11.84,SGST=11.84" {
+		t.Logf("unexpected summary %v", inv.HSN)
+		t.FailNow()
+	}
+
+	if len(inv.HSN[1].Taxes) != 0 || !inv.Total.Equal(dec("275.17")) || !inv.Payable.Equal(dec("275")) || !inv.RoundOff.Equal(dec("-0.17")) {
+		t.Logf("unexpected totals %v %v %v", inv.Total, inv.Payable, inv.RoundOff)
+		t.FailNow()
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tb := table(t)
+	item := Item{HSN: "8471", Qty: dec("1"), Value: dec("10")}
+
+	if _, err := tb.Calculate(Supply{Supplier: "96", PlaceOfSupply: "27"}, item); err == nil || !strings.Contains(err.Error(), "[ErrInvalidState]") {
+		t.Logf("expected invalid state, got %v", err)
+		t.FailNow()
+	}
+
+	if _, err := tb.Calculate(Supply{Supplier: "27", PlaceOfSupply: "27"}, Item{HSN: "9999", Qty: dec("1")}); err == nil || !strings.Contains(err.Error(), "[ErrUnknownHSN]") {
+		t.Logf("expected unknown HSN, got %v", err)
+		t.FailNow()
+	}
+
+	item.Qty = decimal.Zero
+
+	_, err := tb.Calculate(Supply{Supplier: "27", PlaceOfSupply: "27"}, item)
+
+	if err == nil || !strings.Contains(err.Error(), "[ErrInvalidItem] the item 1") || errors.Unwrap(err) == nil {
+		t.Logf("expected invalid item, got %v", err)
+		t.FailNow()
+	}
+
+	if _, err := LoadTable(strings.NewReader("hsn,cess\n8471,1\n")); err == nil {
+		t.Logf("expected missing column")
+		t.FailNow()
+	}
+}
@@ -0,0 +1,120 @@
+package gst
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/profe-ajedrez/bolson/internal/csvtable"
+	"github.com/shopspring/decimal"
+)
+
+// columns of the rate table, the cess columns are optional
+var columns = []string{"hsn", "gst", "cess", "cess_specific"}
+
+// Table is a local table of the rates of the HSN and SAC codes
+type Table struct {
+	rates map[string]Rate
+}
+
+// NewTable returns a table with rates by HSN code
+func NewTable(rates map[string]Rate) *Table {
+	t := &Table{rates: map[string]Rate{}}
+
+	for hsn, r := range rates {
+		t.rates[hsn] = r
+	}
+
+	return t
+}
+
+// LoadTable reads a rate table in CSV with a header row naming the columns hsn, gst, cess and
+// cess_specific. The cess columns are optional
+func LoadTable(r io.Reader) (*Table, error) {
+	t := NewTable(nil)
+
+	err := csvtable.Load(r, columns[:2], func(row csvtable.Row) error {
+		hsn, rate, err := parseRate(row)
+
+		if err != nil {
+			return err
+		}
+
+		t.rates[hsn] = rate
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func parseRate(row csvtable.Row) (string, Rate, error) {
+	r, hsn := Rate{}, row.Field("hsn")
+
+	if hsn == "" {
+		return hsn, r, errors.New("hsn is required")
+	}
+
+	values := []*decimal.Decimal{&r.GST, &r.Cess, &r.CessSpecific}
+
+	for i, c := range columns[1:] {
+		v := row.Field(c)
+
+		if v == "" && i > 0 {
+			continue
+		}
+
+		d, err := decimal.NewFromString(v)
+
+		if err != nil {
+			return hsn, r, err
+		}
+
+		if d.IsNegative() {
+			return hsn, r, errors.New("negative " + c)
+		}
+
+		*values[i] = d
+	}
+
+	return hsn, r, nil
+}
+
+// Add sets the rate of the HSN code
+func (t *Table) Add(hsn string, r Rate) {
+	t.rates[hsn] = r
+}
+
+// Rate returns the rate of the HSN code, or of its longest prefix in t, as the rates of a chapter or
+// heading apply to its codes of 6 and 8 digits
+func (t *Table) Rate(hsn string) (Rate, error) {
+	for code := strings.TrimSpace(hsn); code != ""; code = code[:len(code)-1] {
+		if r, ok := t.rates[code]; ok {
+			return r, nil
+		}
+	}
+
+	return Rate{}, ErrUnknownHSN(hsn)
+}
+
+// Calculate calculates the invoice of items with the rates of their HSN codes in t
+func (t *Table) Calculate(s Supply, items ...Item) (*Invoice, error) {
+	rated := make([]Item, len(items))
+
+	for i, it := range items {
+		r, err := t.Rate(it.HSN)
+
+		if err != nil {
+			return nil, ErrInvalidItem(i, err)
+		}
+
+		it.Rate = r
+		rated[i] = it
+	}
+
+	return Calculate(s, rated...)
+}
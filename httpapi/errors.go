@@ -0,0 +1,23 @@
+package httpapi
+
+import "github.com/profe-ajedrez/bolson"
+
+// Codes of the errors not coming from the calculations
+const (
+	CodeInvalidRequest   = "ErrInvalidRequest"
+	CodeTooLarge         = "ErrRequestTooLarge"
+	CodeNotFound         = "ErrNotFound"
+	CodeMethodNotAllowed = "ErrMethodNotAllowed"
+	CodeCalculation      = "ErrCalculation"
+)
+
+// classify describes err with its code and field
+func classify(err error) Error {
+	e := Error{Code: CodeCalculation, Message: err.Error()}
+
+	if code, field := bolson.ErrorCode(err); code != "" {
+		e.Code, e.Field = code, field
+	}
+
+	return e
+}
@@ -0,0 +1,247 @@
+// Package httpapi exposes the calculations of bolson as JSON endpoints.
+//
+// Every endpoint receives a POST with the taxes and discounts in the body, and decimals travel as strings:
+//
+//	POST /calculate       line from its unit value
+//	POST /from-brute      line from its brute value
+//	POST /from-brute-wd   line from its brute value without discount
+//	POST /documents       lines and its totals
+//
+// Errors are answered with a 4xx status and an [ErrorResponse] body
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/shopspring/decimal"
+)
+
+// DefaultMaxBodyBytes is the size limit of the requests when it is not configured
+const DefaultMaxBodyBytes = 1 << 20
+
+// LineRequest is the body of the line endpoints
+type LineRequest struct {
+	bolson.Config
+
+	Value decimal.Decimal `json:"value"`
+
+	// Qty is 1 when not given
+	Qty decimal.NullDecimal `json:"qty"`
+
+	// MaxDiscount is 100 when not given
+	MaxDiscount decimal.NullDecimal `json:"maxDiscount"`
+
+	// Scale rounds the results when given
+	Scale *int32 `json:"scale,omitempty"`
+}
+
+// DocumentLine is a line of a [DocumentRequest]
+type DocumentLine struct {
+	Value       decimal.Decimal     `json:"value"`
+	Qty         decimal.NullDecimal `json:"qty"`
+	MaxDiscount decimal.NullDecimal `json:"maxDiscount"`
+	From        bolson.Origin       `json:"from"`
+
+	// Config replaces the configuration of the document for this line
+	Config *bolson.Config `json:"config,omitempty"`
+}
+
+// DocumentRequest is the body of the documents endpoint
+type DocumentRequest struct {
+	bolson.Config
+
+	Lines []DocumentLine `json:"lines"`
+
+	// Scale rounds the lines when given, obtaining the totals from the rounded lines
+	Scale *int32 `json:"scale,omitempty"`
+}
+
+// ErrorResponse is the body of the responses with errors
+type ErrorResponse struct {
+	Error Error `json:"error"`
+}
+
+// Error describes what failed
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+
+	// Line is the index of the line failing in documents
+	Line *int `json:"line,omitempty"`
+}
+
+// Options of [NewHandler]
+type Options struct {
+	// MaxBodyBytes limits the size of the requests, DefaultMaxBodyBytes when not positive
+	MaxBodyBytes int64
+}
+
+type handler struct {
+	mux  *http.ServeMux
+	opts Options
+}
+
+// NewHandler returns the handler of the endpoints, which could be mounted under any prefix with http.StripPrefix
+func NewHandler(opts Options) http.Handler {
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+
+	h := &handler{mux: http.NewServeMux(), opts: opts}
+
+	h.mux.HandleFunc("/calculate", h.line(bolson.FromUnitValue))
+	h.mux.HandleFunc("/from-brute", h.line(bolson.FromBrute))
+	h.mux.HandleFunc("/from-brute-wd", h.line(bolson.FromBruteWD))
+	h.mux.HandleFunc("/documents", h.document)
+	h.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, Error{Code: CodeNotFound, Message: "unknown endpoint " + r.URL.Path})
+	})
+
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, Error{Code: CodeMethodNotAllowed, Message: "only POST is allowed"})
+
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.opts.MaxBodyBytes)
+
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *handler) line(from bolson.Origin) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req LineRequest
+
+		if !decode(w, r, &req) {
+			return
+		}
+
+		b, err := bolson.NewFromConfig(req.Config)
+
+		if err != nil {
+			writeCalculationError(w, err, nil)
+			return
+		}
+
+		calc, err := b.CalculateLine(line(req.Value, req.Qty, req.MaxDiscount, from))
+
+		if err != nil {
+			writeCalculationError(w, err, nil)
+			return
+		}
+
+		if req.Scale != nil {
+			calc = calc.Round(*req.Scale)
+		}
+
+		writeJSON(w, http.StatusOK, calc)
+	}
+}
+
+func (h *handler) document(w http.ResponseWriter, r *http.Request) {
+	var req DocumentRequest
+
+	if !decode(w, r, &req) {
+		return
+	}
+
+	b, err := bolson.NewFromConfig(req.Config)
+
+	if err != nil {
+		writeCalculationError(w, err, nil)
+		return
+	}
+
+	bags := make([]bolson.Bag, 0, len(req.Lines))
+
+	for i, l := range req.Lines {
+		lb := b
+
+		if l.Config != nil {
+			if lb, err = bolson.NewFromConfig(*l.Config); err != nil {
+				writeCalculationError(w, err, &i)
+				return
+			}
+		}
+
+		calc, err := lb.CalculateLine(line(l.Value, l.Qty, l.MaxDiscount, l.From))
+
+		if err != nil {
+			writeCalculationError(w, err, &i)
+			return
+		}
+
+		bags = append(bags, calc)
+	}
+
+	doc := bolson.NewDocument(bags...)
+
+	if req.Scale != nil {
+		doc = doc.Round(*req.Scale)
+	}
+
+	writeJSON(w, http.StatusOK, doc)
+}
+
+// line builds a line with the defaults of the omitted values
+func line(value decimal.Decimal, qty decimal.NullDecimal, maxDiscount decimal.NullDecimal, from bolson.Origin) bolson.Line {
+	l := bolson.Line{Value: value, Qty: decimal.NewFromInt(1), MaxDiscount: decimal.NewFromInt(100), From: from}
+
+	if qty.Valid {
+		l.Qty = qty.Decimal
+	}
+
+	if maxDiscount.Valid {
+		l.MaxDiscount = maxDiscount.Decimal
+	}
+
+	return l
+}
+
+// decode reads the body of r into v, answering the error when it cannot
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, Error{Code: CodeTooLarge, Message: err.Error()})
+			return false
+		}
+
+		writeError(w, http.StatusBadRequest, Error{Code: CodeInvalidRequest, Message: err.Error()})
+
+		return false
+	}
+
+	return true
+}
+
+func writeCalculationError(w http.ResponseWriter, err error, line *int) {
+	e := classify(err)
+	e.Line = line
+
+	writeError(w, http.StatusUnprocessableEntity, e)
+}
+
+func writeError(w http.ResponseWriter, status int, e Error) {
+	writeJSON(w, status, ErrorResponse{Error: e})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
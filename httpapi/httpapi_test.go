@@ -0,0 +1,70 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/profe-ajedrez/bolson"
+)
+
+func TestHandler(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(Options{MaxBodyBytes: 1024}))
+	defer srv.Close()
+
+	testCases := []struct {
+		method   string
+		path     string
+		body     string
+		status   int
+		expected string
+	}{
+		{http.MethodPost, "/calculate", `{"taxes":[{"value":"19","mode":0,"stage":0}],"discounts":[{"value":"10","mode":0}],"value":"100","qty":"3"}`, 200, `"brute":"321.3"`},
+		{http.MethodPost, "/from-brute", `{"taxes":[{"value":"19","mode":0,"stage":0}],"value":"119","scale":2}`, 200, `"unitValue":"100"`},
+		{http.MethodPost, "/from-brute-wd", `{"taxes":[{"value":"19","mode":0,"stage":0}],"value":"357","qty":3}`, 200, `"net":"300"`},
+		{http.MethodPost, "/calculate", `{"taxes":[{"value":"19","mode":0,"stage":7}],"value":"100"}`, 422, `"code":"ErrInvalidTaxStage"`},
+		{http.MethodPost, "/calculate", `{"discounts":[{"value":"50","mode":0}],"value":"100","maxDiscount":"10"}`, 422, `"code":"ErrOverMaxDiscount"`},
+		{http.MethodPost, "/calculate", `{"value":"100","qty":"0"}`, 422, `"code":"ErrNegativeQty"`},
+		{http.MethodPost, "/calculate", `{"value":"100","unknown":1}`, 400, `"code":"ErrInvalidRequest"`},
+		{http.MethodPost, "/calculate", `{"value":"` + strings.Repeat("1", 2048) + `"}`, 413, `"code":"ErrRequestTooLarge"`},
+		{http.MethodGet, "/calculate", ``, 405, `"code":"ErrMethodNotAllowed"`},
+		{http.MethodPost, "/nope", `{}`, 404, `"code":"ErrNotFound"`},
+		{http.MethodPost, "/documents", `{"taxes":[{"value":"19","mode":0,"stage":0}],"lines":[{"value":"100","qty":"3"},{"value":"50","config":{}}],"scale":0}`, 200, `"totals":{"net":"350","tax":"57","brute":"407"`},
+		{http.MethodPost, "/documents", `{"lines":[{"value":"100"},{"value":"-5"}]}`, 422, `"line":1`},
+	}
+
+	for i, tc := range testCases {
+		req, _ := http.NewRequest(tc.method, srv.URL+tc.path, strings.NewReader(tc.body))
+		res, err := http.DefaultClient.Do(req)
+
+		if err != nil {
+			t.Logf("Fail test case[%d] --- %v", i, err)
+			t.FailNow()
+		}
+
+		var body json.RawMessage
+		_ = json.NewDecoder(res.Body).Decode(&body)
+		res.Body.Close()
+
+		if res.StatusCode != tc.status || !strings.Contains(string(body), tc.expected) {
+			t.Logf("Fail test case[%d] --- expected %d %s --- got %d %s", i, tc.status, tc.expected, res.StatusCode, body)
+			t.FailNow()
+		}
+	}
+}
+
+func TestHandlerDocumentDecodes(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/documents", strings.NewReader(`{"lines":[{"value":"10","qty":"2"}]}`))
+
+	NewHandler(Options{}).ServeHTTP(rec, req)
+
+	var doc bolson.Document
+
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil || len(doc.Lines) != 1 || doc.Totals.Brute.String() != "20" {
+		t.Logf("expected one line with total 20, got %s %v", rec.Body.String(), err)
+		t.FailNow()
+	}
+}
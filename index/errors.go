@@ -0,0 +1,18 @@
+package index
+
+import "fmt"
+
+// ErrUnknownRate there is no rate registered for the unit at the requested date
+func ErrUnknownRate(info any) error {
+	return fmt.Errorf("[ErrUnknownRate] there is no rate for the indexed unit at the requested date. %v", info)
+}
+
+// ErrInvalidRate the value of a unit is zero or negative
+func ErrInvalidRate(info any) error {
+	return fmt.Errorf("[ErrInvalidRate] the value of an indexed unit must be positive. %v", info)
+}
+
+// ErrNoRateProvider an indexed amount was converted without a rate provider
+func ErrNoRateProvider(info any) error {
+	return fmt.Errorf("[ErrNoRateProvider] there is no rate provider to convert the indexed unit. %v", info)
+}
@@ -0,0 +1,121 @@
+// Package index converts amounts expressed in indexed units, like the chilean UF or UTM,
+// to the currency of the sale using the value of the unit at a given date
+package index
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Unit is an indexed unit whose value in the currency of the sale changes over time
+type Unit string
+
+const (
+	// UF Unidad de Fomento, its value changes daily
+	UF = Unit("UF")
+
+	// UTM Unidad Tributaria Mensual, its value changes monthly
+	UTM = Unit("UTM")
+
+	// UTA Unidad Tributaria Anual
+	UTA = Unit("UTA")
+)
+
+// Amount is a value expressed in an indexed unit
+type Amount struct {
+	Value decimal.Decimal `json:"value"`
+	Unit  Unit            `json:"unit"`
+}
+
+// Convert returns the value of the amount in the currency of the sale at the received date
+func (a Amount) Convert(provider RateProvider, date time.Time) (decimal.Decimal, decimal.Decimal, error) {
+	if provider == nil {
+		return decimal.Zero, decimal.Zero, ErrNoRateProvider(a.Unit)
+	}
+
+	rate, err := provider.Rate(a.Unit, date)
+
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+
+	return a.Value.Mul(rate), rate, nil
+}
+
+// RateProvider is something able to give the value of an indexed unit at a date
+type RateProvider interface {
+	Rate(unit Unit, date time.Time) (decimal.Decimal, error)
+}
+
+var _ RateProvider = &Table{}
+
+type rate struct {
+	from  time.Time
+	value decimal.Decimal
+}
+
+// Table implements [RateProvider] using a local table of rates.
+//
+// Each registered rate is valid from its date until the date of the next registered rate
+// of the same unit, so daily units like UF and monthly ones like UTM can be stored alike
+type Table struct {
+	mu    sync.RWMutex
+	rates map[Unit][]rate
+}
+
+// NewTable returns a new pointer to an empty [Table]
+func NewTable() *Table {
+	return &Table{
+		rates: make(map[Unit][]rate),
+	}
+}
+
+// Set registers the value of unit valid from the received date
+func (t *Table) Set(unit Unit, from time.Time, value decimal.Decimal) error {
+	if !value.IsPositive() {
+		return ErrInvalidRate(value)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	from = day(from)
+	rates := t.rates[unit]
+	i := sort.Search(len(rates), func(i int) bool { return !rates[i].from.Before(from) })
+
+	if i < len(rates) && rates[i].from.Equal(from) {
+		rates[i].value = value
+		return nil
+	}
+
+	rates = append(rates, rate{})
+	copy(rates[i+1:], rates[i:])
+	rates[i] = rate{from: from, value: value}
+	t.rates[unit] = rates
+
+	return nil
+}
+
+// Rate implements RateProvider.
+func (t *Table) Rate(unit Unit, date time.Time) (decimal.Decimal, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	date = day(date)
+	rates := t.rates[unit]
+	i := sort.Search(len(rates), func(i int) bool { return rates[i].from.After(date) })
+
+	if i == 0 {
+		return decimal.Zero, ErrUnknownRate(string(unit) + " at " + date.Format(time.DateOnly))
+	}
+
+	return rates[i-1].value, nil
+}
+
+func day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,56 @@
+package index
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestTable(t *testing.T) {
+	table := NewTable()
+
+	_ = table.Set(UTM, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), decimal.RequireFromString("64793"))
+	_ = table.Set(UTM, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), decimal.RequireFromString("64666"))
+	_ = table.Set(UTM, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), decimal.RequireFromString("64732"))
+
+	if err := table.Set(UF, time.Now(), decimal.Zero); err == nil {
+		t.Log("this should be failed because a zero rate was passed to Set")
+		t.FailNow()
+	}
+
+	testCases := []struct {
+		date     time.Time
+		expected string
+	}{
+		{date: time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC), expected: "64666"},
+		{date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), expected: "64732"},
+		{date: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), expected: "64793"},
+	}
+
+	for i, tc := range testCases {
+		rate, err := table.Rate(UTM, tc.date)
+
+		if err != nil {
+			t.Logf("Fail test case[%d] --- %v", i, err)
+			t.FailNow()
+		}
+
+		if rate.String() != tc.expected {
+			t.Logf("Fail test case[%d] --- expected %v --- got %v", i, tc.expected, rate)
+			t.FailNow()
+		}
+	}
+
+	if _, err := table.Rate(UTM, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Log("this should be failed because there is no rate before the first registered date")
+		t.FailNow()
+	}
+
+	converted, _, err := Amount{Value: decimal.RequireFromString("1.5"), Unit: UTM}.Convert(table, time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC))
+
+	if err != nil || converted.String() != "97098" {
+		t.Logf("Fail converting amount --- expected 97098 --- got %v %v", converted, err)
+		t.FailNow()
+	}
+}
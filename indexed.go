@@ -0,0 +1,151 @@
+package bolson
+
+import (
+	"time"
+
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/index"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+type indexedTax struct {
+	code   string
+	amount index.Amount
+	mode   tax.Mode
+	stage  tax.Stage
+	base   tax.Base
+}
+
+type indexedDiscount struct {
+	amount index.Amount
+	mode   discount.Mode
+}
+
+// AddIndexedTax registers an amount tax expressed in an indexed unit, like UF or UTM.
+//
+// The amount is converted to the currency of the sale on each calculation, using the
+// rate provider set with [Bolson.SetRateProvider] at the date set with [Bolson.SetDate].
+// Percentual taxes have no unit, so only amount modes are accepted
+func (b Bolson) AddIndexedTax(value decimal.Decimal, unit index.Unit, mode tax.Mode, stage tax.Stage) error {
+	return b.AddIndexedTaxOverBase(value, unit, mode, stage, tax.DiscountedBase)
+}
+
+// AddIndexedTaxOverBase registers an amount tax expressed in an indexed unit specifying the base over
+// which is calculated, as [Bolson.AddTaxOverBase] does
+func (b Bolson) AddIndexedTaxOverBase(value decimal.Decimal, unit index.Unit, mode tax.Mode, stage tax.Stage, base tax.Base) error {
+	if mode != tax.AmountLineMode && mode != tax.AmountUnitMode {
+		return tax.ErrInvalidTaxMode(mode)
+	}
+
+	if stage > tax.OverTaxIgnorable {
+		return tax.ErrInvalidTaxStage(stage)
+	}
+
+	if base > tax.ListPriceBase {
+		return tax.ErrInvalidTaxBase(base)
+	}
+
+	if value.IsNegative() {
+		return tax.ErrNegativeTax(value)
+	}
+
+	b.settings.indexedTaxes = append(b.settings.indexedTaxes, indexedTax{
+		amount: index.Amount{Value: value, Unit: unit},
+		mode:   mode,
+		stage:  stage,
+		base:   base,
+	})
+
+	return nil
+}
+
+// AddIndexedCodedTax registers an amount tax expressed in an indexed unit identified by code, reported
+// apart in [Bag.Taxes] as the taxes registered with [Bolson.AddCodedTax]
+func (b Bolson) AddIndexedCodedTax(code string, value decimal.Decimal, unit index.Unit, mode tax.Mode, stage tax.Stage, base tax.Base) error {
+	if code == "" {
+		return tax.ErrInvalidTaxCode(code)
+	}
+
+	if err := b.AddIndexedTaxOverBase(value, unit, mode, stage, base); err != nil {
+		return err
+	}
+
+	b.settings.indexedTaxes[len(b.settings.indexedTaxes)-1].code = code
+
+	return nil
+}
+
+// AddIndexedDiscount registers an amount discount expressed in an indexed unit, like UF or UTM.
+//
+// The amount is converted to the currency of the sale on each calculation, same as [Bolson.AddIndexedTax]
+func (b Bolson) AddIndexedDiscount(value decimal.Decimal, unit index.Unit, mode discount.Mode) error {
+	if mode != discount.AmountLine && mode != discount.AmountUnit {
+		return discount.ErrInvalidDiscountMode(mode)
+	}
+
+	if value.IsNegative() {
+		return discount.ErrNegativeDiscount(value)
+	}
+
+	b.settings.indexedDiscounts = append(b.settings.indexedDiscounts, indexedDiscount{
+		amount: index.Amount{Value: value, Unit: unit},
+		mode:   mode,
+	})
+
+	return nil
+}
+
+// SetRateProvider sets the provider used to convert the amounts expressed in indexed units
+func (b Bolson) SetRateProvider(provider index.RateProvider) {
+	b.settings.rateProvider = provider
+}
+
+// SetDate sets the date of the document, used to convert the indexed amounts and to evaluate
+// the conditional taxes
+func (b Bolson) SetDate(date time.Time) {
+	b.settings.attributes.Date = date
+}
+
+// registerIndexed converts the indexed amounts and registers them in the handlers of b,
+// returning the conversion factors used
+func (b Bolson) registerIndexed() (map[index.Unit]decimal.Decimal, error) {
+	rates := make(map[index.Unit]decimal.Decimal)
+	date := b.settings.attributes.Date
+
+	for _, t := range b.settings.indexedTaxes {
+		value, rate, err := t.amount.Convert(b.settings.rateProvider, date)
+
+		if err != nil {
+			return nil, err
+		}
+
+		rates[t.amount.Unit] = rate
+
+		if t.code != "" {
+			err = b.addCoded(tax.Rule{Code: t.code, Value: value, Mode: t.mode, Stage: t.stage, Base: t.base})
+		} else {
+			err = b.AddTaxOverBase(value, t.mode, t.stage, t.base)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, d := range b.settings.indexedDiscounts {
+		value, rate, err := d.amount.Convert(b.settings.rateProvider, date)
+
+		if err != nil {
+			return nil, err
+		}
+
+		rates[d.amount.Unit] = rate
+
+		if err = b.discountHandler.AddDiscount(value, d.mode); err != nil {
+			return nil, err
+		}
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,136 @@
+package bolson
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/index"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+func TestIndexedAmounts(t *testing.T) {
+	b := New()
+
+	_ = b.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxIgnorable)
+	_ = b.AddIndexedTax(decimal.RequireFromString("0.5"), index.UF, tax.AmountLineMode, tax.OverTaxable)
+	_ = b.AddIndexedDiscount(decimal.RequireFromString("0.1"), index.UF, discount.AmountLine)
+
+	if err := b.AddIndexedTax(decimal.NewFromInt(1), index.UF, tax.PercentualMode, tax.OverTaxable); err == nil {
+		t.Log("this should be failed because a percentual tax cannot be indexed")
+		t.FailNow()
+	}
+
+	qty := decimal.NewFromInt(1)
+	maxDiscount := decimal.NewFromInt(100)
+
+	b.SetDate(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC))
+
+	if _, err := b.Calculate(decimal.NewFromInt(100000), qty, maxDiscount); err == nil {
+		t.Log("this should be failed because there is no rate provider")
+		t.FailNow()
+	}
+
+	table := index.NewTable()
+	_ = table.Set(index.UF, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), decimal.NewFromInt(37000))
+	_ = table.Set(index.UF, time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), decimal.NewFromInt(37010))
+	b.SetRateProvider(table)
+
+	calc, err := b.Calculate(decimal.NewFromInt(100000), qty, maxDiscount)
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	// discount 0.1 UF = 3701, tax = 96299 * 19% + 0.5 UF = 18296.81 + 18505
+	expected := `{"withDiscount":{"net":"96299","brute":"133100.81","tax":"36801.81","discount":"3.701","discountedValue":"3701","discountedValueBrute":"4404.19","unitValue":"96299"},"withoutDiscount":{"net":"100000","brute":"137505","tax":"37505","unitValue":"100000"},"rates":{"UF":"37010"}}`
+
+	js, _ := json.Marshal(calc)
+
+	if string(js) != expected {
+		t.Logf("Fail --- expected %v --- got %v", expected, string(js))
+		t.FailNow()
+	}
+
+	// the converted amounts must not remain registered after the calculation
+	if !b.OverTaxables().AmountLine().IsZero() {
+		t.Log("Fail --- indexed tax leaked into the registry")
+		t.FailNow()
+	}
+}
+
+func TestIndexedCodedTax(t *testing.T) {
+	b := New()
+
+	if err := b.AddIndexedCodedTax("", decimal.NewFromInt(1), index.UTM, tax.AmountUnitMode, tax.OverTaxable, tax.DiscountedBase); err == nil {
+		t.Log("this should be failed because the code is empty")
+		t.FailNow()
+	}
+
+	_ = b.AddIndexedCodedTax("28", decimal.RequireFromString("0.0015"), index.UTM, tax.AmountUnitMode, tax.OverTaxable, tax.DiscountedBase)
+
+	table := index.NewTable()
+	_ = table.Set(index.UTM, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), decimal.NewFromInt(65000))
+	b.SetRateProvider(table)
+	b.SetDate(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC))
+
+	calc, err := b.Calculate(decimal.NewFromInt(800), decimal.NewFromInt(1000), decimal.NewFromInt(100))
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	// 0.0015 UTM by liter = 97.5 pesos, by 1000 liters
+	if len(calc.Taxes) != 1 || calc.Taxes[0].Code != "28" || !calc.Taxes[0].Amount.Equal(decimal.NewFromInt(97500)) || !calc.WithDiscount.Tax.Equal(decimal.NewFromInt(97500)) {
+		t.Logf("Fail --- unexpected coded tax %v %v", calc.Taxes, calc.WithDiscount.Tax)
+		t.FailNow()
+	}
+
+	if calc, _ = b.Calculate(decimal.NewFromInt(800), decimal.NewFromInt(1), decimal.NewFromInt(100)); len(calc.Taxes) != 1 {
+		t.Logf("Fail --- the converted coded tax leaked into the registry %v", calc.Taxes)
+		t.FailNow()
+	}
+}
+
+func TestIndexedTaxOverListPrice(t *testing.T) {
+	table := index.NewTable()
+	_ = table.Set(index.UF, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), decimal.NewFromInt(37000))
+
+	indexed, plain := New(), New()
+
+	for _, b := range []Bolson{indexed, plain} {
+		_ = b.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable)
+		_ = b.AddTaxOverBase(decimal.NewFromInt(10), tax.PercentualMode, tax.OverTax, tax.ListPriceBase)
+		_ = b.AddDiscount(decimal.NewFromInt(10), discount.Percentual)
+	}
+
+	if err := indexed.AddIndexedTaxOverBase(decimal.RequireFromString("0.01"), index.UF, tax.AmountUnitMode, tax.OverTaxable, tax.Base(9)); err == nil {
+		t.Log("this should be failed because the base does not exist")
+		t.FailNow()
+	}
+
+	_ = indexed.AddIndexedTaxOverBase(decimal.RequireFromString("0.01"), index.UF, tax.AmountUnitMode, tax.OverTaxable, tax.ListPriceBase)
+	_ = plain.AddTaxOverBase(decimal.NewFromInt(370), tax.AmountUnitMode, tax.OverTaxable, tax.ListPriceBase)
+
+	indexed.SetRateProvider(table)
+	indexed.SetDate(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC))
+
+	got, err := indexed.Calculate(decimal.NewFromInt(100000), decimal.NewFromInt(2), decimal.NewFromInt(100))
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	expected, _ := plain.Calculate(decimal.NewFromInt(100000), decimal.NewFromInt(2), decimal.NewFromInt(100))
+
+	// the 10% over the list price is also over the indexed amount, 370 by unit
+	if !got.WithDiscount.Tax.Equal(expected.WithDiscount.Tax) || !got.WithoutDiscount.Tax.Equal(expected.WithoutDiscount.Tax) {
+		t.Logf("Fail --- expected taxes %v %v --- got %v %v", expected.WithDiscount.Tax, expected.WithoutDiscount.Tax, got.WithDiscount.Tax, got.WithoutDiscount.Tax)
+		t.FailNow()
+	}
+}
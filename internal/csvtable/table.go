@@ -0,0 +1,73 @@
+// Package csvtable reads the rate tables in CSV of the tax packages, with a header row naming their columns
+package csvtable
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrInvalidTable the rate table could not be read
+func ErrInvalidTable(row int, err error) error {
+	return fmt.Errorf("[ErrInvalidTable] the row %d of the rate table could not be read. %w", row, err)
+}
+
+// Row is a record of the table, whose fields are read by the names of their columns
+type Row struct {
+	record []string
+	index  map[string]int
+}
+
+// Field returns the value of the column name without spaces around, empty when the row does not have it
+func (r Row) Field(name string) string {
+	if i, ok := r.index[name]; ok && i < len(r.record) {
+		return strings.TrimSpace(r.record[i])
+	}
+
+	return ""
+}
+
+// Load reads the table in r, which must have the columns required, and calls parse with each row after the
+// header. The names of the columns are compared in lower case. The errors of the CSV and of parse are
+// returned as [ErrInvalidTable] with the number of the row, starting at 1 for the header
+func Load(r io.Reader, required []string, parse func(Row) error) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+
+	if err != nil {
+		return ErrInvalidTable(1, err)
+	}
+
+	index := map[string]int{}
+
+	for i, h := range header {
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	for _, c := range required {
+		if _, ok := index[c]; !ok {
+			return ErrInvalidTable(1, errors.New("missing column "+c))
+		}
+	}
+
+	for row := 2; ; row++ {
+		record, err := cr.Read()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err == nil {
+			err = parse(Row{record: record, index: index})
+		}
+
+		if err != nil {
+			return ErrInvalidTable(row, err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package csvtable
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	var rows []string
+
+	err := Load(strings.NewReader("Code, Rate\nA,1\nB\n"), []string{"code", "rate"}, func(r Row) error {
+		rows = append(rows, r.Field("code")+"="+r.Field("rate")+r.Field("missing"))
+		return nil
+	})
+
+	if err != nil || strings.Join(rows, ",") != "A=1,B=" {
+		t.Logf("unexpected rows %v. Error %v", rows, err)
+		t.FailNow()
+	}
+
+	if err := Load(strings.NewReader("code\nA\n"), []string{"code", "rate"}, nil); err == nil || !strings.Contains(err.Error(), "row 1") {
+		t.Logf("expected ErrInvalidTable in the header. Got %v", err)
+		t.FailNow()
+	}
+
+	invalid := errors.New("invalid rate")
+
+	err = Load(strings.NewReader("code\nA\nB\n"), []string{"code"}, func(r Row) error {
+		if r.Field("code") == "B" {
+			return invalid
+		}
+
+		return nil
+	})
+
+	if !errors.Is(err, invalid) || !strings.Contains(err.Error(), "[ErrInvalidTable] the row 3") {
+		t.Logf("expected ErrInvalidTable wrapping the error of the row 3. Got %v", err)
+		t.FailNow()
+	}
+}
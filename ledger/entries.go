@@ -0,0 +1,94 @@
+package ledger
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/dte"
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/profe-ajedrez/bolson/sii"
+	"github.com/shopspring/decimal"
+)
+
+// Add adds the entry e to l
+func (l *Ledger) Add(e Entry) error {
+	if e.Date.Before(l.From) || !e.Date.Before(l.To) {
+		return ErrOutOfPeriod(e.Date.Format(time.DateOnly))
+	}
+
+	if e.Side > Purchases || e.Use > Exempt {
+		return ErrInvalidEntry("invalid side or use")
+	}
+
+	if e.Net.IsNegative() || e.Exempt.IsNegative() {
+		return ErrInvalidEntry("negative values, credit notes are added with positive values and Credit")
+	}
+
+	l.entries = append(l.entries, e)
+
+	return nil
+}
+
+// AddDocument adds a document calculated by bolson. The lines with a tax of kind VAT are its net, the
+// others its exempt value
+func (l *Ledger) AddDocument(side Side, use Use, date time.Time, credit bool, d bolson.Document) error {
+	e := Entry{Side: side, Date: date, Credit: credit, Use: use, Taxes: d.Totals.Taxes}
+
+	for _, line := range d.Lines {
+		if l.taxed(line) {
+			e.Net = e.Net.Add(line.WithDiscount.Net)
+		} else {
+			e.Exempt = e.Exempt.Add(line.WithDiscount.Net)
+		}
+	}
+
+	return l.Add(e)
+}
+
+// taxed indicates if the line has a tax of kind VAT
+func (l *Ledger) taxed(line bolson.Bag) bool {
+	for _, d := range line.Taxes {
+		if l.kind(d.Code) == sii.VAT {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddDTE adds a calculated DTE, the credit notes subtract. The taxable values of the additional taxes are
+// obtained from their amounts and rates, as the DTE does not have them
+func (l *Ledger) AddDTE(side Side, use Use, c *dte.Calculated) error {
+	e := Entry{
+		Side: side, Date: c.Header.Date, Credit: c.Header.Type == dte.NotaCredito, Use: use,
+		Net: c.Totals.Net, Exempt: c.Totals.Exempt,
+	}
+
+	if !c.Totals.Net.IsZero() || !c.Totals.IVA.IsZero() {
+		e.Taxes = append(e.Taxes, bolson.TaxTotal{Code: dte.CodeIVA, Taxable: c.Totals.Net, Amount: c.Totals.IVA})
+	}
+
+	for _, r := range c.Totals.Retentions {
+		t := bolson.TaxTotal{Code: strconv.Itoa(r.Code), Taxable: decimal.Zero, Amount: r.Amount}
+
+		if r.Rate.IsPositive() {
+			t.Taxable = r.Amount.Mul(numbers.Hundred).Div(r.Rate).Round(0)
+		}
+
+		e.Taxes = append(e.Taxes, t)
+	}
+
+	return l.Add(e)
+}
+
+// kind returns the kind of the tax code in the catalog of l
+func (l *Ledger) kind(code string) sii.Kind {
+	if l.Catalog != nil {
+		if e, err := l.Catalog.Entry(code); err == nil {
+			return e.Kind
+		}
+	}
+
+	return sii.Additional
+}
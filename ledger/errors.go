@@ -0,0 +1,13 @@
+package ledger
+
+import "fmt"
+
+// ErrOutOfPeriod the date of the document is out of the period of the ledger
+func ErrOutOfPeriod(info any) error {
+	return fmt.Errorf("[ErrOutOfPeriod] the date of the document is out of the period of the ledger. %v", info)
+}
+
+// ErrInvalidEntry the document could not be added to the ledger
+func ErrInvalidEntry(info any) error {
+	return fmt.Errorf("[ErrInvalidEntry] the document could not be added to the ledger. %v", info)
+}
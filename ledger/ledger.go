@@ -0,0 +1,131 @@
+// Package ledger aggregates the calculated sales and purchases of a month in a summary by tax code, as the
+// F29 monthly return: the output VAT of the sales, the input VAT of the purchases, proportional when they
+// are used for taxed and exempt sales, the withholdings, the additional taxes by code, and the resulting
+// VAT payable or remaining credit.
+//
+//	l := ledger.New(2024, time.May)
+//	err := l.AddDTE(ledger.Sales, ledger.Common, invoice)
+//	summary := l.Summary()
+package ledger
+
+import (
+	"time"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/sii"
+	"github.com/shopspring/decimal"
+)
+
+// Side is the book of a document
+type Side uint8
+
+const (
+	Sales     = Side(0)
+	Purchases = Side(1)
+)
+
+// Use is the use of a purchase, which gives its VAT credit
+type Use uint8
+
+const (
+	// Common purchases are used for taxed and exempt sales, their credit is proportional to the taxed sales
+	Common = Use(0)
+
+	// Taxed purchases are used only for taxed sales, with the whole credit
+	Taxed = Use(1)
+
+	// Exempt purchases are used only for exempt sales, without credit
+	Exempt = Use(2)
+)
+
+// Entry is a calculated document in the ledger
+type Entry struct {
+	Side Side      `json:"side"`
+	Date time.Time `json:"date"`
+
+	// Credit indicates a credit note, whose values are subtracted
+	Credit bool `json:"credit,omitempty"`
+	Use    Use  `json:"use,omitempty"`
+
+	// Net is the value affected by VAT, Exempt the one which is not
+	Net    decimal.Decimal `json:"net"`
+	Exempt decimal.Decimal `json:"exempt"`
+
+	// Taxes are the amounts of the taxes by code, VAT and withholdings included
+	Taxes []bolson.TaxTotal `json:"taxes"`
+}
+
+// Book are the totals of the sales or the purchases of the period, in pesos
+type Book struct {
+	Documents int `json:"documents"`
+
+	Net    decimal.Decimal `json:"net"`
+	Exempt decimal.Decimal `json:"exempt"`
+
+	// VAT and Withheld are the totals of the taxes of kind VAT and withholding
+	VAT      decimal.Decimal `json:"vat"`
+	Withheld decimal.Decimal `json:"withheld"`
+
+	// Taxes are the totals by code, in the order they first appear
+	Taxes []bolson.TaxTotal `json:"taxes"`
+}
+
+// Tax returns the total of the tax with the code in b
+func (b Book) Tax(code string) bolson.TaxTotal {
+	for _, t := range b.Taxes {
+		if t.Code == code {
+			return t
+		}
+	}
+
+	return bolson.TaxTotal{Code: code}
+}
+
+// Summary is the return of the period, in pesos
+type Summary struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	Sales     Book `json:"sales"`
+	Purchases Book `json:"purchases"`
+
+	// Debit is the output VAT of the sales
+	Debit decimal.Decimal `json:"debit"`
+
+	// Factor is the proportion of the taxed sales, which gives the credit of the common purchases
+	Factor decimal.Decimal `json:"factor"`
+
+	// Credit is the input VAT of the purchases which is credited, Carried the credit of the previous period
+	Credit  decimal.Decimal `json:"credit"`
+	Carried decimal.Decimal `json:"carried"`
+
+	// Payable is the VAT to pay, or Remaining the credit for the next period
+	Payable   decimal.Decimal `json:"payable"`
+	Remaining decimal.Decimal `json:"remaining"`
+}
+
+// Ledger collects the entries of a period
+type Ledger struct {
+	// From and To are the period, To excluded
+	From time.Time
+	To   time.Time
+
+	// Catalog gives the kind of the tax codes, those unknown are additional taxes
+	Catalog *sii.Catalog
+
+	// Carried is the remaining credit of the previous period
+	Carried decimal.Decimal
+
+	// Factor replaces the proportion of the taxed sales of the period when valid, as when it is the one
+	// accumulated in the year
+	Factor decimal.NullDecimal
+
+	entries []Entry
+}
+
+// New returns the ledger of a month, with the default catalog of taxes
+func New(year int, month time.Month) *Ledger {
+	from := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+
+	return &Ledger{From: from, To: from.AddDate(0, 1, 0), Catalog: sii.Default()}
+}
@@ -0,0 +1,176 @@
+package ledger
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/dte"
+	"github.com/profe-ajedrez/bolson/sii"
+	"github.com/shopspring/decimal"
+)
+
+var may = time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+// vat returns an entry with net and its IVA
+func vat(side Side, use Use, net string, exempt string) Entry {
+	iva := dec(net).Mul(dec("0.19"))
+
+	return Entry{
+		Side: side, Date: may, Use: use, Net: dec(net), Exempt: dec(exempt),
+		Taxes: []bolson.TaxTotal{{Code: sii.IVA, Taxable: dec(net), Amount: iva}},
+	}
+}
+
+// document returns a document of a line calculated with the taxes of the catalog with codes
+func document(t *testing.T, value string, qty string, codes ...string) bolson.Document {
+	b := bolson.New()
+
+	for _, code := range codes {
+		if err := sii.Default().Add(b, code); err != nil {
+			t.Logf("unexpected error %v", err)
+			t.FailNow()
+		}
+	}
+
+	d, err := b.CalculateDocument(bolson.Line{Value: dec(value), Qty: dec(qty), MaxDiscount: dec("100")})
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	return d
+}
+
+func TestProportionalCredit(t *testing.T) {
+	l := New(2024, time.May)
+	l.Carried = dec("10000")
+
+	credit := vat(Sales, Common, "100000", "0")
+	credit.Credit = true
+
+	for _, e := range []Entry{
+		vat(Sales, Common, "800000", "200000"),
+		credit,
+		vat(Purchases, Common, "300000", "0"),
+		vat(Purchases, Taxed, "100000", "0"),
+		vat(Purchases, Exempt, "50000", "0"),
+	} {
+		if err := l.Add(e); err != nil {
+			t.Logf("unexpected error %v", err)
+			t.FailNow()
+		}
+	}
+
+	s := l.Summary()
+
+	if s.Sales.Documents != 2 || !s.Sales.Net.Equal(dec("700000")) || !s.Debit.Equal(dec("133000")) || !s.Purchases.VAT.Equal(dec("85500")) {
+		t.Logf("unexpected books %v %v", s.Sales, s.Purchases)
+		t.FailNow()
+	}
+
+	// 700000 of 900000 sales are taxed, so the common purchases credit 57000 * 0.7778
+	if !s.Factor.Equal(dec("0.7778")) || !s.Credit.Equal(dec("63335")) {
+		t.Logf("unexpected credit %v %v", s.Factor, s.Credit)
+		t.FailNow()
+	}
+
+	if !s.Payable.Equal(dec("59665")) || !s.Remaining.IsZero() {
+		t.Logf("unexpected balance %v %v", s.Payable, s.Remaining)
+		t.FailNow()
+	}
+
+	l.Factor = decimal.NewNullDecimal(dec("0.5"))
+
+	if s = l.Summary(); !s.Credit.Equal(dec("47500")) {
+		t.Logf("unexpected credit with the factor of the year %v", s.Credit)
+		t.FailNow()
+	}
+}
+
+func TestWithholdingsAndAdditionalTaxes(t *testing.T) {
+	l := New(2024, time.May)
+
+	if err := l.AddDocument(Sales, Common, may, false, document(t, "1000", "10", sii.IVA, sii.ILABeer)); err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if err := l.AddDocument(Sales, Common, may, false, document(t, "5000", "1")); err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	purchase := vat(Purchases, Common, "100000", "0")
+	purchase.Taxes = append(purchase.Taxes, bolson.TaxTotal{Code: sii.RetentionCattle, Taxable: dec("100000"), Amount: dec("8000")})
+
+	if err := l.Add(purchase); err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	s := l.Summary()
+
+	if !s.Sales.Net.Equal(dec("10000")) || !s.Sales.Exempt.Equal(dec("5000")) || !s.Sales.Tax(sii.ILABeer).Amount.Equal(dec("2050")) {
+		t.Logf("unexpected sales %v", s.Sales)
+		t.FailNow()
+	}
+
+	// 1900 of debit, 19000 * 0.6667 of credit, and 8000 withheld to the seller of the cattle
+	if !s.Purchases.Withheld.Equal(dec("8000")) || !s.Credit.Equal(dec("12667")) || !s.Remaining.Equal(dec("2767")) || !s.Payable.IsZero() {
+		t.Logf("unexpected balance %v %v %v %v", s.Purchases.Withheld, s.Credit, s.Remaining, s.Payable)
+		t.FailNow()
+	}
+}
+
+func TestAddDTE(t *testing.T) {
+	c, err := dte.Calculate(dte.Document{
+		Header: dte.Header{Type: dte.Factura, Folio: 1, Date: may},
+		Items: []dte.Item{
+			{Name: "Bebida", Qty: dec("3"), Price: dec("990"), Taxes: []dte.AdditionalTax{{Code: 27, Rate: dec("10")}}},
+			{Name: "Asesoría", Qty: dec("1"), Price: dec("5000"), Exempt: true},
+		},
+	})
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	l := New(2024, time.May)
+
+	if err := l.AddDTE(Sales, Common, c); err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	s := l.Summary()
+
+	if !s.Sales.Net.Equal(dec("2970")) || !s.Sales.Exempt.Equal(dec("5000")) || !s.Debit.Equal(dec("564")) {
+		t.Logf("unexpected sales %v", s.Sales)
+		t.FailNow()
+	}
+
+	if tt := s.Sales.Tax("27"); !tt.Amount.Equal(dec("297")) || !tt.Taxable.Equal(dec("2970")) {
+		t.Logf("unexpected additional tax %v", tt)
+		t.FailNow()
+	}
+
+	c.Header.Date = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := l.AddDTE(Sales, Common, c); err == nil || !strings.Contains(err.Error(), "[ErrOutOfPeriod]") {
+		t.Logf("expected out of period, got %v", err)
+		t.FailNow()
+	}
+
+	if err := l.Add(Entry{Side: Purchases, Date: may, Net: dec("-1")}); err == nil || !strings.Contains(err.Error(), "[ErrInvalidEntry]") {
+		t.Logf("expected invalid entry, got %v", err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,113 @@
+package ledger
+
+import (
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/sii"
+	"github.com/shopspring/decimal"
+)
+
+// Summary adds up the entries of l. The output VAT of the sales less the credited input VAT of the
+// purchases and the carried credit is payable when positive. The VAT withheld by the buyers of the sales
+// is paid by them, so it is subtracted, and the one withheld to the sellers of the purchases is added.
+//
+// The credit of the common purchases is proportional to the taxed sales of the period, the factor is
+// rounded to 4 decimals and is 1 without sales
+func (l *Ledger) Summary() Summary {
+	s := Summary{From: l.From, To: l.To, Carried: l.Carried.Round(0)}
+
+	// the VAT of the purchases by use, to apply the factor over the common ones
+	uses := map[Use]decimal.Decimal{}
+
+	for _, e := range l.entries {
+		book := &s.Sales
+
+		if e.Side == Purchases {
+			book = &s.Purchases
+		}
+
+		sign := decimal.NewFromInt(1)
+
+		if e.Credit {
+			sign = sign.Neg()
+		}
+
+		book.Documents++
+		book.Net = book.Net.Add(e.Net.Mul(sign))
+		book.Exempt = book.Exempt.Add(e.Exempt.Mul(sign))
+
+		for _, t := range e.Taxes {
+			amount := t.Amount.Mul(sign)
+
+			switch l.kind(t.Code) {
+			case sii.VAT:
+				book.VAT = book.VAT.Add(amount)
+
+				if e.Side == Purchases {
+					uses[e.Use] = uses[e.Use].Add(amount)
+				}
+			case sii.Withholding:
+				book.Withheld = book.Withheld.Add(amount)
+			}
+
+			book.add(t.Code, t.Taxable.Mul(sign), amount)
+		}
+	}
+
+	for _, b := range []*Book{&s.Sales, &s.Purchases} {
+		b.round()
+	}
+
+	s.Factor = l.factor(s.Sales)
+	s.Debit = s.Sales.VAT
+	s.Credit = uses[Taxed].Add(uses[Common].Mul(s.Factor)).Round(0)
+
+	balance := s.Debit.Sub(s.Credit).Sub(s.Carried).Sub(s.Sales.Withheld).Add(s.Purchases.Withheld)
+
+	if balance.IsPositive() {
+		s.Payable, s.Remaining = balance, decimal.Zero
+	} else {
+		s.Payable, s.Remaining = decimal.Zero, balance.Neg()
+	}
+
+	return s
+}
+
+// factor returns the proportion of the taxed sales
+func (l *Ledger) factor(sales Book) decimal.Decimal {
+	if l.Factor.Valid {
+		return l.Factor.Decimal
+	}
+
+	total := sales.Net.Add(sales.Exempt)
+
+	if !total.IsPositive() {
+		return decimal.NewFromInt(1)
+	}
+
+	return sales.Net.Div(total).Round(4)
+}
+
+// add adds the amounts of the tax code to b
+func (b *Book) add(code string, taxable decimal.Decimal, amount decimal.Decimal) {
+	for i := range b.Taxes {
+		if b.Taxes[i].Code == code {
+			b.Taxes[i].Taxable = b.Taxes[i].Taxable.Add(taxable)
+			b.Taxes[i].Amount = b.Taxes[i].Amount.Add(amount)
+
+			return
+		}
+	}
+
+	b.Taxes = append(b.Taxes, bolson.TaxTotal{Code: code, Taxable: taxable, Amount: amount})
+}
+
+// round rounds the values of b to pesos
+func (b *Book) round() {
+	b.Net, b.Exempt = b.Net.Round(0), b.Exempt.Round(0)
+	b.VAT, b.Withheld = b.VAT.Round(0), b.Withheld.Round(0)
+
+	for i := range b.Taxes {
+		b.Taxes[i].Taxable = b.Taxes[i].Taxable.Round(0)
+		b.Taxes[i].Amount = b.Taxes[i].Amount.Round(0)
+	}
+}
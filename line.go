@@ -0,0 +1,88 @@
+package bolson
+
+import (
+	"fmt"
+
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// Origin indicates which value of a line is known, and so, which calculation must be used
+type Origin uint8
+
+const (
+	// FromUnitValue the known value is the unit value without discounts and taxes
+	FromUnitValue = Origin(0)
+
+	// FromBrute the known value is the brute with discounts applied
+	FromBrute = Origin(1)
+
+	// FromBruteWD the known value is the brute without discounts
+	FromBruteWD = Origin(2)
+
+	InvalidOrigin = Origin(99)
+)
+
+// String converts Origin to string
+func (o Origin) String() string {
+	return fmt.Sprintf("%d", o)
+}
+
+// Line is the input of a calculation
+type Line struct {
+	// Value is the unit value, the brute or the brute without discount, depending on From
+	Value decimal.Decimal `json:"value"`
+
+	// Qty is the quantity being sold
+	Qty decimal.Decimal `json:"qty"`
+
+	// MaxDiscount is the max discount percentage allowed
+	MaxDiscount decimal.Decimal `json:"maxDiscount"`
+
+	// From indicates what Value is
+	From Origin `json:"from"`
+
+	// Attributes replace the ones set with [Bolson.SetAttributes] to evaluate the conditional taxes of the
+	// line. The date of the bolson is kept when theirs is zero
+	Attributes *tax.Attributes `json:"attributes,omitempty"`
+}
+
+// CalculateLine calculates l using [Bolson.Calculate], [Bolson.CalculateFromBrute] or
+// [Bolson.CalculateFromBruteWD] depending on its origin. Lines without a positive quantity are rejected
+func (b Bolson) CalculateLine(l Line) (Bag, error) {
+	if !l.Qty.IsPositive() {
+		return Bag{}, tax.ErrNegativeQty(l.Qty)
+	}
+
+	b = b.attributed(l)
+
+	switch l.From {
+	case FromUnitValue:
+		return b.Calculate(l.Value, l.Qty, l.MaxDiscount)
+	case FromBrute:
+		return b.CalculateFromBrute(l.Value, l.Qty, l.MaxDiscount)
+	case FromBruteWD:
+		return b.CalculateFromBruteWD(l.Value, l.Qty, l.MaxDiscount)
+	}
+
+	return Bag{}, ErrInvalidOrigin(l.From)
+}
+
+// attributed returns b with the attributes of l, sharing its handlers
+func (b Bolson) attributed(l Line) Bolson {
+	if l.Attributes == nil {
+		return b
+	}
+
+	s := *b.settings
+	date := s.attributes.Date
+	s.attributes = *l.Attributes
+
+	if s.attributes.Date.IsZero() {
+		s.attributes.Date = date
+	}
+
+	b.settings = &s
+
+	return b
+}
@@ -1,6 +1,10 @@
 package numbers
 
-import "github.com/shopspring/decimal"
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
 
 var (
 	Hundred = decimal.NewFromInt(100)
@@ -8,3 +12,59 @@ var (
 	Inverse = decimal.NewFromInt(-1)
 	Zero    = decimal.Zero.Copy()
 )
+
+// Rounding is the direction used to round values
+type Rounding uint8
+
+const (
+	// HalfUp rounds to the nearest, with halves away from zero
+	HalfUp = Rounding(0)
+
+	// HalfEven rounds to the nearest, with halves to the nearest even digit, as bankers do
+	HalfEven = Rounding(1)
+
+	// Down rounds towards zero, truncating the value
+	Down = Rounding(2)
+
+	// Up rounds away from zero
+	Up = Rounding(3)
+
+	// Floor rounds towards negative infinity
+	Floor = Rounding(4)
+
+	// Ceil rounds towards positive infinity
+	Ceil = Rounding(5)
+
+	InvalidRounding = Rounding(99)
+)
+
+// String converts Rounding to string
+func (r Rounding) String() string {
+	return fmt.Sprintf("%d", r)
+}
+
+// Round rounds d to scale decimal places in the direction of mode
+func Round(d decimal.Decimal, scale int32, mode Rounding) decimal.Decimal {
+	switch mode {
+	case HalfEven:
+		return d.RoundBank(scale)
+	case Down:
+		return d.RoundDown(scale)
+	case Up:
+		return d.RoundUp(scale)
+	case Floor:
+		return d.RoundFloor(scale)
+	case Ceil:
+		return d.RoundCeil(scale)
+	}
+
+	return d.Round(scale)
+}
+
+// RoundToIncrement rounds d to a multiple of increment in the direction of mode,
+// as when rounding cash payments to the nearest 10 pesos or 0.05.
+//
+// increment must be positive
+func RoundToIncrement(d decimal.Decimal, increment decimal.Decimal, mode Rounding) decimal.Decimal {
+	return Round(d.DivRound(increment, 16), 0, mode).Mul(increment)
+}
@@ -0,0 +1,546 @@
+package bolson
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/profe-ajedrez/bolson/numeric"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// LineValues contains the values of a line calculated with a numeric backend
+type LineValues[T any] struct {
+	Net                  T
+	Brute                T
+	Tax                  T
+	Discount             T
+	DiscountedValue      T
+	DiscountedValueBrute T
+	UnitValue            T
+
+	NetWD       T
+	BruteWD     T
+	TaxWD       T
+	UnitValueWD T
+}
+
+// Bag converts the values to a [Bag]
+func (v LineValues[T]) Bag(n numeric.Backend[T]) Bag {
+	return Bag{
+		WithDiscount: WithDiscountValues{
+			Net:                  n.ToDecimal(v.Net),
+			Brute:                n.ToDecimal(v.Brute),
+			Tax:                  n.ToDecimal(v.Tax),
+			Discount:             n.ToDecimal(v.Discount),
+			DiscountedValue:      n.ToDecimal(v.DiscountedValue),
+			DiscountedValueBrute: n.ToDecimal(v.DiscountedValueBrute),
+			UnitValue:            n.ToDecimal(v.UnitValue),
+		},
+		WithoutDiscount: WithoutDiscountValues{
+			Net:       n.ToDecimal(v.NetWD),
+			Brute:     n.ToDecimal(v.BruteWD),
+			Tax:       n.ToDecimal(v.TaxWD),
+			UnitValue: n.ToDecimal(v.UnitValueWD),
+		},
+	}
+}
+
+// CalculateWith calculates l with the configuration of b using the arithmetic of the backend n.
+//
+// It is the calculation made by [Bolson.CalculateLine], which uses [numeric.Decimal], so with it
+// the values are the same. With [numeric.Rat] the values calculated from brutes are exact
+//
+//	calc, err := bolson.CalculateWith[*big.Rat](numeric.Rat{}, b, bolson.Line{Value: brute, Qty: qty, MaxDiscount: max, From: bolson.FromBrute})
+func CalculateWith[T any](n numeric.Backend[T], b Bolson, l Line) (Bag, error) {
+	var unitValue *decimal.Decimal
+
+	if l.From == FromUnitValue {
+		unitValue = &l.Value
+	}
+
+	return b.prepared(unitValue, l.Qty, func(p Bolson) (Bag, error) {
+		return calculateIn(n, p, l.Value, l.Qty, l.MaxDiscount, l.From)
+	})
+}
+
+// calculateIn calculates a line with the registered values of b converted to the backend n
+func calculateIn[T any](n numeric.Backend[T], b Bolson, value decimal.Decimal, qty decimal.Decimal, maxDiscount decimal.Decimal, from Origin) (Bag, error) {
+	cfg, err := newConfig(n, b)
+
+	if err != nil {
+		return Bag{}, err
+	}
+
+	var values [3]T
+
+	for i, d := range []decimal.Decimal{value, qty, maxDiscount} {
+		if values[i], err = n.FromDecimal(d); err != nil {
+			return Bag{}, err
+		}
+	}
+
+	var v LineValues[T]
+
+	if err = cfg.calculateLine(values[0], values[1], values[2], from, &v); err != nil {
+		return Bag{}, err
+	}
+
+	return v.Bag(n), nil
+}
+
+// arithmetic applies the operations of a backend keeping the first error found, so the calculations
+// are written as formulas and checked once
+type arithmetic[T any] struct {
+	n   numeric.Backend[T]
+	err error
+}
+
+func (a *arithmetic[T]) keep(v T, err error) T {
+	if a.err == nil {
+		a.err = err
+	}
+
+	return v
+}
+
+func (a *arithmetic[T]) add(x T, y T) T {
+	return a.keep(a.n.Add(x, y))
+}
+
+func (a *arithmetic[T]) sub(x T, y T) T {
+	return a.keep(a.n.Sub(x, y))
+}
+
+func (a *arithmetic[T]) mul(x T, y T) T {
+	return a.keep(a.n.Mul(x, y))
+}
+
+func (a *arithmetic[T]) div(x T, y T) T {
+	return a.keep(a.n.Div(x, y))
+}
+
+// rateScale is the most decimal places kept for the rates a backend cannot represent
+const rateScale = 8
+
+// rate is a rate or percent converted to a numeric backend. When the backend cannot represent it,
+// as 0.105 with numeric.Fixed{Scale: 2}, it is kept as value / scale with up to [rateScale] decimal
+// places, so it is not rounded to the scale of the money values
+type rate[T any] struct {
+	value  T
+	scale  T
+	scaled bool
+}
+
+func newRate[T any](n numeric.Backend[T], r decimal.Decimal) (rate[T], error) {
+	value, err := n.FromDecimal(r)
+
+	if err != nil {
+		return rate[T]{}, err
+	}
+
+	places := -r.Exponent()
+
+	if places > rateScale {
+		places = rateScale
+	}
+
+	shifted := r.Shift(places).Round(0)
+
+	// the backend already represents r as well as the scaled rate would
+	if places <= 0 || n.ToDecimal(value).Sub(r).Abs().LessThanOrEqual(shifted.Shift(-places).Sub(r).Abs()) {
+		return rate[T]{value: value}, nil
+	}
+
+	if value, err = n.FromDecimal(shifted); err != nil {
+		return rate[T]{}, err
+	}
+
+	scale, err := n.FromDecimal(decimal.New(1, places))
+
+	return rate[T]{value: value, scale: scale, scaled: true}, err
+}
+
+// of returns x multiplied by r
+func (r rate[T]) of(a *arithmetic[T], x T) T {
+	if !r.scaled {
+		return a.mul(x, r.value)
+	}
+
+	return a.div(a.mul(x, r.value), r.scale)
+}
+
+// under returns x divided by qty times r
+func (r rate[T]) under(a *arithmetic[T], x T, qty T) T {
+	if !r.scaled {
+		return a.div(x, a.mul(qty, r.value))
+	}
+
+	return a.div(a.mul(x, r.scale), a.mul(qty, r.value))
+}
+
+// stageValues holds the registered values of a tax stage
+type stageValues[T any] struct {
+	rate       rate[T]
+	amountUnit T
+	amountLine T
+}
+
+// handlerValues holds the registered values of the stages of a tax handler, and their combined rate
+type handlerValues[T any] struct {
+	stages [3]stageValues[T]
+	rate   rate[T]
+}
+
+// config holds the registered values of a [Bolson] converted to a numeric backend, and calculates
+// lines with them.
+//
+// The brutes are inverted dividing by the combined rates, being R and L the ones of the taxes over
+// the discounted and list price bases, and k = (100 - discount percent) / 100
+//
+//	bruteRate   = k * (1 + R) + L
+//	bruteWDRate = 1 + R + L
+//
+// Without taxes over the list price base, the discounts and the taxes are removed from the brutes one
+// after the other, dividing by undiscountRate = 100 - discount percent and taxRate = 1 + R, as
+// [discount.ComputedDiscount.UnDiscount] and [tax.Handler.Untax] do
+type config[T any] struct {
+	n       numeric.Backend[T]
+	one     T
+	hundred T
+
+	discountPercent rate[T]
+	discountUnit    T
+	discountLine    T
+
+	undiscountRate rate[T]
+	taxRate        rate[T]
+	bruteRate      rate[T]
+	bruteWDRate    rate[T]
+
+	taxes     handlerValues[T]
+	listTaxes handlerValues[T]
+	hasList   bool
+
+	// coarse is set when the backend cannot represent rates with rateScale decimal places
+	coarse bool
+}
+
+func newConfig[T any](n numeric.Backend[T], b Bolson) (config[T], error) {
+	var err error
+
+	c := config[T]{n: n, hasList: !b.listTaxHandler.IsZero()}
+
+	for _, v := range []struct {
+		to   *T
+		from decimal.Decimal
+	}{
+		{&c.one, numbers.One},
+		{&c.hundred, numbers.Hundred},
+		{&c.discountUnit, b.discountHandler.AmountUnit()},
+		{&c.discountLine, b.discountHandler.AmountLine()},
+	} {
+		if *v.to, err = n.FromDecimal(v.from); err != nil {
+			return c, err
+		}
+	}
+
+	fine := decimal.New(1, -rateScale)
+	unit, err := n.FromDecimal(fine)
+
+	if err != nil {
+		return c, err
+	}
+
+	c.coarse = !n.ToDecimal(unit).Equal(fine)
+
+	if c.taxes, err = newHandlerValues(n, b.taxHandler); err != nil {
+		return c, err
+	}
+
+	if c.listTaxes, err = newHandlerValues(n, b.listTaxHandler); err != nil {
+		return c, err
+	}
+
+	percent := b.discountHandler.Percent()
+	k := numbers.Hundred.Sub(percent).Div(numbers.Hundred)
+	r, l := combinedRate(b.taxHandler), combinedRate(b.listTaxHandler)
+
+	for _, v := range []struct {
+		to   *rate[T]
+		from decimal.Decimal
+	}{
+		{&c.discountPercent, percent},
+		{&c.undiscountRate, numbers.Hundred.Sub(percent)},
+		{&c.taxRate, numbers.One.Add(r)},
+		{&c.bruteRate, k.Mul(numbers.One.Add(r)).Add(l)},
+		{&c.bruteWDRate, numbers.One.Add(r).Add(l)},
+	} {
+		if *v.to, err = newRate(n, v.from); err != nil {
+			return c, err
+		}
+	}
+
+	return c, nil
+}
+
+// combinedRate returns the rate of the taxes of h, r1 + r2 + r1 * r2 + r3, as overtaxes are calculated
+// over net + overtaxables
+func combinedRate(h *tax.Handler) decimal.Decimal {
+	r1, r2, r3 := h.OverTaxables.Percent().Div(numbers.Hundred), h.OverTaxes.Percent().Div(numbers.Hundred), h.OverTaxIgnorables.Percent().Div(numbers.Hundred)
+	return r1.Add(r2).Add(r1.Mul(r2)).Add(r3)
+}
+
+func newHandlerValues[T any](n numeric.Backend[T], h *tax.Handler) (handlerValues[T], error) {
+	var values handlerValues[T]
+
+	for i, stage := range []*tax.TaxStage{h.OverTaxables, h.OverTaxes, h.OverTaxIgnorables} {
+		var err error
+
+		if values.stages[i].rate, err = newRate(n, stage.Percent().Div(numbers.Hundred)); err != nil {
+			return values, err
+		}
+
+		if values.stages[i].amountUnit, err = n.FromDecimal(stage.AmountUnit()); err != nil {
+			return values, err
+		}
+
+		if values.stages[i].amountLine, err = n.FromDecimal(stage.AmountLine()); err != nil {
+			return values, err
+		}
+	}
+
+	var err error
+
+	values.rate, err = newRate(n, combinedRate(h))
+
+	return values, err
+}
+
+// calculateLine calculates a line from the value indicated by from, writing the results in v
+func (c config[T]) calculateLine(value T, qty T, maxDiscount T, from Origin, v *LineValues[T]) error {
+	n := c.n
+
+	if n.Cmp(qty, n.Zero()) <= 0 {
+		return tax.ErrNegativeQty(n.ToDecimal(qty))
+	}
+
+	a := arithmetic[T]{n: n}
+
+	switch from {
+	case FromUnitValue:
+		return c.calculate(value, qty, maxDiscount, v)
+	case FromBrute:
+		unitValue, err := c.unitValueFromBrute(&a, value, qty)
+
+		if err != nil {
+			return err
+		}
+
+		return c.calculate(unitValue, qty, c.hundred, v)
+	case FromBruteWD:
+		// the discounts are checked over the brute, as they were applied over it
+		if _, err := c.discounted(&a, a.div(value, qty), qty, maxDiscount); err != nil {
+			return err
+		}
+
+		intercept := a.add(c.intercept(&a, c.taxes, qty), c.intercept(&a, c.listTaxes, qty))
+		unitValue := c.bruteWDRate.under(&a, a.sub(value, intercept), qty)
+
+		if a.err != nil {
+			return a.err
+		}
+
+		return c.calculate(unitValue, qty, c.hundred, v)
+	}
+
+	return ErrInvalidOrigin(from)
+}
+
+// calculate calculates a line from its unit value
+func (c config[T]) calculate(unitValue T, qty T, maxDiscount T, v *LineValues[T]) error {
+	n := c.n
+	a := arithmetic[T]{n: n}
+
+	discounted, err := c.discounted(&a, unitValue, qty, maxDiscount)
+
+	if err != nil {
+		return err
+	}
+
+	netWD := a.mul(unitValue, qty)
+	percent := c.hundred
+
+	if n.Cmp(unitValue, n.Zero()) != 0 {
+		percent = a.div(a.mul(discounted, c.hundred), netWD)
+	}
+
+	taxed, err := c.tax(&a, c.discountedUnitValue(&a, unitValue, qty, percent, a.sub(netWD, discounted)), unitValue, qty)
+
+	if err != nil {
+		return err
+	}
+
+	taxedWD, err := c.tax(&a, unitValue, unitValue, qty)
+
+	if err != nil {
+		return err
+	}
+
+	v.Net = a.sub(netWD, discounted)
+	v.Tax = taxed
+	v.Brute = a.add(v.Net, taxed)
+	v.Discount = percent
+	v.DiscountedValue = discounted
+
+	v.NetWD = netWD
+	v.TaxWD = taxedWD
+	v.BruteWD = a.add(netWD, taxedWD)
+
+	v.DiscountedValueBrute = a.sub(v.BruteWD, v.Brute)
+
+	v.UnitValue = a.div(v.Net, qty)
+	v.UnitValueWD = a.div(netWD, qty)
+
+	return a.err
+}
+
+// discounted returns the discounted value of a line as [discount.ComputedDiscount.Compute] does
+func (c config[T]) discounted(a *arithmetic[T], unitValue T, qty T, maxDiscount T) (T, error) {
+	n := c.n
+	zero := n.Zero()
+
+	if a.err != nil {
+		return zero, a.err
+	}
+
+	if n.Cmp(unitValue, zero) < 0 {
+		return zero, inOp(discount.ErrNegativeUnitValue(n.ToDecimal(unitValue)))
+	}
+
+	if n.Cmp(maxDiscount, zero) < 0 || n.Cmp(maxDiscount, c.hundred) > 0 {
+		maxDiscount = c.hundred
+	}
+
+	maxDiscountValue := a.mul(a.div(a.mul(unitValue, maxDiscount), c.hundred), qty)
+	discounted := a.div(c.discountPercent.of(a, unitValue), c.hundred)
+	discounted = a.add(a.mul(a.add(discounted, c.discountUnit), qty), c.discountLine)
+
+	if a.err != nil {
+		return zero, a.err
+	}
+
+	if n.Cmp(discounted, maxDiscountValue) > 0 {
+		return zero, inOp(discount.ErrOverMaxDiscount(fmt.Sprintf("discount: %v  max discount: %v", n.ToDecimal(discounted), n.ToDecimal(maxDiscount))))
+	}
+
+	return discounted, nil
+}
+
+// discountedUnitValue returns the unit value with the discount percent applied.
+// When the backend is coarse it is obtained dividing the discounted net, so the discount factor is not
+// rounded to the scale of the backend
+func (c config[T]) discountedUnitValue(a *arithmetic[T], unitValue T, qty T, percent T, net T) T {
+	if c.coarse {
+		return a.div(net, qty)
+	}
+
+	return a.mul(unitValue, a.div(a.sub(c.hundred, percent), c.hundred))
+}
+
+// tax calculates the taxes over the discounted base using taxable and the ones over the list price
+// base using listPrice
+func (c config[T]) tax(a *arithmetic[T], taxable T, listPrice T, qty T) (T, error) {
+	taxed, err := c.handlerTax(a, c.taxes, taxable, qty)
+
+	if err != nil || !c.hasList {
+		return taxed, err
+	}
+
+	listTaxed, err := c.handlerTax(a, c.listTaxes, listPrice, qty)
+
+	return a.add(taxed, listTaxed), err
+}
+
+// handlerTax calculates the taxes of h as [tax.Handler.Tax] does
+func (c config[T]) handlerTax(a *arithmetic[T], h handlerValues[T], taxable T, qty T) (T, error) {
+	n := c.n
+
+	if a.err != nil {
+		return n.Zero(), a.err
+	}
+
+	if n.Cmp(taxable, n.Zero()) < 0 {
+		return n.Zero(), inOp(tax.ErrNegativeTaxable(n.ToDecimal(taxable)))
+	}
+
+	overTaxables := c.stageTax(a, h.stages[0], taxable, qty)
+	overTaxes := c.stageTax(a, h.stages[1], a.add(taxable, a.div(overTaxables, qty)), qty)
+	overTaxIgnorables := c.stageTax(a, h.stages[2], taxable, qty)
+
+	return a.add(a.add(overTaxables, overTaxes), overTaxIgnorables), a.err
+}
+
+// stageTax calculates the taxes of s as [tax.TaxStage.Tax] does
+func (c config[T]) stageTax(a *arithmetic[T], s stageValues[T], taxable T, qty T) T {
+	return a.add(a.mul(a.add(s.rate.of(a, taxable), s.amountUnit), qty), s.amountLine)
+}
+
+// intercept returns the taxes of h over a zero taxable, as the intercept of [tax.Handler.Factors].
+// The amounts of the overtaxables are taxed by the overtaxes
+func (c config[T]) intercept(a *arithmetic[T], h handlerValues[T], qty T) T {
+	i1 := a.add(a.mul(h.stages[0].amountUnit, qty), h.stages[0].amountLine)
+	i2 := a.add(a.mul(h.stages[1].amountUnit, qty), h.stages[1].amountLine)
+	i3 := a.add(a.mul(h.stages[2].amountUnit, qty), h.stages[2].amountLine)
+
+	return a.add(a.add(a.add(i1, h.stages[1].rate.of(a, i1)), i2), i3)
+}
+
+// unitValueFromBrute obtains the unit value without discounts and taxes from a discounted brute value.
+//
+// The discounted net is uv * qty * k - d, where d are the amount discounts of the line, so
+//
+//	brute = (uv * qty * k - d) * (1 + R) + uv * qty * L + intercept
+func (c config[T]) unitValueFromBrute(a *arithmetic[T], brute T, qty T) (T, error) {
+	n := c.n
+
+	if n.Cmp(c.bruteRate.value, n.Zero()) == 0 {
+		return n.Zero(), discount.ErrInvalidDecimal(fmt.Sprintf("cannot obtain the unit value from brute %v with the registered discounts", n.ToDecimal(brute)))
+	}
+
+	// the amount discounts of the line are taxed by the taxes over the discounted base
+	discounts := a.add(a.mul(c.discountUnit, qty), c.discountLine)
+	intercept := a.add(c.intercept(a, c.taxes, qty), c.intercept(a, c.listTaxes, qty))
+	taxable := a.add(a.sub(brute, intercept), a.add(discounts, c.taxes.rate.of(a, discounts)))
+
+	if c.hasList {
+		return c.bruteRate.under(a, taxable, qty), a.err
+	}
+
+	var undiscounted T
+
+	// coarse backends multiply first, so the rounding of the quotient is not multiplied
+	if c.coarse {
+		undiscounted = c.undiscountRate.under(a, a.mul(taxable, c.hundred), c.one)
+	} else {
+		undiscounted = a.mul(c.undiscountRate.under(a, taxable, c.one), c.hundred)
+	}
+
+	return a.div(c.taxRate.under(a, undiscounted, c.one), qty), a.err
+}
+
+// inOp sets the operation of err as the handler which calculates its value does
+func inOp(err error) error {
+	var de *discount.Error
+	var te *tax.Error
+
+	if errors.As(err, &de) {
+		de.Op = discount.OpCompute
+	} else if errors.As(err, &te) {
+		te.Op = tax.OpTax
+	}
+
+	return err
+}
@@ -0,0 +1,13 @@
+package numeric
+
+import "fmt"
+
+// ErrDivisionByZero a value was divided by zero
+func ErrDivisionByZero(info any) error {
+	return fmt.Errorf("[ErrDivisionByZero] a value was divided by zero. %v", info)
+}
+
+// ErrOutOfRange a value cannot be represented by the backend
+func ErrOutOfRange(info any) error {
+	return fmt.Errorf("[ErrOutOfRange] the value cannot be represented by the numeric backend. %v", info)
+}
@@ -0,0 +1,140 @@
+package numeric
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/shopspring/decimal"
+)
+
+// Fixed implements [Backend] using int64 values in minor units, as 1234 for 12.34 with Scale 2.
+//
+// Operations dont allocate, so it suits high volume calculations. Products and quotients are
+// rounded half away from zero to Scale decimal places, and values must fit in int64 minor units.
+// Results out of that range fail with ErrOutOfRange
+type Fixed struct {
+	Scale int32
+}
+
+// FromDecimal implements Backend.
+func (f Fixed) FromDecimal(d decimal.Decimal) (int64, error) {
+	v := d.Shift(f.Scale).Round(0)
+
+	if !v.BigInt().IsInt64() {
+		return 0, ErrOutOfRange(d)
+	}
+
+	return v.IntPart(), nil
+}
+
+// ToDecimal implements Backend.
+func (f Fixed) ToDecimal(v int64) decimal.Decimal {
+	return decimal.New(v, -f.Scale)
+}
+
+// Zero implements Backend.
+func (Fixed) Zero() int64 {
+	return 0
+}
+
+// Add implements Backend.
+func (Fixed) Add(a int64, b int64) (int64, error) {
+	r := a + b
+
+	if (a >= 0) == (b >= 0) && (r >= 0) != (a >= 0) {
+		return 0, ErrOutOfRange("fixed point addition overflows int64")
+	}
+
+	return r, nil
+}
+
+// Sub implements Backend.
+func (Fixed) Sub(a int64, b int64) (int64, error) {
+	r := a - b
+
+	if (a >= 0) != (b >= 0) && (r >= 0) != (a >= 0) {
+		return 0, ErrOutOfRange("fixed point subtraction overflows int64")
+	}
+
+	return r, nil
+}
+
+// Mul implements Backend.
+func (f Fixed) Mul(a int64, b int64) (int64, error) {
+	hi, lo, negative := mul128(a, b)
+	return quo128(hi, lo, pow10(f.Scale), negative)
+}
+
+// Div implements Backend.
+func (f Fixed) Div(a int64, b int64) (int64, error) {
+	if b == 0 {
+		return 0, ErrDivisionByZero(a)
+	}
+
+	hi, lo, negative := mul128(a, int64(pow10(f.Scale)))
+
+	if b < 0 {
+		negative = !negative
+	}
+
+	return quo128(hi, lo, abs(b), negative)
+}
+
+// Cmp implements Backend.
+func (Fixed) Cmp(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// mul128 returns the 128 bits product of the absolute values of a and b, and its sign
+func mul128(a int64, b int64) (uint64, uint64, bool) {
+	hi, lo := bits.Mul64(abs(a), abs(b))
+	return hi, lo, (a < 0) != (b < 0)
+}
+
+// quo128 divides the 128 bits value hi:lo by d, rounding half away from zero
+func quo128(hi uint64, lo uint64, d uint64, negative bool) (int64, error) {
+	if hi >= d {
+		return 0, ErrOutOfRange("fixed point operation overflows int64")
+	}
+
+	q, r := bits.Div64(hi, lo, d)
+
+	if r >= d-r {
+		q++
+	}
+
+	if q > math.MaxInt64 {
+		return 0, ErrOutOfRange("fixed point operation overflows int64")
+	}
+
+	if negative {
+		return -int64(q), nil
+	}
+
+	return int64(q), nil
+}
+
+func abs(v int64) uint64 {
+	if v < 0 {
+		return uint64(-v)
+	}
+
+	return uint64(v)
+}
+
+func pow10(scale int32) uint64 {
+	p := uint64(1)
+
+	for i := int32(0); i < scale; i++ {
+		p *= 10
+	}
+
+	return p
+}
@@ -0,0 +1,146 @@
+// Package numeric abstracts the arithmetic used to calculate sales values, so calculations
+// can be made with different numeric backends, like exact rationals or int64 minor units
+package numeric
+
+import (
+	"math/big"
+
+	"github.com/shopspring/decimal"
+)
+
+// Backend is the arithmetic over values of type T
+type Backend[T any] interface {
+	// FromDecimal converts d to T, failing when d cannot be represented
+	FromDecimal(d decimal.Decimal) (T, error)
+
+	// ToDecimal converts v to decimal
+	ToDecimal(v T) decimal.Decimal
+
+	Zero() T
+
+	// Add, Sub and Mul fail when the result cannot be represented, as when it overflows
+	Add(a T, b T) (T, error)
+	Sub(a T, b T) (T, error)
+	Mul(a T, b T) (T, error)
+
+	// Div divides a by b, failing when b is zero or the result cannot be represented
+	Div(a T, b T) (T, error)
+
+	// Cmp returns -1, 0 or 1 when a is less, equal or greater than b
+	Cmp(a T, b T) int
+}
+
+var (
+	_ Backend[decimal.Decimal] = Decimal{}
+	_ Backend[*big.Rat]        = Rat{}
+	_ Backend[int64]           = Fixed{}
+)
+
+// Decimal implements [Backend] using shopspring/decimal, the same arithmetic used by the rest of the module
+type Decimal struct{}
+
+// FromDecimal implements Backend.
+func (Decimal) FromDecimal(d decimal.Decimal) (decimal.Decimal, error) {
+	return d, nil
+}
+
+// ToDecimal implements Backend.
+func (Decimal) ToDecimal(v decimal.Decimal) decimal.Decimal {
+	return v
+}
+
+// Zero implements Backend.
+func (Decimal) Zero() decimal.Decimal {
+	return decimal.Zero
+}
+
+// Add implements Backend.
+func (Decimal) Add(a decimal.Decimal, b decimal.Decimal) (decimal.Decimal, error) {
+	return a.Add(b), nil
+}
+
+// Sub implements Backend.
+func (Decimal) Sub(a decimal.Decimal, b decimal.Decimal) (decimal.Decimal, error) {
+	return a.Sub(b), nil
+}
+
+// Mul implements Backend.
+func (Decimal) Mul(a decimal.Decimal, b decimal.Decimal) (decimal.Decimal, error) {
+	return a.Mul(b), nil
+}
+
+// Div implements Backend.
+func (Decimal) Div(a decimal.Decimal, b decimal.Decimal) (decimal.Decimal, error) {
+	if b.IsZero() {
+		return decimal.Zero, ErrDivisionByZero(a)
+	}
+
+	return a.Div(b), nil
+}
+
+// Cmp implements Backend.
+func (Decimal) Cmp(a decimal.Decimal, b decimal.Decimal) int {
+	return a.Cmp(b)
+}
+
+// Rat implements [Backend] using exact rationals, so values obtained inverting calculations,
+// as when untaxing or undiscounting, are exact.
+//
+// Precision is the number of decimal places used when converting back to decimal.
+// When zero, decimal.DivisionPrecision is used
+type Rat struct {
+	Precision int32
+}
+
+// FromDecimal implements Backend.
+func (Rat) FromDecimal(d decimal.Decimal) (*big.Rat, error) {
+	return d.Rat(), nil
+}
+
+// ToDecimal implements Backend.
+func (r Rat) ToDecimal(v *big.Rat) decimal.Decimal {
+	precision := r.Precision
+
+	if precision == 0 {
+		precision = int32(decimal.DivisionPrecision)
+	}
+
+	num := decimal.NewFromBigInt(v.Num(), 0)
+	denom := decimal.NewFromBigInt(v.Denom(), 0)
+
+	return num.DivRound(denom, precision)
+}
+
+// Zero implements Backend.
+func (Rat) Zero() *big.Rat {
+	return new(big.Rat)
+}
+
+// Add implements Backend.
+func (Rat) Add(a *big.Rat, b *big.Rat) (*big.Rat, error) {
+	return new(big.Rat).Add(a, b), nil
+}
+
+// Sub implements Backend.
+func (Rat) Sub(a *big.Rat, b *big.Rat) (*big.Rat, error) {
+	return new(big.Rat).Sub(a, b), nil
+}
+
+// Mul implements Backend.
+func (Rat) Mul(a *big.Rat, b *big.Rat) (*big.Rat, error) {
+	return new(big.Rat).Mul(a, b), nil
+}
+
+// Div implements Backend.
+func (Rat) Div(a *big.Rat, b *big.Rat) (*big.Rat, error) {
+	if b.Sign() == 0 {
+		return new(big.Rat), ErrDivisionByZero(a)
+	}
+
+	return new(big.Rat).Quo(a, b), nil
+}
+
+// Cmp implements Backend.
+func (Rat) Cmp(a *big.Rat, b *big.Rat) int {
+	return a.Cmp(b)
+}
@@ -0,0 +1,87 @@
+package numeric
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFixed(t *testing.T) {
+	f := Fixed{Scale: 2}
+
+	testCases := []struct {
+		a, b     string
+		mul, div string
+	}{
+		{a: "12.34", b: "2", mul: "24.68", div: "6.17"},
+		{a: "10", b: "3", mul: "30", div: "3.33"},
+		{a: "-10", b: "3", mul: "-30", div: "-3.33"},
+		{a: "0.05", b: "0.5", mul: "0.03", div: "0.1"},
+		{a: "-0.05", b: "0.5", mul: "-0.03", div: "-0.1"},
+		{a: "2", b: "3", mul: "6", div: "0.67"},
+	}
+
+	for i, tc := range testCases {
+		a, err := f.FromDecimal(decimal.RequireFromString(tc.a))
+
+		if err != nil {
+			t.Logf("Fail test case[%d] --- %v", i, err)
+			t.FailNow()
+		}
+
+		b, _ := f.FromDecimal(decimal.RequireFromString(tc.b))
+		mul, _ := f.Mul(a, b)
+		div, _ := f.Div(a, b)
+
+		if f.ToDecimal(mul).String() != tc.mul || f.ToDecimal(div).String() != tc.div {
+			t.Logf("Fail test case[%d] --- expected %v %v --- got %v %v", i, tc.mul, tc.div, f.ToDecimal(mul), f.ToDecimal(div))
+			t.FailNow()
+		}
+	}
+
+	overflows := []func() (int64, error){
+		func() (int64, error) { return f.Mul(math.MaxInt64/10, 100000) },
+		func() (int64, error) { return f.Div(math.MaxInt64/10, 1) },
+		func() (int64, error) { return f.Add(math.MaxInt64, 1) },
+		func() (int64, error) { return f.Sub(math.MinInt64, 1) },
+	}
+
+	for i, overflow := range overflows {
+		if _, err := overflow(); err == nil {
+			t.Logf("Fail overflow[%d] --- this should be failed because the result doesnt fit in int64", i)
+			t.FailNow()
+		}
+	}
+
+	if _, err := f.Div(100, 0); err == nil {
+		t.Log("this should be failed because of a division by zero")
+		t.FailNow()
+	}
+
+	if _, err := f.FromDecimal(decimal.RequireFromString("1e30")); err == nil {
+		t.Log("this should be failed because the value doesnt fit in int64")
+		t.FailNow()
+	}
+}
+
+func TestRat(t *testing.T) {
+	r := Rat{}
+
+	a, _ := r.FromDecimal(decimal.NewFromInt(1))
+	b, _ := r.FromDecimal(decimal.NewFromInt(3))
+
+	third, _ := r.Div(a, b)
+	one, _ := r.Mul(third, b)
+
+	if one.Cmp(big.NewRat(1, 1)) != 0 {
+		t.Logf("expected an exact division, got %v", one)
+		t.FailNow()
+	}
+
+	if r.ToDecimal(third).String() != "0.3333333333333333" {
+		t.Logf("expected 16 decimal places, got %v", r.ToDecimal(third))
+		t.FailNow()
+	}
+}
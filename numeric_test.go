@@ -0,0 +1,161 @@
+package bolson
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/numeric"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+func TestCalculateWithDecimalMatchesCalculate(t *testing.T) {
+	withoutList := New()
+
+	_ = withoutList.AddTax(decimal.RequireFromString("16.092732673726362323232"), tax.PercentualMode, tax.OverTaxable)
+	_ = withoutList.AddTax(decimal.RequireFromString("11.35"), tax.AmountUnitMode, tax.OverTax)
+	_ = withoutList.AddTax(decimal.RequireFromString("1.1"), tax.PercentualMode, tax.OverTax)
+	_ = withoutList.AddTax(decimal.RequireFromString("10.32"), tax.AmountLineMode, tax.OverTaxIgnorable)
+	_ = withoutList.AddDiscount(decimal.RequireFromString("30.1885553573578"), discount.Percentual)
+	_ = withoutList.AddDiscount(decimal.RequireFromString("2.3"), discount.AmountUnit)
+	_ = withoutList.AddDiscount(decimal.RequireFromString("4.1"), discount.AmountLine)
+
+	withList := withoutList.clone()
+	_ = withList.AddTaxOverBase(decimal.RequireFromString("3"), tax.PercentualMode, tax.OverTaxable, tax.ListPriceBase)
+
+	for i, tc := range []struct {
+		b  Bolson
+		uv string
+	}{
+		{withoutList, "913.793103448276"}, {withoutList, "42008.403361"}, {withoutList, "100"}, {withoutList, "7.5"},
+		{withList, "913.793103448276"}, {withList, "42008.403361"}, {withList, "100"}, {withList, "7.5"},
+	} {
+		b, uv := tc.b, tc.uv
+		qty := decimal.RequireFromString("3")
+		maxDiscount := decimal.NewFromInt(100)
+
+		expected, err := b.Calculate(decimal.RequireFromString(uv), qty, maxDiscount)
+
+		if err != nil {
+			t.Logf("Fail test case[%d] --- %v", i, err)
+			t.FailNow()
+		}
+
+		lines := []Line{
+			{Value: decimal.RequireFromString(uv), Qty: qty, MaxDiscount: maxDiscount, From: FromUnitValue},
+			{Value: expected.WithDiscount.Brute, Qty: qty, MaxDiscount: maxDiscount, From: FromBrute},
+			{Value: expected.WithoutDiscount.Brute, Qty: qty, MaxDiscount: maxDiscount, From: FromBruteWD},
+		}
+
+		for _, l := range lines {
+			calc, err := b.CalculateLine(l)
+
+			if err != nil {
+				t.Logf("Fail test case[%d] from %d --- %v", i, l.From, err)
+				t.FailNow()
+			}
+
+			got, err := CalculateWith[decimal.Decimal](numeric.Decimal{}, b, l)
+
+			if err != nil {
+				t.Logf("Fail test case[%d] from %d --- %v", i, l.From, err)
+				t.FailNow()
+			}
+
+			js1, _ := json.Marshal(calc)
+			js2, _ := json.Marshal(got)
+
+			if string(js1) != string(js2) {
+				t.Logf("Fail test case[%d] from %d --- expected %v --- got %v", i, l.From, string(js1), string(js2))
+				t.FailNow()
+			}
+
+			// the brutes are inverted exactly, amount discounts included
+			if calc.WithoutDiscount.UnitValue.Sub(decimal.RequireFromString(uv)).Abs().GreaterThan(decimal.New(1, -10)) {
+				t.Logf("Fail test case[%d] from %d --- expected the unit value %v --- got %v", i, l.From, uv, calc.WithoutDiscount.UnitValue)
+				t.FailNow()
+			}
+		}
+	}
+}
+
+func TestCalculateWithFixedOverflow(t *testing.T) {
+	b := New()
+
+	_ = b.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable)
+
+	// the net of the line doesnt fit in int64 cents
+	_, err := CalculateWith[int64](numeric.Fixed{Scale: 2}, b, Line{Value: decimal.NewFromInt(90000000000000), Qty: decimal.NewFromInt(1000)})
+
+	if err == nil || !strings.Contains(err.Error(), "ErrOutOfRange") {
+		t.Logf("expected an out of range error, got %v", err)
+		t.FailNow()
+	}
+}
+
+func TestCalculateWithBackends(t *testing.T) {
+	b := New()
+
+	_ = b.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable)
+	_ = b.AddTax(decimal.NewFromInt(7), tax.PercentualMode, tax.OverTax)
+	_ = b.AddDiscount(decimal.NewFromInt(13), discount.Percentual)
+	_ = b.AddDiscount(decimal.RequireFromString("0.7"), discount.AmountUnit)
+
+	qty := decimal.NewFromInt(3)
+	maxDiscount := decimal.NewFromInt(100)
+
+	calc, _ := b.Calculate(decimal.RequireFromString("33.33"), qty, maxDiscount)
+
+	// the rational backend inverts the brute exactly
+	got, err := CalculateWith[*big.Rat](numeric.Rat{}, b, Line{Value: calc.WithDiscount.Brute, Qty: qty, MaxDiscount: maxDiscount, From: FromBrute})
+
+	if err != nil || got.WithoutDiscount.UnitValue.String() != "33.33" {
+		t.Logf("expected the exact unit value 33.33, got %v %v", got.WithoutDiscount.UnitValue, err)
+		t.FailNow()
+	}
+
+	got, err = CalculateWith[*big.Rat](numeric.Rat{}, b, Line{Value: calc.WithoutDiscount.Brute, Qty: qty, MaxDiscount: maxDiscount, From: FromBruteWD})
+
+	if err != nil || got.WithoutDiscount.UnitValue.String() != "33.33" {
+		t.Logf("expected the exact unit value 33.33 from brute without discount, got %v %v", got.WithoutDiscount.UnitValue, err)
+		t.FailNow()
+	}
+
+	// the fixed point backend rounds each operation to the minor unit
+	got, err = CalculateWith[int64](numeric.Fixed{Scale: 2}, b, Line{Value: decimal.RequireFromString("33.33"), Qty: qty, MaxDiscount: maxDiscount})
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	tolerance := decimal.RequireFromString("0.05")
+
+	if got.WithDiscount.Brute.Sub(calc.WithDiscount.Brute).Abs().GreaterThan(tolerance) || !got.WithoutDiscount.Net.Equal(calc.WithoutDiscount.Net) {
+		t.Logf("expected fixed point values close to %v, got %v", calc, got)
+		t.FailNow()
+	}
+}
+
+func TestCalculateWithFixedKeepsRates(t *testing.T) {
+	b := New()
+
+	_ = b.AddTax(decimal.RequireFromString("10.5"), tax.PercentualMode, tax.OverTaxable)
+	_ = b.AddDiscount(decimal.RequireFromString("2.25"), discount.Percentual)
+
+	// the rates are not rounded to the scale of the money values, so 10.5% is not taken as 11%
+	got, err := CalculateWith[int64](numeric.Fixed{Scale: 2}, b, Line{Value: decimal.NewFromInt(100), Qty: decimal.NewFromInt(1), MaxDiscount: decimal.NewFromInt(100)})
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if got.WithoutDiscount.Tax.String() != "10.5" || got.WithDiscount.DiscountedValue.String() != "2.25" || got.WithDiscount.Tax.String() != "10.26" {
+		t.Logf("expected taxes 10.5 and 10.26 with a discount of 2.25, got %v", got)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,50 @@
+package bolson
+
+import (
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/shopspring/decimal"
+)
+
+// CashRounding describes how the amounts paid in cash are rounded, as the chilean rounding
+// to the nearest 10 pesos or the rounding to 0.05 of other countries
+type CashRounding struct {
+	// Increment is the smallest amount which could be paid
+	Increment decimal.Decimal `json:"increment"`
+
+	// Mode is the direction of the rounding
+	Mode numbers.Rounding `json:"mode"`
+}
+
+// Payment contains the amount to pay after the cash rounding.
+//
+// The rounding is not part of the tax base, so the calculated net and taxes are not affected
+type Payment struct {
+	// Total is the amount to pay before rounding
+	Total decimal.Decimal `json:"total"`
+
+	// Payable is the rounded amount to pay
+	Payable decimal.Decimal `json:"payable"`
+
+	// Adjustment is the difference between the payable and total amounts, which must be reported
+	Adjustment decimal.Decimal `json:"adjustment"`
+}
+
+// Apply rounds the total to pay
+func (r CashRounding) Apply(total decimal.Decimal) (Payment, error) {
+	if !r.Increment.IsPositive() {
+		return Payment{}, ErrInvalidIncrement(r.Increment)
+	}
+
+	payable := numbers.RoundToIncrement(total, r.Increment, r.Mode)
+
+	return Payment{
+		Total:      total,
+		Payable:    payable,
+		Adjustment: payable.Sub(total),
+	}, nil
+}
+
+// Pay rounds the brute with discount of the bag as an amount paid in cash
+func (b Bag) Pay(r CashRounding) (Payment, error) {
+	return r.Apply(b.WithDiscount.Brute)
+}
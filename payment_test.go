@@ -0,0 +1,47 @@
+package bolson
+
+import (
+	"testing"
+
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/shopspring/decimal"
+)
+
+func TestCashRounding(t *testing.T) {
+	testCases := []struct {
+		total      string
+		increment  string
+		mode       numbers.Rounding
+		payable    string
+		adjustment string
+	}{
+		{total: "11904", increment: "10", mode: numbers.HalfUp, payable: "11900", adjustment: "-4"},
+		{total: "11905", increment: "10", mode: numbers.HalfUp, payable: "11910", adjustment: "5"},
+		{total: "11905", increment: "10", mode: numbers.Down, payable: "11900", adjustment: "-5"},
+		{total: "12.32", increment: "0.05", mode: numbers.HalfUp, payable: "12.3", adjustment: "-0.02"},
+		{total: "12.33", increment: "0.05", mode: numbers.HalfUp, payable: "12.35", adjustment: "0.02"},
+		{total: "12.31", increment: "0.05", mode: numbers.Up, payable: "12.35", adjustment: "0.04"},
+	}
+
+	for i, tc := range testCases {
+		var calc Bag
+		calc.WithDiscount.Brute = decimal.RequireFromString(tc.total)
+
+		payment, err := calc.Pay(CashRounding{Increment: decimal.RequireFromString(tc.increment), Mode: tc.mode})
+
+		if err != nil {
+			t.Logf("Fail test case[%d] --- %v", i, err)
+			t.FailNow()
+		}
+
+		if payment.Payable.String() != tc.payable || payment.Adjustment.String() != tc.adjustment {
+			t.Logf("Fail test case[%d] --- expected %v %v --- got %v %v", i, tc.payable, tc.adjustment, payment.Payable, payment.Adjustment)
+			t.FailNow()
+		}
+	}
+
+	if _, err := (CashRounding{}).Apply(decimal.NewFromInt(10)); err == nil {
+		t.Log("this should be failed because the increment is zero")
+		t.FailNow()
+	}
+}
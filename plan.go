@@ -0,0 +1,63 @@
+package bolson
+
+import (
+	"github.com/profe-ajedrez/bolson/numeric"
+	"github.com/profe-ajedrez/bolson/tax"
+)
+
+// Plan is a configuration of [Bolson] compiled for a numeric backend.
+//
+// The registered rates and amounts are converted to the backend once when compiling, so lines are
+// calculated without going back to decimal. Only [numeric.Fixed] calculates without allocating, at the
+// cost of rounding each operation to its scale and failing with values out of int64 minor units.
+// Each operation of [numeric.Decimal] and [numeric.Rat] allocates, about 450 allocations by line, so
+// with them a Plan only saves the conversion of the configuration.
+// A Plan is not modified when calculating, so it can be shared between goroutines.
+//
+// The values are the same obtained by [CalculateWith] with the same backend, so with [numeric.Decimal]
+// they are the same obtained by [Bolson.CalculateLine]
+type Plan[T any] struct {
+	config config[T]
+}
+
+// Compile compiles the configuration of b for the backend n.
+//
+// The amounts in indexed units are converted at the date set in b when compiling. Conditional taxes
+// are evaluated by line, so configurations with them cannot be compiled
+func Compile[T any](n numeric.Backend[T], b Bolson) (*Plan[T], error) {
+	if len(b.settings.rules) > 0 {
+		return nil, ErrNotCompilable("conditional taxes are evaluated by line")
+	}
+
+	if len(b.settings.indexedTaxes) > 0 || len(b.settings.indexedDiscounts) > 0 {
+		b = b.clone()
+
+		if _, err := b.registerIndexed(); err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := newConfig(n, b)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Plan[T]{config: c}, nil
+}
+
+// Calculate calculates a line from its unit value, writing the results in v
+func (p *Plan[T]) Calculate(unitValue T, qty T, maxDiscount T, v *LineValues[T]) error {
+	n := p.config.n
+
+	if n.Cmp(qty, n.Zero()) <= 0 {
+		return tax.ErrNegativeQty(n.ToDecimal(qty))
+	}
+
+	return p.config.calculate(unitValue, qty, maxDiscount, v)
+}
+
+// CalculateLine calculates a line from the value indicated by from, writing the results in v
+func (p *Plan[T]) CalculateLine(value T, qty T, maxDiscount T, from Origin, v *LineValues[T]) error {
+	return p.config.calculateLine(value, qty, maxDiscount, from, v)
+}
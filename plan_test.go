@@ -0,0 +1,194 @@
+package bolson
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/numeric"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+func planBolson() Bolson {
+	b := New()
+
+	_ = b.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable)
+	_ = b.AddTax(decimal.RequireFromString("11.35"), tax.AmountUnitMode, tax.OverTaxable)
+	_ = b.AddTax(decimal.NewFromInt(7), tax.PercentualMode, tax.OverTax)
+	_ = b.AddTax(decimal.RequireFromString("10.32"), tax.AmountLineMode, tax.OverTaxIgnorable)
+	_ = b.AddTaxOverBase(decimal.NewFromInt(3), tax.PercentualMode, tax.OverTaxable, tax.ListPriceBase)
+	_ = b.AddDiscount(decimal.NewFromInt(13), discount.Percentual)
+	_ = b.AddDiscount(decimal.RequireFromString("0.7"), discount.AmountUnit)
+
+	return b
+}
+
+func TestPlanMatchesCalculate(t *testing.T) {
+	b := planBolson()
+
+	plan, err := Compile[*big.Rat](numeric.Rat{}, b)
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	qty := decimal.NewFromInt(3)
+	maxDiscount := decimal.NewFromInt(100)
+
+	for i, uv := range []string{"913.793103448276", "42008.403361", "100", "7.5"} {
+		expected, err := b.Calculate(decimal.RequireFromString(uv), qty, maxDiscount)
+
+		if err != nil {
+			t.Logf("Fail test case[%d] --- %v", i, err)
+			t.FailNow()
+		}
+
+		for _, from := range []struct {
+			origin Origin
+			value  decimal.Decimal
+		}{
+			{FromUnitValue, decimal.RequireFromString(uv)},
+			{FromBrute, expected.WithDiscount.Brute},
+			{FromBruteWD, expected.WithoutDiscount.Brute},
+		} {
+			var v LineValues[*big.Rat]
+
+			value, _ := numeric.Rat{}.FromDecimal(from.value)
+			q, _ := numeric.Rat{}.FromDecimal(qty)
+			md, _ := numeric.Rat{}.FromDecimal(maxDiscount)
+
+			if err := plan.CalculateLine(value, q, md, from.origin, &v); err != nil {
+				t.Logf("Fail test case[%d] from %d --- %v", i, from.origin, err)
+				t.FailNow()
+			}
+
+			got := v.Bag(numeric.Rat{}).Round(8)
+			want := expected.Round(8)
+
+			if !got.WithDiscount.Brute.Equal(want.WithDiscount.Brute) || !got.WithDiscount.Tax.Equal(want.WithDiscount.Tax) ||
+				!got.WithoutDiscount.Net.Equal(want.WithoutDiscount.Net) || !got.WithDiscount.Discount.Equal(want.WithDiscount.Discount) {
+				t.Logf("Fail test case[%d] from %d --- expected %v --- got %v", i, from.origin, want, got)
+				t.FailNow()
+			}
+		}
+	}
+}
+
+func TestPlanErrors(t *testing.T) {
+	b := planBolson()
+	_ = b.AddConditionalTax(tax.Rule{Code: "lux", Value: decimal.NewFromInt(15), Mode: tax.PercentualMode, Stage: tax.OverTaxable, When: tax.UnitValueOver(decimal.NewFromInt(1000))})
+
+	if _, err := Compile[int64](numeric.Fixed{Scale: 2}, b); err == nil {
+		t.Log("expected conditional taxes not to be compilable")
+		t.FailNow()
+	}
+
+	plan, _ := Compile[int64](numeric.Fixed{Scale: 2}, planBolson())
+
+	var v LineValues[int64]
+
+	if err := plan.Calculate(1000, 0, 10000, &v); err == nil {
+		t.Log("expected error for zero quantity")
+		t.FailNow()
+	}
+
+	// 10% max discount, 13% + 0.70 applied
+	if err := plan.Calculate(10000, 100, 1000, &v); err == nil {
+		t.Log("expected error over max discount")
+		t.FailNow()
+	}
+}
+
+func TestPlanFixedDoesNotAllocate(t *testing.T) {
+	n := numeric.Fixed{Scale: 2}
+	plan, _ := Compile[int64](n, planBolson())
+
+	var v LineValues[int64]
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = plan.Calculate(91379, 300, 10000, &v)
+	})
+
+	if allocs != 0 {
+		t.Logf("expected no allocations, got %v", allocs)
+		t.FailNow()
+	}
+}
+
+func TestPlanBackendsMatchCalculate(t *testing.T) {
+	b := New()
+
+	_ = b.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable)
+	_ = b.AddTax(decimal.NewFromInt(7), tax.PercentualMode, tax.OverTax)
+	_ = b.AddDiscount(decimal.NewFromInt(13), discount.Percentual)
+
+	uv := decimal.RequireFromString("913.79")
+	qty := decimal.NewFromInt(3)
+	maxDiscount := decimal.NewFromInt(100)
+
+	expected, err := b.Calculate(uv, qty, maxDiscount)
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	decimalPlan, err := Compile[decimal.Decimal](numeric.Decimal{}, b)
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	var dv LineValues[decimal.Decimal]
+
+	if err := decimalPlan.Calculate(uv, qty, maxDiscount, &dv); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if got := dv.Bag(numeric.Decimal{}); !got.WithDiscount.Tax.Equal(expected.WithDiscount.Tax) || !got.WithDiscount.Brute.Equal(expected.WithDiscount.Brute) {
+		t.Logf("expected the decimal plan to give %v, got %v", expected, got)
+		t.FailNow()
+	}
+
+	n := numeric.Fixed{Scale: 2}
+	fixedPlan, err := Compile[int64](n, b)
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	want := expected.Round(2)
+	tolerance := decimal.RequireFromString("0.01")
+
+	for _, from := range []struct {
+		origin Origin
+		value  decimal.Decimal
+	}{
+		{FromUnitValue, uv},
+		{FromBrute, want.WithDiscount.Brute},
+		{FromBruteWD, want.WithoutDiscount.Brute},
+	} {
+		var fv LineValues[int64]
+
+		value, _ := n.FromDecimal(from.value)
+		q, _ := n.FromDecimal(qty)
+		md, _ := n.FromDecimal(maxDiscount)
+
+		if err := fixedPlan.CalculateLine(value, q, md, from.origin, &fv); err != nil {
+			t.Logf("Fail from %d --- %v", from.origin, err)
+			t.FailNow()
+		}
+
+		got := fv.Bag(n)
+
+		if got.WithDiscount.Tax.Sub(want.WithDiscount.Tax).Abs().GreaterThan(tolerance) || got.WithDiscount.Brute.Sub(want.WithDiscount.Brute).Abs().GreaterThan(tolerance) {
+			t.Logf("Fail from %d --- expected the fixed plan to give tax %v brute %v --- got tax %v brute %v", from.origin, want.WithDiscount.Tax, want.WithDiscount.Brute, got.WithDiscount.Tax, got.WithDiscount.Brute)
+			t.FailNow()
+		}
+	}
+}
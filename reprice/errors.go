@@ -0,0 +1,23 @@
+package reprice
+
+import "fmt"
+
+// ErrMissingColumn the input doesnt have a required column
+func ErrMissingColumn(info any) error {
+	return fmt.Errorf("[ErrMissingColumn] the input doesnt have the required column. %v", info)
+}
+
+// ErrUnknownProfile the row references a profile which is not configured
+func ErrUnknownProfile(info any) error {
+	return fmt.Errorf("[ErrUnknownProfile] the profile is not configured. %v", info)
+}
+
+// ErrInvalidField a field of the row could not be parsed
+func ErrInvalidField(info any) error {
+	return fmt.Errorf("[ErrInvalidField] the field could not be parsed. %v", info)
+}
+
+// ErrUnknownFormat the format is not supported
+func ErrUnknownFormat(info any) error {
+	return fmt.Errorf("[ErrUnknownFormat] the format is not supported, use csv or jsonl. %v", info)
+}
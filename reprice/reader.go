@@ -0,0 +1,230 @@
+package reprice
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/shopspring/decimal"
+)
+
+// Column names of the input. Only value is required
+const (
+	ColumnValue       = "value"
+	ColumnQty         = "qty"
+	ColumnMaxDiscount = "max_discount"
+	ColumnFrom        = "from"
+	ColumnProfile     = "profile"
+	ColumnDiscounts   = "discounts"
+)
+
+// Record is a row of the input
+type Record struct {
+	// Row is the number of the row, starting at 1 for the first one after the header
+	Row int `json:"row"`
+
+	Line bolson.Line `json:"line"`
+
+	// Profile is the name of the configuration of taxes and discounts to use
+	Profile string `json:"profile,omitempty"`
+
+	// Discounts are applied besides the ones of the profile
+	Discounts []bolson.DiscountConfig `json:"discounts,omitempty"`
+
+	// Err is the error reading the row, if any. Rows with errors are written without calculating them
+	Err error `json:"-"`
+}
+
+// Reader reads records one by one, returning io.EOF when there are no more
+type Reader interface {
+	Read() (Record, error)
+}
+
+// CSVReader reads records from CSV with a header row naming the columns
+type CSVReader struct {
+	r       *csv.Reader
+	columns map[string]int
+	row     int
+}
+
+// NewCSVReader returns a reader of the CSV in r, reading its header
+func NewCSVReader(r io.Reader) (*CSVReader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := columns[ColumnValue]; !ok {
+		return nil, ErrMissingColumn(ColumnValue)
+	}
+
+	return &CSVReader{r: cr, columns: columns}, nil
+}
+
+// Read reads the next record. Errors of the row are returned in the record, the error returned is only for
+// errors reading the input
+func (r *CSVReader) Read() (Record, error) {
+	fields, err := r.r.Read()
+
+	if err != nil {
+		if _, ok := err.(*csv.ParseError); !ok {
+			return Record{}, err
+		}
+
+		r.row++
+
+		return Record{Row: r.row, Err: err}, nil
+	}
+
+	r.row++
+
+	field := func(name string) string {
+		if i, ok := r.columns[name]; ok && i < len(fields) {
+			return strings.TrimSpace(fields[i])
+		}
+
+		return ""
+	}
+
+	rec, err := parse(field(ColumnValue), field(ColumnQty), field(ColumnMaxDiscount), field(ColumnFrom), field(ColumnDiscounts))
+	rec.Row = r.row
+	rec.Profile = field(ColumnProfile)
+	rec.Err = err
+
+	return rec, nil
+}
+
+// JSONLReader reads records from JSON Lines, one object by line with the column names as keys. The values
+// could be strings or numbers
+type JSONLReader struct {
+	s   *bufio.Scanner
+	row int
+}
+
+// NewJSONLReader returns a reader of the JSON Lines in r
+func NewJSONLReader(r io.Reader) *JSONLReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	return &JSONLReader{s: s}
+}
+
+// Read reads the next record. Errors of the row are returned in the record, the error returned is only for
+// errors reading the input
+func (r *JSONLReader) Read() (Record, error) {
+	for r.s.Scan() {
+		line := strings.TrimSpace(r.s.Text())
+
+		if line == "" {
+			continue
+		}
+
+		r.row++
+
+		var obj map[string]json.RawMessage
+
+		if err := json.Unmarshal([]byte(line), &obj); err != nil {
+			return Record{Row: r.row, Err: err}, nil
+		}
+
+		field := func(name string) string {
+			raw, ok := obj[name]
+
+			if !ok {
+				return ""
+			}
+
+			var s string
+
+			if json.Unmarshal(raw, &s) == nil {
+				return strings.TrimSpace(s)
+			}
+
+			return strings.TrimSpace(string(raw))
+		}
+
+		if _, ok := obj[ColumnValue]; !ok {
+			return Record{Row: r.row, Err: ErrMissingColumn(ColumnValue)}, nil
+		}
+
+		rec, err := parse(field(ColumnValue), field(ColumnQty), field(ColumnMaxDiscount), field(ColumnFrom), field(ColumnDiscounts))
+		rec.Row = r.row
+		rec.Profile = field(ColumnProfile)
+		rec.Err = err
+
+		return rec, nil
+	}
+
+	if err := r.s.Err(); err != nil {
+		return Record{}, err
+	}
+
+	return Record{}, io.EOF
+}
+
+// parse builds a record from the fields of a row. qty defaults to 1, maxDiscount to 100 and from to the
+// unit value. discounts are written as value:mode separated by ;
+func parse(value, qty, maxDiscount, from, discounts string) (Record, error) {
+	rec := Record{
+		Line: bolson.Line{
+			Qty:         decimal.NewFromInt(1),
+			MaxDiscount: decimal.NewFromInt(100),
+		},
+	}
+
+	var err error
+
+	if rec.Line.Value, err = decimal.NewFromString(value); err != nil {
+		return rec, ErrInvalidField(fmt.Sprintf("%s %q", ColumnValue, value))
+	}
+
+	if qty != "" {
+		if rec.Line.Qty, err = decimal.NewFromString(qty); err != nil {
+			return rec, ErrInvalidField(fmt.Sprintf("%s %q", ColumnQty, qty))
+		}
+	}
+
+	if maxDiscount != "" {
+		if rec.Line.MaxDiscount, err = decimal.NewFromString(maxDiscount); err != nil {
+			return rec, ErrInvalidField(fmt.Sprintf("%s %q", ColumnMaxDiscount, maxDiscount))
+		}
+	}
+
+	if from != "" {
+		if rec.Line.From, err = bolson.ParseOrigin(from); err != nil {
+			return rec, err
+		}
+	}
+
+	for _, d := range strings.Split(discounts, ";") {
+		if strings.TrimSpace(d) == "" {
+			continue
+		}
+
+		dc, err := bolson.ParseDiscountConfig(d)
+
+		if err != nil {
+			return rec, err
+		}
+
+		rec.Discounts = append(rec.Discounts, dc)
+	}
+
+	return rec, nil
+}
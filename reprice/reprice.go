@@ -0,0 +1,120 @@
+// Package reprice recalculates streams of lines read from CSV or JSON Lines.
+//
+// Each row is read, calculated and written before reading the next one, so files of any size are
+// processed with constant memory. Rows failing are written with its error, without stopping the others.
+package reprice
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/profe-ajedrez/bolson"
+)
+
+// Profiles are named configurations of taxes and discounts. The one named "" is used by the rows without
+// profile, which are calculated without taxes when it is not given
+type Profiles map[string]bolson.Config
+
+// Summary counts the rows processed
+type Summary struct {
+	Rows   int `json:"rows"`
+	Errors int `json:"errors"`
+}
+
+// Options of [Reprice]
+type Options struct {
+	Profiles Profiles
+
+	// Round indicates to round the results to Scale decimal places
+	Round bool
+	Scale int32
+}
+
+// Reprice calculates each record of r with the configuration of its profile, writing the results to w.
+//
+// It stops when r is exhausted, when writing fails or when ctx is cancelled, returning the rows processed
+func Reprice(ctx context.Context, r Reader, w Writer, opts Options) (Summary, error) {
+	var sum Summary
+
+	profiles := make(map[string]bolson.Bolson, len(opts.Profiles))
+
+	for name, cfg := range opts.Profiles {
+		b, err := bolson.NewFromConfig(cfg)
+
+		if err != nil {
+			return sum, err
+		}
+
+		profiles[name] = b
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return sum, w.Flush()
+		}
+
+		rec, err := r.Read()
+
+		if errors.Is(err, io.EOF) {
+			return sum, w.Flush()
+		}
+
+		if err != nil {
+			_ = w.Flush()
+			return sum, err
+		}
+
+		calc, err := calculate(rec, profiles, opts)
+
+		sum.Rows++
+
+		if err != nil {
+			sum.Errors++
+		}
+
+		if err := w.Write(rec, calc, err); err != nil {
+			return sum, err
+		}
+	}
+}
+
+// calculate calculates rec with its profile plus its own discounts
+func calculate(rec Record, profiles map[string]bolson.Bolson, opts Options) (bolson.Bag, error) {
+	if rec.Err != nil {
+		return bolson.Bag{}, rec.Err
+	}
+
+	b, ok := profiles[rec.Profile]
+
+	if !ok {
+		if rec.Profile != "" {
+			return bolson.Bag{}, ErrUnknownProfile(rec.Profile)
+		}
+
+		b = bolson.New()
+	}
+
+	if len(rec.Discounts) > 0 {
+		cfg := opts.Profiles[rec.Profile]
+		cfg.Discounts = append(cfg.Discounts[:len(cfg.Discounts):len(cfg.Discounts)], rec.Discounts...)
+
+		var err error
+
+		if b, err = bolson.NewFromConfig(cfg); err != nil {
+			return bolson.Bag{}, err
+		}
+	}
+
+	calc, err := b.CalculateLine(rec.Line)
+
+	if err != nil {
+		return calc, err
+	}
+
+	if opts.Round {
+		calc = calc.Round(opts.Scale)
+	}
+
+	return calc, nil
+}
@@ -0,0 +1,175 @@
+package reprice
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/index"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+var testProfiles = Profiles{
+	"":    {Taxes: []bolson.TaxConfig{{Value: decimal.NewFromInt(19), Mode: tax.PercentualMode, Stage: tax.OverTaxable}}},
+	"ila": {Taxes: []bolson.TaxConfig{{Value: decimal.NewFromInt(19), Mode: tax.PercentualMode, Stage: tax.OverTaxable}, {Value: decimal.RequireFromString("31.5"), Mode: tax.PercentualMode, Stage: tax.OverTaxIgnorable}}},
+}
+
+func TestRepriceCSV(t *testing.T) {
+	in := strings.NewReader(`value,qty,from,profile,discounts
+100,3,,,10:percentual
+357,3,brute-wd,,
+100,1,,ila,
+100,0,,,
+x,1,,,
+100,1,,nope,
+`)
+
+	r, err := NewCSVReader(in)
+
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	var out bytes.Buffer
+
+	sum, err := Reprice(context.Background(), r, NewCSVWriter(&out), Options{Profiles: testProfiles, Round: true, Scale: 2})
+
+	if err != nil || sum.Rows != 6 || sum.Errors != 3 {
+		t.Logf("expected 6 rows and 3 errors, got %v %v", sum, err)
+		t.FailNow()
+	}
+
+	rows, err := csv.NewReader(&out).ReadAll()
+
+	if err != nil || len(rows) != 7 {
+		t.Logf("expected header and 6 rows, got %v %v", rows, err)
+		t.FailNow()
+	}
+
+	column := func(name string) int {
+		for i, h := range rows[0] {
+			if h == name {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	brute, unitValueWD, errs := column("brute"), column("unit_value_wd"), column("error")
+
+	expected := []struct {
+		brute       string
+		unitValueWD string
+		failed      bool
+	}{
+		{"321.3", "100", false},
+		{"357", "100", false},
+		{"150.5", "100", false},
+		{"", "", true},
+		{"", "", true},
+		{"", "", true},
+	}
+
+	for i, e := range expected {
+		row := rows[i+1]
+
+		if row[brute] != e.brute || row[unitValueWD] != e.unitValueWD || (row[errs] != "") != e.failed {
+			t.Logf("Fail row %d --- expected %v --- got %v", i+1, e, row)
+			t.FailNow()
+		}
+	}
+}
+
+func TestRepriceJSONL(t *testing.T) {
+	in := strings.NewReader(`{"value":"100","qty":3,"discounts":"10:0"}
+
+{"value":100,"profile":"ila"}
+{"qty":1}
+not json
+`)
+
+	var out bytes.Buffer
+
+	sum, err := Reprice(context.Background(), NewJSONLReader(in), NewJSONLWriter(&out), Options{Profiles: testProfiles})
+
+	if err != nil || sum.Rows != 4 || sum.Errors != 2 {
+		t.Logf("expected 4 rows and 2 errors, got %v %v", sum, err)
+		t.FailNow()
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+
+	var first struct {
+		Row int         `json:"row"`
+		Bag *bolson.Bag `json:"bag"`
+	}
+
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil || first.Row != 1 || !first.Bag.WithDiscount.Brute.Equal(decimal.RequireFromString("321.3")) {
+		t.Logf("expected first row with brute 321.3, got %s %v", lines[0], err)
+		t.FailNow()
+	}
+
+	if !strings.Contains(lines[2], "ErrMissingColumn") || !strings.Contains(lines[3], `"error"`) {
+		t.Logf("expected errors in the last rows, got %v", lines)
+		t.FailNow()
+	}
+}
+
+func TestRepriceCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r, _ := NewCSVReader(strings.NewReader("value\n1\n2\n"))
+
+	sum, err := Reprice(ctx, r, NewCSVWriter(&bytes.Buffer{}), Options{})
+
+	if err != nil || sum.Rows != 0 {
+		t.Logf("expected no rows processed, got %v %v", sum, err)
+		t.FailNow()
+	}
+}
+
+func TestNewCSVReaderWithoutValue(t *testing.T) {
+	if _, err := NewCSVReader(strings.NewReader("qty\n1\n")); err == nil {
+		t.Log("expected error without value column")
+		t.FailNow()
+	}
+}
+
+func TestCSVWriterDetails(t *testing.T) {
+	var out bytes.Buffer
+
+	w := NewCSVWriter(&out)
+
+	calc := bolson.Bag{
+		Taxes: []bolson.TaxDetail{{Code: "14", Amount: decimal.NewFromInt(57), AmountWD: decimal.NewFromInt(63)}, {Code: "27", Amount: decimal.NewFromInt(30), AmountWD: decimal.NewFromInt(33)}},
+		Fired: []string{"lux", "ila"},
+		Rates: map[index.Unit]decimal.Decimal{index.UTM: decimal.NewFromInt(65000), index.UF: decimal.NewFromInt(36000)},
+	}
+
+	_ = w.Write(Record{Row: 1, Line: bolson.Line{Value: decimal.NewFromInt(300), Qty: decimal.NewFromInt(1)}}, calc, nil)
+	_ = w.Flush()
+
+	rows, err := csv.NewReader(&out).ReadAll()
+
+	if err != nil || len(rows) != 2 || len(rows[1]) != len(Header) {
+		t.Logf("expected header and 1 row, got %v %v", rows, err)
+		t.FailNow()
+	}
+
+	expected := map[string]string{"taxes": "14:57:63;27:30:33", "fired": "lux;ila", "rates": "UF:36000;UTM:65000"}
+
+	for i, h := range rows[0] {
+		if e, ok := expected[h]; ok && rows[1][i] != e {
+			t.Logf("Fail column %s --- expected %s --- got %s", h, e, rows[1][i])
+			t.FailNow()
+		}
+	}
+}
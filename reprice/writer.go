@@ -0,0 +1,141 @@
+package reprice
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/index"
+	"github.com/shopspring/decimal"
+)
+
+// Writer writes the result of each record
+type Writer interface {
+	Write(rec Record, calc bolson.Bag, err error) error
+	Flush() error
+}
+
+// Header are the columns written by [CSVWriter]
+var Header = []string{
+	"row", ColumnValue, ColumnQty, ColumnMaxDiscount, ColumnFrom, ColumnProfile,
+	"net", "brute", "tax", "discount", "discounted_value", "discounted_value_brute", "unit_value",
+	"net_wd", "brute_wd", "tax_wd", "unit_value_wd",
+	"taxes", "fired", "rates",
+	"error",
+}
+
+// CSVWriter writes results as CSV, with the values of the bag empty for the rows with errors.
+//
+// The coded taxes are written in the taxes column as code:amount:amountWD, the conditional taxes fired
+// by its code and the rates of the indexed units as unit:rate, separated by semicolons, as 14:57:63;27:30:33
+type CSVWriter struct {
+	w      *csv.Writer
+	header bool
+	fields []string
+}
+
+// NewCSVWriter returns a writer of CSV to w
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w), fields: make([]string, len(Header))}
+}
+
+// Write writes the result of rec, writing the header first
+func (w *CSVWriter) Write(rec Record, calc bolson.Bag, err error) error {
+	if !w.header {
+		if err := w.w.Write(Header); err != nil {
+			return err
+		}
+
+		w.header = true
+	}
+
+	f := w.fields[:0]
+	f = append(f, strconv.Itoa(rec.Row), rec.Line.Value.String(), rec.Line.Qty.String(), rec.Line.MaxDiscount.String(), rec.Line.From.String(), rec.Profile)
+
+	if err != nil {
+		for len(f) < len(Header)-1 {
+			f = append(f, "")
+		}
+
+		f = append(f, err.Error())
+	} else {
+		f = append(f,
+			calc.WithDiscount.Net.String(), calc.WithDiscount.Brute.String(), calc.WithDiscount.Tax.String(),
+			calc.WithDiscount.Discount.String(), calc.WithDiscount.DiscountedValue.String(), calc.WithDiscount.DiscountedValueBrute.String(),
+			calc.WithDiscount.UnitValue.String(),
+			calc.WithoutDiscount.Net.String(), calc.WithoutDiscount.Brute.String(), calc.WithoutDiscount.Tax.String(),
+			calc.WithoutDiscount.UnitValue.String(),
+			taxesField(calc.Taxes), strings.Join(calc.Fired, ";"), ratesField(calc.Rates),
+			"",
+		)
+	}
+
+	return w.w.Write(f)
+}
+
+// Flush writes the buffered data
+func (w *CSVWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func taxesField(taxes []bolson.TaxDetail) string {
+	values := make([]string, len(taxes))
+
+	for i, t := range taxes {
+		values[i] = t.Code + ":" + t.Amount.String() + ":" + t.AmountWD.String()
+	}
+
+	return strings.Join(values, ";")
+}
+
+// ratesField writes the rates sorted by unit, so the column does not depend on the map order
+func ratesField(rates map[index.Unit]decimal.Decimal) string {
+	values := make([]string, 0, len(rates))
+
+	for unit, rate := range rates {
+		values = append(values, string(unit)+":"+rate.String())
+	}
+
+	sort.Strings(values)
+
+	return strings.Join(values, ";")
+}
+
+// JSONLWriter writes results as JSON Lines
+type JSONLWriter struct {
+	enc *json.Encoder
+}
+
+// NewJSONLWriter returns a writer of JSON Lines to w
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	return &JSONLWriter{enc: json.NewEncoder(w)}
+}
+
+type jsonlResult struct {
+	Record
+	Bag   *bolson.Bag `json:"bag,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+// Write writes the result of rec as one line
+func (w *JSONLWriter) Write(rec Record, calc bolson.Bag, err error) error {
+	r := jsonlResult{Record: rec}
+
+	if err != nil {
+		r.Error = err.Error()
+	} else {
+		r.Bag = &calc
+	}
+
+	return w.enc.Encode(r)
+}
+
+// Flush does nothing, as each line is written when encoded
+func (w *JSONLWriter) Flush() error {
+	return nil
+}
@@ -0,0 +1,177 @@
+package salestax
+
+import (
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// cents are the decimals of the amounts
+const cents = 2
+
+// Calculate calculates the tax of each jurisdiction of l
+func (t *Table) Calculate(l Line) (Result, error) {
+	r := Result{}
+
+	js, err := t.Jurisdictions(l.ShipFrom, l.ShipTo)
+
+	if err != nil {
+		return r, err
+	}
+
+	line := bolson.Line{Value: l.Value, Qty: l.Qty, MaxDiscount: numbers.Hundred, From: bolson.FromUnitValue}
+
+	// the net without taxes is needed first to apply the caps
+	untaxed, err := calculator(l)
+
+	if err != nil {
+		return r, err
+	}
+
+	calc, err := untaxed.CalculateLine(line)
+
+	if err != nil {
+		return r, err
+	}
+
+	net := calc.WithDiscount.Net
+
+	b, err := calculator(l)
+
+	if err != nil {
+		return r, err
+	}
+
+	r.Taxes = make([]Tax, len(js))
+
+	for i, j := range js {
+		rate, taxed := j.rate(l.Category)
+
+		r.Taxes[i] = Tax{Jurisdiction: j, Exempt: !taxed, Rate: rate}
+
+		if !taxed || rate.IsZero() {
+			continue
+		}
+
+		// over the cap the tax is the rate of the cap of the units, a fixed amount of the line
+		if taxable, ok := j.capped(net, l.Qty); ok {
+			err = b.AddCodedTax(j.Code, taxable.Mul(rate).Div(numbers.Hundred), tax.AmountLineMode, tax.OverTaxable, tax.DiscountedBase)
+		} else {
+			err = b.AddCodedTax(j.Code, rate, tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase)
+		}
+
+		if err != nil {
+			return r, err
+		}
+	}
+
+	if r.Calc, err = b.CalculateLine(line); err != nil {
+		return r, err
+	}
+
+	details := map[string]bolson.TaxDetail{}
+
+	for _, d := range r.Calc.Taxes {
+		details[d.Code] = d
+	}
+
+	r.Net = net.Round(cents)
+
+	for i, tx := range r.Taxes {
+		d, ok := details[tx.Jurisdiction.Code]
+
+		if !ok {
+			continue
+		}
+
+		r.Taxes[i].Taxable = net.Round(cents)
+
+		if taxable, ok := tx.Jurisdiction.capped(net, l.Qty); ok {
+			r.Taxes[i].Taxable = taxable.Round(cents)
+		}
+
+		r.Taxes[i].Amount = d.Amount.Round(cents)
+		r.Tax = r.Tax.Add(r.Taxes[i].Amount)
+	}
+
+	r.Total = r.Net.Add(r.Tax)
+
+	return r, nil
+}
+
+// capped returns the taxable amount of a line of qty units whose net is net, when the net of each unit is
+// over the cap of j
+func (j Jurisdiction) capped(net decimal.Decimal, qty decimal.Decimal) (decimal.Decimal, bool) {
+	if !j.Cap.IsPositive() || !net.Div(qty).GreaterThan(j.Cap) {
+		return decimal.Zero, false
+	}
+
+	return j.Cap.Mul(qty), true
+}
+
+// calculator returns a bolson with the discount of l
+func calculator(l Line) (bolson.Bolson, error) {
+	b := bolson.New()
+
+	if l.Discount.IsPositive() {
+		if err := b.AddDiscount(l.Discount, discount.AmountLine); err != nil {
+			return b, err
+		}
+	}
+
+	return b, nil
+}
+
+// Summarize adds up the taxes of results by jurisdiction and rate, as they are reported to each one. A
+// jurisdiction which applied reduced rates to some categories has an entry for each rate applied, and one
+// which exempted every line has a single exempt entry
+func Summarize(results ...Result) []Tax {
+	var summary []Tax
+
+	// jurisdictions has the first entry of each jurisdiction, index the one of each jurisdiction and rate
+	jurisdictions, index := map[string]int{}, map[string]int{}
+
+	for _, r := range results {
+		for _, t := range r.Taxes {
+			code := t.Jurisdiction.Code
+			first, seen := jurisdictions[code]
+
+			if t.Exempt {
+				if !seen {
+					jurisdictions[code] = len(summary)
+					summary = append(summary, Tax{Jurisdiction: t.Jurisdiction, Exempt: true})
+				}
+
+				continue
+			}
+
+			key := code + " " + t.Rate.String()
+			i, ok := index[key]
+
+			switch {
+			case ok:
+			case seen && summary[first].Exempt:
+				// the entry of the exempt lines is the one of the first rate applied
+				i = first
+				summary[i].Exempt, summary[i].Rate = false, t.Rate
+			default:
+				i = len(summary)
+				summary = append(summary, Tax{Jurisdiction: t.Jurisdiction, Rate: t.Rate})
+
+				if !seen {
+					jurisdictions[code] = i
+				}
+			}
+
+			index[key] = i
+
+			s := &summary[i]
+			s.Taxable = s.Taxable.Add(t.Taxable)
+			s.Amount = s.Amount.Add(t.Amount)
+		}
+	}
+
+	return summary
+}
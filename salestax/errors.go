@@ -0,0 +1,22 @@
+package salestax
+
+import (
+	"fmt"
+
+	"github.com/profe-ajedrez/bolson/internal/csvtable"
+)
+
+// ErrInvalidLevel the level of the jurisdiction is not valid
+func ErrInvalidLevel(info any) error {
+	return fmt.Errorf("[ErrInvalidLevel] the level of the jurisdiction is not valid. %v", info)
+}
+
+// ErrInvalidTable the rate table could not be read
+func ErrInvalidTable(row int, err error) error {
+	return csvtable.ErrInvalidTable(row, err)
+}
+
+// ErrUnknownAddress no jurisdiction of the rate table covers the address
+func ErrUnknownAddress(info any) error {
+	return fmt.Errorf("[ErrUnknownAddress] no jurisdiction of the rate table covers the address. %v", info)
+}
@@ -0,0 +1,158 @@
+// Package salestax calculates the US sales tax of lines whose ship to address is resolved, from a local rate
+// table, to the jurisdictions which tax it: the state, the county, the city and the special districts.
+//
+// Each jurisdiction has its own rate, taxability by category and cap, and its tax is calculated by bolson as
+// a coded tax, so it is reported apart:
+//
+//	table, err := salestax.LoadTable(f)
+//	result, err := table.Calculate(line)
+package salestax
+
+import (
+	"github.com/profe-ajedrez/bolson"
+	"github.com/shopspring/decimal"
+)
+
+// Level is the kind of jurisdiction
+type Level uint8
+
+const (
+	State    = Level(0)
+	County   = Level(1)
+	City     = Level(2)
+	District = Level(3)
+
+	InvalidLevel = Level(99)
+)
+
+var levels = []string{"state", "county", "city", "district"}
+
+// String converts Level to string
+func (l Level) String() string {
+	if int(l) < len(levels) {
+		return levels[l]
+	}
+
+	return "invalid"
+}
+
+// ParseLevel converts a name as state or county to Level
+func ParseLevel(s string) (Level, error) {
+	for i, name := range levels {
+		if name == s {
+			return Level(i), nil
+		}
+	}
+
+	return InvalidLevel, ErrInvalidLevel(s)
+}
+
+// Sourcing indicates which address determines the jurisdictions of the sales inside a state
+type Sourcing uint8
+
+const (
+	// Destination sources the sales by the ship to address
+	Destination = Sourcing(0)
+
+	// Origin sources the sales by the ship from address
+	Origin = Sourcing(1)
+
+	// Mixed sources the state, county and city taxes by the origin and the districts by the destination,
+	// as California does
+	Mixed = Sourcing(2)
+)
+
+// Address is the location of the seller or the buyer
+type Address struct {
+	// State is the USPS code of the state, as TX
+	State string `json:"state"`
+
+	// ZIP is the ZIP code, only its first 5 digits are used
+	ZIP string `json:"zip"`
+}
+
+// zip5 returns the five digits ZIP code of a
+func (a Address) zip5() string {
+	if len(a.ZIP) > 5 {
+		return a.ZIP[:5]
+	}
+
+	return a.ZIP
+}
+
+// Jurisdiction is a taxing authority
+type Jurisdiction struct {
+	State string `json:"state"`
+
+	// ZIP is the ZIP code the jurisdiction applies to, empty in the ones which cover the whole state
+	ZIP   string `json:"zip,omitempty"`
+	Level Level  `json:"level"`
+
+	// Code identifies the jurisdiction, it must be unique in the table
+	Code string `json:"code"`
+	Name string `json:"name"`
+
+	// Rate is the general rate, a percentage
+	Rate decimal.Decimal `json:"rate"`
+
+	// Cap is the max taxable amount of each unit of a line, as the single article caps, zero means no cap
+	Cap decimal.Decimal `json:"cap"`
+
+	// Exempt are the categories the jurisdiction does not tax
+	Exempt []string `json:"exempt,omitempty"`
+
+	// Rates are the reduced rates of some categories
+	Rates map[string]decimal.Decimal `json:"rates,omitempty"`
+}
+
+// rate returns the rate of the category in j and if it taxes it
+func (j Jurisdiction) rate(category string) (decimal.Decimal, bool) {
+	for _, e := range j.Exempt {
+		if e == category {
+			return decimal.Zero, false
+		}
+	}
+
+	if r, ok := j.Rates[category]; ok {
+		return r, true
+	}
+
+	return j.Rate, true
+}
+
+// Line is a line of a sale
+type Line struct {
+	// Value is the unit value, Discount an amount of the line
+	Value    decimal.Decimal `json:"value"`
+	Qty      decimal.Decimal `json:"qty"`
+	Discount decimal.Decimal `json:"discount"`
+
+	// Category is the taxability category of the product, as food or clothing. Empty is general
+	Category string `json:"category,omitempty"`
+
+	ShipFrom Address `json:"shipFrom"`
+	ShipTo   Address `json:"shipTo"`
+}
+
+// Tax is the tax of a jurisdiction
+type Tax struct {
+	Jurisdiction Jurisdiction `json:"jurisdiction"`
+
+	// Exempt indicates the jurisdiction does not tax the category of the line
+	Exempt  bool            `json:"exempt,omitempty"`
+	Rate    decimal.Decimal `json:"rate"`
+	Taxable decimal.Decimal `json:"taxable"`
+	Amount  decimal.Decimal `json:"amount"`
+}
+
+// Result is a calculated line
+type Result struct {
+	Taxes []Tax `json:"taxes"`
+
+	// Net is the value of the line after discount, Tax the sum of the jurisdictions and Total both
+	Net   decimal.Decimal `json:"net"`
+	Tax   decimal.Decimal `json:"tax"`
+	Total decimal.Decimal `json:"total"`
+
+	Calc bolson.Bag `json:"calc"`
+}
@@ -0,0 +1,260 @@
+package salestax
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+const rates = `state,zip,level,code,name,rate,cap,exempt,rates
+CA,,state,CA,California,7.25,,food,
+CA,90001,county,CA-LA,Los Angeles County,0.25,,food,
+CA,90001,district,CA-LA-MTA,Los Angeles County MTA,2.25,,food,
+CA,94103,county,CA-SF,San Francisco County,0.25,,food,
+CA,94103,district,CA-SF-SFMTA,San Francisco Transit,1.25,,food,
+TX,,state,TX,Texas,6.25,,food,
+TX,78701,city,TX-AUSTIN,Austin,1,,food,
+TX,78701,district,TX-AUSTIN-MTA,Austin MTA,1,,food,
+TX,75201,city,TX-DALLAS,Dallas,1,,food,
+TX,75201,district,TX-DALLAS-DART,Dallas DART,1,,food,
+TN,,state,TN,Tennessee,7,,,food=4
+TN,37203,county,TN-DAVIDSON,Davidson County,2.25,1600,,
+NY,,state,NY,New York,4,,clothing,
+NY,10001,city,NY-NYC,New York City,4.5,,,
+NY,10001,district,NY-MCTD,Metropolitan Commuter Transportation District,0.375,,,
+`
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func table(t *testing.T) *Table {
+	tb, err := LoadTable(strings.NewReader(rates))
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	return tb
+}
+
+// codes returns the codes of the jurisdictions of r
+func codes(r Result) string {
+	c := make([]string, len(r.Taxes))
+
+	for i, t := range r.Taxes {
+		c[i] = t.Jurisdiction.Code
+	}
+
+	return strings.Join(c, ",")
+}
+
+func TestDestination(t *testing.T) {
+	r, err := table(t).Calculate(Line{
+		Value: dec("100"), Qty: dec("2"), Discount: dec("20"),
+		ShipFrom: Address{State: "NJ", ZIP: "07030"}, ShipTo: Address{State: "NY", ZIP: "10001-2345"},
+	})
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if codes(r) != "NY,NY-NYC,NY-MCTD" {
+		t.Logf("unexpected jurisdictions %s", codes(r))
+		t.FailNow()
+	}
+
+	// 180 at 4%, 4.5% and 0.375%, each one rounded to cents
+	amounts := []string{"7.2", "8.1", "0.68"}
+
+	for i, a := range amounts {
+		if !r.Taxes[i].Amount.Equal(dec(a)) || !r.Taxes[i].Taxable.Equal(dec("180")) {
+			t.Logf("%s: expected %s. Got %+v", r.Taxes[i].Jurisdiction.Code, a, r.Taxes[i])
+			t.FailNow()
+		}
+	}
+
+	if !r.Net.Equal(dec("180")) || !r.Tax.Equal(dec("15.98")) || !r.Total.Equal(dec("195.98")) {
+		t.Logf("unexpected result %+v", r)
+		t.FailNow()
+	}
+
+	// the bag has the sum of the jurisdictions
+	if !r.Calc.WithDiscount.Tax.Round(2).Equal(dec("15.98")) {
+		t.Logf("unexpected calc %v", r.Calc)
+		t.FailNow()
+	}
+}
+
+func TestSourcing(t *testing.T) {
+	tb := table(t)
+
+	cases := []struct {
+		from, to Address
+		expected string
+	}{
+		// origin based inside Texas
+		{Address{"TX", "78701"}, Address{"TX", "75201"}, "TX,TX-AUSTIN,TX-AUSTIN-MTA"},
+		// between states the destination
+		{Address{"TX", "78701"}, Address{"NY", "10001"}, "NY,NY-NYC,NY-MCTD"},
+		// california sources the districts by destination
+		{Address{"CA", "90001"}, Address{"CA", "94103"}, "CA,CA-LA,CA-SF-SFMTA"},
+	}
+
+	for _, c := range cases {
+		r, err := tb.Calculate(Line{Value: dec("10"), Qty: dec("1"), ShipFrom: c.from, ShipTo: c.to})
+
+		if err != nil {
+			t.Logf("unexpected error %v", err)
+			t.FailNow()
+		}
+
+		if codes(r) != c.expected {
+			t.Logf("from %v to %v: expected %s. Got %s", c.from, c.to, c.expected, codes(r))
+			t.FailNow()
+		}
+	}
+
+	// without origin sourcing Texas is destination based
+	tb.Sourcing = map[string]Sourcing{}
+
+	r, _ := tb.Calculate(Line{Value: dec("10"), Qty: dec("1"), ShipFrom: Address{"TX", "78701"}, ShipTo: Address{"TX", "75201"}})
+
+	if codes(r) != "TX,TX-DALLAS,TX-DALLAS-DART" {
+		t.Logf("unexpected jurisdictions %s", codes(r))
+		t.FailNow()
+	}
+}
+
+func TestCapsAndCategories(t *testing.T) {
+	tb := table(t)
+	nashville := Address{State: "TN", ZIP: "37203"}
+
+	general, _ := tb.Calculate(Line{Value: dec("2000"), Qty: dec("1"), ShipFrom: nashville, ShipTo: nashville})
+
+	// the county taxes only the first 1600 of the line
+	if !general.Taxes[0].Amount.Equal(dec("140")) || !general.Taxes[1].Amount.Equal(dec("36")) || !general.Taxes[1].Taxable.Equal(dec("1600")) {
+		t.Logf("unexpected taxes %+v", general.Taxes)
+		t.FailNow()
+	}
+
+	// the cap is by unit, two units of 1000 are taxed in full and the ones of 2000 up to 1600 each
+	cases := []struct{ value, taxable, amount string }{
+		{"1000", "2000", "45"},
+		{"2000", "3200", "72"},
+	}
+
+	for _, c := range cases {
+		r, _ := tb.Calculate(Line{Value: dec(c.value), Qty: dec("2"), ShipFrom: nashville, ShipTo: nashville})
+
+		if !r.Taxes[1].Taxable.Equal(dec(c.taxable)) || !r.Taxes[1].Amount.Equal(dec(c.amount)) {
+			t.Logf("%s: expected %s over %s. Got %+v", c.value, c.amount, c.taxable, r.Taxes[1])
+			t.FailNow()
+		}
+	}
+
+	food, _ := tb.Calculate(Line{Value: dec("2000"), Qty: dec("1"), Category: "food", ShipFrom: nashville, ShipTo: nashville})
+
+	if !food.Taxes[0].Rate.Equal(dec("4")) || !food.Taxes[0].Amount.Equal(dec("80")) || !food.Tax.Equal(dec("116")) {
+		t.Logf("unexpected taxes %+v", food.Taxes)
+		t.FailNow()
+	}
+
+	la := Address{State: "CA", ZIP: "90001"}
+
+	exempt, _ := tb.Calculate(Line{Value: dec("50"), Qty: dec("1"), Category: "food", ShipFrom: la, ShipTo: la})
+
+	if !exempt.Tax.IsZero() || len(exempt.Taxes) != 3 || !exempt.Taxes[0].Exempt || !exempt.Total.Equal(dec("50")) {
+		t.Logf("unexpected result %+v", exempt)
+		t.FailNow()
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tb := table(t)
+	nyc := Address{State: "NY", ZIP: "10001"}
+
+	r1, _ := tb.Calculate(Line{Value: dec("100"), Qty: dec("1"), ShipFrom: nyc, ShipTo: nyc})
+	r2, _ := tb.Calculate(Line{Value: dec("50"), Qty: dec("1"), Category: "clothing", ShipFrom: nyc, ShipTo: nyc})
+
+	summary := Summarize(r1, r2)
+
+	if len(summary) != 3 {
+		t.Logf("unexpected summary %+v", summary)
+		t.FailNow()
+	}
+
+	// the state does not tax clothing, the city does
+	if !summary[0].Taxable.Equal(dec("100")) || !summary[0].Amount.Equal(dec("4")) {
+		t.Logf("unexpected state summary %+v", summary[0])
+		t.FailNow()
+	}
+
+	if !summary[1].Taxable.Equal(dec("150")) || !summary[1].Amount.Equal(dec("6.75")) {
+		t.Logf("unexpected city summary %+v", summary[1])
+		t.FailNow()
+	}
+
+	// the reduced rate of food is reported apart from the general one
+	nashville := Address{State: "TN", ZIP: "37203"}
+
+	r3, _ := tb.Calculate(Line{Value: dec("100"), Qty: dec("1"), ShipFrom: nashville, ShipTo: nashville})
+	r4, _ := tb.Calculate(Line{Value: dec("50"), Qty: dec("1"), Category: "food", ShipFrom: nashville, ShipTo: nashville})
+
+	summary = Summarize(r3, r4)
+
+	expected := []struct{ code, rate, taxable, amount string }{
+		{"TN", "7", "100", "7"},
+		{"TN-DAVIDSON", "2.25", "150", "3.38"},
+		{"TN", "4", "50", "2"},
+	}
+
+	if len(summary) != len(expected) {
+		t.Logf("unexpected summary %+v", summary)
+		t.FailNow()
+	}
+
+	for i, e := range expected {
+		s := summary[i]
+
+		if s.Jurisdiction.Code != e.code || !s.Rate.Equal(dec(e.rate)) || !s.Taxable.Equal(dec(e.taxable)) || !s.Amount.Equal(dec(e.amount)) {
+			t.Logf("expected %+v. Got %+v", e, s)
+			t.FailNow()
+		}
+	}
+
+	// a jurisdiction which exempts every line
+	la := Address{State: "CA", ZIP: "90001"}
+	r5, _ := tb.Calculate(Line{Value: dec("50"), Qty: dec("1"), Category: "food", ShipFrom: la, ShipTo: la})
+
+	if summary = Summarize(r5); len(summary) != 3 || !summary[0].Exempt || !summary[0].Rate.IsZero() {
+		t.Logf("unexpected summary %+v", summary)
+		t.FailNow()
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tb := table(t)
+
+	if _, err := tb.Calculate(Line{Value: dec("10"), Qty: dec("1"), ShipTo: Address{State: "OR", ZIP: "97201"}}); err == nil || !strings.Contains(err.Error(), "ErrUnknownAddress") {
+		t.Logf("expected ErrUnknownAddress. Got %v", err)
+		t.FailNow()
+	}
+
+	tables := map[string]string{
+		"missing column": "state,zip,level,code,name\nTX,,state,TX,Texas\n",
+		"invalid level":  "state,zip,level,code,name,rate\nTX,,country,TX,Texas,6.25\n",
+		"invalid rate":   "state,zip,level,code,name,rate\nTX,,state,TX,Texas,six\n",
+		"invalid rates":  "state,zip,level,code,name,rate,cap,exempt,rates\nTX,,state,TX,Texas,6.25,,,food\n",
+	}
+
+	for name, data := range tables {
+		if _, err := LoadTable(strings.NewReader(data)); err == nil || !strings.Contains(err.Error(), "ErrInvalidTable") {
+			t.Logf("%s: expected ErrInvalidTable. Got %v", name, err)
+			t.FailNow()
+		}
+	}
+}
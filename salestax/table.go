@@ -0,0 +1,175 @@
+package salestax
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/profe-ajedrez/bolson/internal/csvtable"
+	"github.com/shopspring/decimal"
+)
+
+// columns of the rate table, exempt and rates are lists separated by ";", as food;clothing and food=4
+var columns = []string{"state", "zip", "level", "code", "name", "rate", "cap", "exempt", "rates"}
+
+// Table is a local rate table, the jurisdictions of each state and ZIP code
+type Table struct {
+	jurisdictions []Jurisdiction
+
+	// Sourcing is the sourcing of the sales inside each state, those not listed are destination based
+	Sourcing map[string]Sourcing
+}
+
+// DefaultSourcing returns the states whose sales inside the state are not destination based
+func DefaultSourcing() map[string]Sourcing {
+	s := map[string]Sourcing{"CA": Mixed}
+
+	for _, state := range []string{"AZ", "IL", "MS", "MO", "OH", "PA", "TN", "TX", "UT", "VA"} {
+		s[state] = Origin
+	}
+
+	return s
+}
+
+// NewTable returns a table with the jurisdictions js and the default sourcing
+func NewTable(js ...Jurisdiction) *Table {
+	return &Table{jurisdictions: js, Sourcing: DefaultSourcing()}
+}
+
+// LoadTable reads a rate table in CSV with a header row naming the columns state, zip, level, code, name,
+// rate, cap, exempt and rates. The ones after rate are optional
+func LoadTable(r io.Reader) (*Table, error) {
+	t := NewTable()
+
+	err := csvtable.Load(r, columns[:6], func(row csvtable.Row) error {
+		j, err := parseJurisdiction(row)
+
+		if err != nil {
+			return err
+		}
+
+		t.jurisdictions = append(t.jurisdictions, j)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func parseJurisdiction(row csvtable.Row) (Jurisdiction, error) {
+	field := row.Field
+
+	j := Jurisdiction{State: strings.ToUpper(field("state")), ZIP: field("zip"), Code: field("code"), Name: field("name")}
+
+	if j.State == "" || j.Code == "" {
+		return j, errors.New("state and code are required")
+	}
+
+	level, err := ParseLevel(strings.ToLower(field("level")))
+
+	if err != nil {
+		return j, err
+	}
+
+	j.Level = level
+
+	if j.Rate, err = decimal.NewFromString(field("rate")); err != nil {
+		return j, err
+	}
+
+	if limit := field("cap"); limit != "" {
+		if j.Cap, err = decimal.NewFromString(limit); err != nil {
+			return j, err
+		}
+	}
+
+	if exempt := field("exempt"); exempt != "" {
+		j.Exempt = strings.Split(exempt, ";")
+	}
+
+	if rates := field("rates"); rates != "" {
+		j.Rates = map[string]decimal.Decimal{}
+
+		for _, r := range strings.Split(rates, ";") {
+			category, value, ok := strings.Cut(r, "=")
+
+			if !ok {
+				return j, errors.New("invalid rate " + r)
+			}
+
+			if j.Rates[category], err = decimal.NewFromString(value); err != nil {
+				return j, err
+			}
+		}
+	}
+
+	return j, nil
+}
+
+// Add adds jurisdictions to t
+func (t *Table) Add(js ...Jurisdiction) {
+	t.jurisdictions = append(t.jurisdictions, js...)
+}
+
+// Resolve returns the jurisdictions of the address, ordered by level
+func (t *Table) Resolve(a Address) []Jurisdiction {
+	var js []Jurisdiction
+
+	seen := map[string]bool{}
+	state, zip := strings.ToUpper(a.State), a.zip5()
+
+	for _, j := range t.jurisdictions {
+		if j.State != state || (j.ZIP != "" && j.ZIP != zip) || seen[j.Code] {
+			continue
+		}
+
+		seen[j.Code] = true
+		js = append(js, j)
+	}
+
+	sort.SliceStable(js, func(i, k int) bool { return js[i].Level < js[k].Level })
+
+	return js
+}
+
+// Jurisdictions returns the jurisdictions which tax a sale shipped from one address to another. Sales
+// between states are sourced by the destination, the ones inside a state by the sourcing of the state
+func (t *Table) Jurisdictions(from Address, to Address) ([]Jurisdiction, error) {
+	sourcing := Destination
+
+	if strings.EqualFold(from.State, to.State) {
+		sourcing = t.Sourcing[strings.ToUpper(to.State)]
+	}
+
+	var js []Jurisdiction
+
+	switch sourcing {
+	case Origin:
+		js = t.Resolve(from)
+	case Mixed:
+		for _, j := range t.Resolve(from) {
+			if j.Level != District {
+				js = append(js, j)
+			}
+		}
+
+		for _, j := range t.Resolve(to) {
+			if j.Level == District {
+				js = append(js, j)
+			}
+		}
+	default:
+		js = t.Resolve(to)
+	}
+
+	if len(js) == 0 {
+		return nil, ErrUnknownAddress(to)
+	}
+
+	return js, nil
+}
@@ -0,0 +1,71 @@
+package bolson
+
+import (
+	"github.com/profe-ajedrez/bolson/index"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// settings stores the configuration of [Bolson] which is evaluated on each calculation
+type settings struct {
+	rules            []tax.Rule
+	coded            []tax.Rule
+	indexedTaxes     []indexedTax
+	indexedDiscounts []indexedDiscount
+	attributes       tax.Attributes
+	rateProvider     index.RateProvider
+}
+
+// reset removes the registered rules, coded taxes and indexed amounts, keeping the attributes and the rate provider
+func (s *settings) reset() {
+	s.rules = nil
+	s.coded = nil
+	s.indexedTaxes = nil
+	s.indexedDiscounts = nil
+}
+
+// prepared runs calculation over a copy of b with the indexed amounts converted at the date of the
+// operation and the conditional taxes whose predicates hold registered.
+//
+// When the unit value is unknown, as when calculating from brute values, the conditional taxes are
+// evaluated over the unit value obtained with them, see [Bolson.conditioned]
+func (b Bolson) prepared(unitValue *decimal.Decimal, qty decimal.Decimal, calculation func(Bolson) (Bag, error)) (Bag, error) {
+	return b.withSettings(unitValue, qty, detailed(qty, calculation))
+}
+
+// withSettings runs calculation as prepared does, without adding the details of the coded taxes
+func (b Bolson) withSettings(unitValue *decimal.Decimal, qty decimal.Decimal, calculation func(Bolson) (Bag, error)) (Bag, error) {
+	if len(b.settings.rules) == 0 && len(b.settings.indexedTaxes) == 0 && len(b.settings.indexedDiscounts) == 0 {
+		return calculation(b)
+	}
+
+	p := b
+	var rates map[index.Unit]decimal.Decimal
+
+	if len(b.settings.indexedTaxes) > 0 || len(b.settings.indexedDiscounts) > 0 {
+		var err error
+
+		p = b.clone()
+		rates, err = p.registerIndexed()
+
+		if err != nil {
+			return Bag{}, err
+		}
+	}
+
+	calc, err := p.conditioned(unitValue, qty, calculation)
+	calc.Rates = rates
+
+	return calc, err
+}
+
+// clone returns a copy of s whose registries could be modified without affecting s
+func (s *settings) clone() *settings {
+	c := *s
+	c.rules = append([]tax.Rule(nil), s.rules...)
+	c.coded = append([]tax.Rule(nil), s.coded...)
+	c.indexedTaxes = append([]indexedTax(nil), s.indexedTaxes...)
+	c.indexedDiscounts = append([]indexedDiscount(nil), s.indexedDiscounts...)
+
+	return &c
+}
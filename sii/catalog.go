@@ -0,0 +1,265 @@
+package sii
+
+import (
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/index"
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// Catalog is a set of taxes by SII code
+type Catalog struct {
+	entries map[string]Entry
+	codes   []string
+
+	// Rates converts the indexed amounts in [Catalog.Calculate]
+	Rates index.RateProvider
+}
+
+// NewCatalog returns a catalog with the taxes es
+func NewCatalog(es ...Entry) (*Catalog, error) {
+	c := &Catalog{entries: map[string]Entry{}}
+
+	for _, e := range es {
+		if err := c.Register(e); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Default returns the catalog of the current chilean taxes. The specific taxes of fuels have only their
+// fixed component, 1.5 UTM by cubic meter of diesel and 6 of gasoline, the variable one is set weekly and
+// could be registered as a tax of its own
+func Default() *Catalog {
+	percent := func(code string, name string, kind Kind, rate string) Entry {
+		return Entry{Code: code, Name: name, Kind: kind, Value: decimal.RequireFromString(rate), Mode: tax.PercentualMode, Stage: tax.OverTaxable}
+	}
+	fuel := func(code string, name string, utm string) Entry {
+		return Entry{Code: code, Name: name, Kind: Specific, Value: decimal.RequireFromString(utm), Mode: tax.AmountUnitMode, Stage: tax.OverTaxable, Unit: index.UTM}
+	}
+
+	c, _ := NewCatalog(
+		percent(IVA, "IVA", VAT, "19"),
+
+		percent(LuxuryJewelry, "Impuesto adicional art. 37 letras a, b y c", Additional, "15"),
+		percent(LuxuryGoods, "Impuesto adicional art. 37 letras e, h, i y l", Additional, "15"),
+		percent(LuxuryPyrotechnics, "Impuesto adicional art. 37 letra j", Additional, "50"),
+
+		percent(ILASpirits, "Licores, piscos y destilados", Additional, "31.5"),
+		percent(ILAWine, "Vinos", Additional, "20.5"),
+		percent(ILABeer, "Cervezas y bebidas alcohólicas", Additional, "20.5"),
+		percent(ILASoftDrinks, "Bebidas analcohólicas y minerales", Additional, "10"),
+		percent(ILASugaryDrinks, "Bebidas analcohólicas con elevado contenido de azúcares", Additional, "18"),
+
+		fuel(SpecificDiesel, "Impuesto específico diésel", "0.0015"),
+		fuel(SpecificGasoline, "Impuesto específico gasolinas", "0.006"),
+
+		percent(RetentionTotal, "IVA retenido total", Withholding, "19"),
+		percent(RetentionLegumes, "IVA retenido legumbres", Withholding, "10"),
+		percent(RetentionWild, "IVA retenido silvestres", Withholding, "19"),
+		percent(RetentionCattle, "IVA retenido ganado", Withholding, "8"),
+		percent(RetentionWood, "IVA retenido madera", Withholding, "8"),
+		percent(RetentionWheat, "IVA retenido trigo", Withholding, "11"),
+		percent(RetentionRice, "IVA retenido arroz", Withholding, "10"),
+		percent(RetentionHydrobiology, "IVA retenido hidrobiológicas", Withholding, "10"),
+		percent(RetentionScrap, "IVA retenido chatarra", Withholding, "19"),
+		percent(RetentionPPA, "IVA retenido PPA", Withholding, "19"),
+		percent(RetentionConstruction, "IVA retenido construcción", Withholding, "19"),
+		percent(RetentionGold, "IVA retenido oro", Withholding, "19"),
+		percent(RetentionCardboard, "IVA retenido cartones", Withholding, "19"),
+		percent(RetentionRaspberries, "IVA retenido frambuesas y pasas", Withholding, "14"),
+	)
+
+	return c
+}
+
+// Register adds the tax e to c, replacing the one with its code
+func (c *Catalog) Register(e Entry) error {
+	switch {
+	case e.Code == "":
+		return ErrInvalidEntry("the code is required")
+	case e.Value.IsNegative():
+		return ErrInvalidEntry("negative value of " + e.Code)
+	case e.Mode > tax.AmountUnitMode:
+		return ErrInvalidEntry("invalid mode of " + e.Code)
+	case e.Stage > tax.OverTaxIgnorable:
+		return ErrInvalidEntry("invalid stage of " + e.Code)
+	case e.Unit != "" && e.Mode == tax.PercentualMode:
+		return ErrInvalidEntry("percentual tax in an indexed unit " + e.Code)
+	}
+
+	if _, ok := c.entries[e.Code]; !ok {
+		c.codes = append(c.codes, e.Code)
+	}
+
+	c.entries[e.Code] = e
+
+	return nil
+}
+
+// Entry returns the tax with the code
+func (c *Catalog) Entry(code string) (Entry, error) {
+	e, ok := c.entries[code]
+
+	if !ok {
+		return e, ErrUnknownCode(code)
+	}
+
+	return e, nil
+}
+
+// Entries returns the taxes of c, in the order they were registered
+func (c *Catalog) Entries() []Entry {
+	es := make([]Entry, len(c.codes))
+
+	for i, code := range c.codes {
+		es[i] = c.entries[code]
+	}
+
+	return es
+}
+
+// Add adds the tax with the code to b as a coded tax. The taxes in indexed units are converted with the
+// rate provider and the date of b. The withholdings are not added, as they do not increase the brute of the
+// line, they are calculated by [Catalog.Calculate]
+func (c *Catalog) Add(b bolson.Bolson, code string) error {
+	e, err := c.Entry(code)
+
+	if err != nil {
+		return err
+	}
+
+	if e.Kind == Withholding {
+		return ErrWithholding(code)
+	}
+
+	return add(b, e)
+}
+
+// add adds the tax of e to b as a coded tax
+func add(b bolson.Bolson, e Entry) error {
+	if e.Unit != "" {
+		return b.AddIndexedCodedTax(e.Code, e.Value, e.Unit, e.Mode, e.Stage, tax.DiscountedBase)
+	}
+
+	return b.AddCodedTax(e.Code, e.Value, e.Mode, e.Stage, tax.DiscountedBase)
+}
+
+// Calculate calculates the taxes with the codes of l. The withholdings are calculated over the same net,
+// apart from the taxes
+func (c *Catalog) Calculate(l Line) (Result, error) {
+	r := Result{}
+
+	taxes, err := c.calculator(l)
+
+	if err != nil {
+		return r, err
+	}
+
+	withholdings, err := c.calculator(l)
+
+	if err != nil {
+		return r, err
+	}
+
+	withheld := false
+
+	for _, code := range l.Codes {
+		e, err := c.Entry(code)
+
+		if err != nil {
+			return r, err
+		}
+
+		b := taxes
+
+		if e.Kind == Withholding {
+			b, withheld = withholdings, true
+		}
+
+		if err := add(b, e); err != nil {
+			return r, err
+		}
+	}
+
+	line := bolson.Line{Value: l.Value, Qty: l.Qty, MaxDiscount: numbers.Hundred, From: bolson.FromUnitValue}
+
+	if r.Calc, err = taxes.CalculateLine(line); err != nil {
+		return r, err
+	}
+
+	r.Net = r.Calc.WithDiscount.Net.Round(0)
+
+	if r.Taxes, err = c.amounts(r.Calc.Taxes); err != nil {
+		return r, err
+	}
+
+	for _, t := range r.Taxes {
+		r.Tax = r.Tax.Add(t.Amount)
+	}
+
+	r.Total = r.Net.Add(r.Tax)
+	r.Payable = r.Total
+
+	if !withheld {
+		return r, nil
+	}
+
+	calc, err := withholdings.CalculateLine(line)
+
+	if err != nil {
+		return r, err
+	}
+
+	if r.Withholdings, err = c.amounts(calc.Taxes); err != nil {
+		return r, err
+	}
+
+	for _, t := range r.Withholdings {
+		r.Withheld = r.Withheld.Add(t.Amount)
+	}
+
+	r.Payable = r.Total.Sub(r.Withheld)
+
+	return r, nil
+}
+
+// calculator returns a bolson with the discount of l, converting the indexed amounts at its date
+func (c *Catalog) calculator(l Line) (bolson.Bolson, error) {
+	b := bolson.New()
+
+	if c.Rates != nil {
+		b.SetRateProvider(c.Rates)
+	}
+
+	b.SetDate(l.Date)
+
+	if !l.Discount.IsZero() {
+		if err := b.AddDiscount(l.Discount, discount.AmountLine); err != nil {
+			return b, err
+		}
+	}
+
+	return b, nil
+}
+
+// amounts returns the taxes of the details, rounded to pesos
+func (c *Catalog) amounts(details []bolson.TaxDetail) ([]Tax, error) {
+	taxes := make([]Tax, 0, len(details))
+
+	for _, d := range details {
+		e, err := c.Entry(d.Code)
+
+		if err != nil {
+			return nil, err
+		}
+
+		taxes = append(taxes, Tax{Entry: e, Taxable: d.Taxable.Round(0), Amount: d.Amount.Round(0)})
+	}
+
+	return taxes, nil
+}
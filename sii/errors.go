@@ -0,0 +1,18 @@
+package sii
+
+import "fmt"
+
+// ErrUnknownCode the catalog has no tax with the code
+func ErrUnknownCode(info any) error {
+	return fmt.Errorf("[ErrUnknownCode] the catalog has no tax with the code. %v", info)
+}
+
+// ErrInvalidEntry the tax could not be registered in the catalog
+func ErrInvalidEntry(info any) error {
+	return fmt.Errorf("[ErrInvalidEntry] the tax could not be registered in the catalog. %v", info)
+}
+
+// ErrWithholding the withholding cannot be added to a bolson, as it does not increase the brute
+func ErrWithholding(info any) error {
+	return fmt.Errorf("[ErrWithholding] the withholding cannot be added to a bolson, as it does not increase the brute. %v", info)
+}
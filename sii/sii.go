@@ -0,0 +1,113 @@
+// Package sii is a catalog of the chilean taxes with their SII codes, so they are added to a bolson by
+// code instead of by rate, and identified by code in its results:
+//
+//	err := sii.Default().Add(b, sii.ILABeer)
+//
+// The specific taxes of fuels are set in UTM by liter, converted with the rate provider of the bolson at
+// its date. The withholdings of IVA are the part of it retained by the buyer, so they are calculated apart
+// by [Catalog.Calculate] and reduce the amount to pay, not the taxes of the line
+package sii
+
+import (
+	"time"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/index"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// Kind is the kind of a tax of the catalog
+type Kind string
+
+const (
+	VAT         = Kind("vat")
+	Additional  = Kind("additional")
+	Specific    = Kind("specific")
+	Withholding = Kind("withholding")
+)
+
+// SII codes of the taxes of the default catalog
+const (
+	IVA = "14"
+
+	// IVA withholdings, total and partial
+	RetentionTotal        = "15"
+	RetentionLegumes      = "30"
+	RetentionWild         = "31"
+	RetentionCattle       = "32"
+	RetentionWood         = "33"
+	RetentionWheat        = "34"
+	RetentionRice         = "36"
+	RetentionHydrobiology = "37"
+	RetentionScrap        = "38"
+	RetentionPPA          = "39"
+	RetentionConstruction = "41"
+	RetentionGold         = "46"
+	RetentionCardboard    = "47"
+	RetentionRaspberries  = "48"
+
+	// luxury taxes of the article 37 of the DL 825
+	LuxuryJewelry      = "23"
+	LuxuryGoods        = "44"
+	LuxuryPyrotechnics = "45"
+
+	// ILA, the taxes on alcoholic and non alcoholic drinks
+	ILASpirits      = "24"
+	ILAWine         = "25"
+	ILABeer         = "26"
+	ILASoftDrinks   = "27"
+	ILASugaryDrinks = "271"
+
+	// specific taxes of fuels
+	SpecificDiesel   = "28"
+	SpecificGasoline = "35"
+)
+
+// Entry is a tax of the catalog
+type Entry struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	Kind Kind   `json:"kind"`
+
+	// Value is a percentage in percentual mode, otherwise an amount in Unit, or in pesos when Unit is empty
+	Value decimal.Decimal `json:"value"`
+	Mode  tax.Mode        `json:"mode"`
+	Stage tax.Stage       `json:"stage"`
+	Unit  index.Unit      `json:"unit,omitempty"`
+}
+
+// Line is a line calculated with taxes of the catalog
+type Line struct {
+	Value    decimal.Decimal `json:"value"`
+	Qty      decimal.Decimal `json:"qty"`
+	Discount decimal.Decimal `json:"discount"`
+
+	// Date is the date the indexed amounts are converted at
+	Date  time.Time `json:"date"`
+	Codes []string  `json:"codes"`
+}
+
+// Tax is the amount of a tax of the catalog in a line, in pesos
+type Tax struct {
+	Entry
+
+	Taxable decimal.Decimal `json:"taxable"`
+	Amount  decimal.Decimal `json:"amount"`
+}
+
+// Result is a calculated line, in pesos
+type Result struct {
+	Taxes        []Tax `json:"taxes"`
+	Withholdings []Tax `json:"withholdings,omitempty"`
+
+	Net      decimal.Decimal `json:"net"`
+	Tax      decimal.Decimal `json:"tax"`
+	Total    decimal.Decimal `json:"total"`
+	Withheld decimal.Decimal `json:"withheld"`
+
+	// Payable is the total less the withholdings, the amount the buyer pays to the seller
+	Payable decimal.Decimal `json:"payable"`
+
+	Calc bolson.Bag `json:"calc"`
+}
@@ -0,0 +1,163 @@
+package sii
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/index"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+// amounts returns the codes of taxes with their amounts
+func amounts(taxes []Tax) string {
+	c := make([]string, len(taxes))
+
+	for i, t := range taxes {
+		c[i] = t.Code + "=" + t.Amount.String()
+	}
+
+	return strings.Join(c, ",")
+}
+
+func TestDrinks(t *testing.T) {
+	r, err := Default().Calculate(Line{Value: dec("1000"), Qty: dec("6"), Codes: []string{IVA, ILABeer}})
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if amounts(r.Taxes) != "14=1140,26=1230" || !r.Net.Equal(dec("6000")) || !r.Total.Equal(dec("8370")) || !r.Payable.Equal(r.Total) {
+		t.Logf("unexpected result %s %v %v", amounts(r.Taxes), r.Net, r.Total)
+		t.FailNow()
+	}
+
+	r, err = Default().Calculate(Line{Value: dec("890"), Qty: dec("1"), Discount: dec("90"), Codes: []string{IVA, ILASugaryDrinks}})
+
+	if err != nil || amounts(r.Taxes) != "14=152,271=144" || r.Taxes[1].Kind != Additional || !r.Total.Equal(dec("1096")) {
+		t.Logf("unexpected sugary drink %v %v", err, r)
+		t.FailNow()
+	}
+}
+
+func TestFuel(t *testing.T) {
+	rates := index.NewTable()
+	_ = rates.Set(index.UTM, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), dec("65000"))
+
+	c := Default()
+
+	line := Line{Value: dec("800"), Qty: dec("1000"), Date: time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC), Codes: []string{IVA, SpecificDiesel}}
+
+	if _, err := c.Calculate(line); err == nil {
+		t.Logf("expected an error without rate provider")
+		t.FailNow()
+	}
+
+	c.Rates = rates
+
+	r, err := c.Calculate(line)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	// 0.0015 UTM by liter, 97.5 pesos, the IVA is over the net only
+	if amounts(r.Taxes) != "14=152000,28=97500" || !r.Total.Equal(dec("1049500")) || !r.Calc.Rates[index.UTM].Equal(dec("65000")) {
+		t.Logf("unexpected result %s %v %v", amounts(r.Taxes), r.Total, r.Calc.Rates)
+		t.FailNow()
+	}
+}
+
+func TestWithholdings(t *testing.T) {
+	r, err := Default().Calculate(Line{Value: dec("100000"), Qty: dec("1"), Codes: []string{IVA, RetentionCattle}})
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if amounts(r.Taxes) != "14=19000" || amounts(r.Withholdings) != "32=8000" || !r.Total.Equal(dec("119000")) || !r.Payable.Equal(dec("111000")) {
+		t.Logf("unexpected result %s %s %v %v", amounts(r.Taxes), amounts(r.Withholdings), r.Total, r.Payable)
+		t.FailNow()
+	}
+
+	r, err = Default().Calculate(Line{Value: dec("50000"), Qty: dec("2"), Codes: []string{IVA, RetentionScrap}})
+
+	if err != nil || !r.Withheld.Equal(dec("19000")) || !r.Payable.Equal(dec("100000")) {
+		t.Logf("unexpected total withholding %v %v", err, r)
+		t.FailNow()
+	}
+}
+
+func TestAdd(t *testing.T) {
+	b := bolson.New()
+
+	if err := Default().Add(b, LuxuryPyrotechnics); err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	calc, err := b.Calculate(dec("1000"), dec("1"), dec("100"))
+
+	if err != nil || len(calc.Taxes) != 1 || calc.Taxes[0].Code != "45" || !calc.Taxes[0].Amount.Equal(dec("500")) {
+		t.Logf("unexpected calculation %v %v", err, calc.Taxes)
+		t.FailNow()
+	}
+
+	if err := Default().Add(b, "99"); err == nil || !strings.Contains(err.Error(), "[ErrUnknownCode]") {
+		t.Logf("expected unknown code, got %v", err)
+		t.FailNow()
+	}
+
+	if err := Default().Add(b, RetentionTotal); err == nil || !strings.Contains(err.Error(), "[ErrWithholding]") {
+		t.Logf("expected the withholding to be rejected, got %v", err)
+		t.FailNow()
+	}
+
+	if calc, err := b.Calculate(dec("1000"), dec("1"), dec("100")); err != nil || !calc.WithDiscount.Brute.Equal(dec("1500")) {
+		t.Logf("expected the brute 1500 without the withholding, got %v %v", err, calc.WithDiscount.Brute)
+		t.FailNow()
+	}
+}
+
+func TestRegister(t *testing.T) {
+	c := Default()
+
+	if es := c.Entries(); es[0].Code != IVA || len(es) != 25 {
+		t.Logf("unexpected entries %d", len(es))
+		t.FailNow()
+	}
+
+	// the variable component of the diesel tax, an amount in pesos by liter
+	if err := c.Register(Entry{Code: "28V", Name: "Componente variable diésel", Kind: Specific, Value: dec("12.3"), Mode: tax.AmountUnitMode}); err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	r, err := c.Calculate(Line{Value: dec("800"), Qty: dec("10"), Codes: []string{"28V"}})
+
+	if err != nil || amounts(r.Taxes) != "28V=123" {
+		t.Logf("unexpected result %v %v", err, r)
+		t.FailNow()
+	}
+
+	for _, e := range []Entry{
+		{Name: "without code"},
+		{Code: "X", Value: dec("-1")},
+		{Code: "X", Value: dec("1"), Unit: index.UF},
+		{Code: "X", Value: dec("1"), Stage: tax.InvalidStage},
+	} {
+		if err := c.Register(e); err == nil || !strings.Contains(err.Error(), "[ErrInvalidEntry]") {
+			t.Logf("expected invalid entry %v, got %v", e, err)
+			t.FailNow()
+		}
+	}
+}
@@ -1,54 +1,166 @@
 // Package tax proporciona funcionalidades relacionadas con el cálculo de impuestos en transacciones financieras.
 package tax
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// Code is a stable identifier of the kind of an [Error]
+type Code string
+
+const (
+	CodeNegativeTaxable      = Code("ErrNegativeTaxable")
+	CodeNegativeTax          = Code("ErrNegativeTax")
+	CodeNegativeQty          = Code("ErrNegativeQty")
+	CodeNegativePercent      = Code("ErrNegativePercent")
+	CodeNegativeAmountByUnit = Code("ErrNegativeAmountByUnit")
+	CodeNegativeAmountByLine = Code("ErrNegativeAmountByLine")
+	CodeInvalidDecimal       = Code("ErrInvalidDecimal")
+	CodeInvalidTaxStage      = Code("ErrInvalidTaxStage")
+	CodeInvalidTaxMode       = Code("ErrInvalidTaxMode")
+	CodeInvalidTaxBase       = Code("ErrInvalidTaxBase")
+	CodeInvalidTaxCode       = Code("ErrInvalidTaxCode")
+	CodeOther                = Code("ErrOther")
+)
+
+// Op is the operation in which an [Error] happened
+type Op string
+
+const (
+	OpAddTax = Op("AddTax")
+	OpTax    = Op("Tax")
+	OpUntax  = Op("Untax")
+)
+
+// Sentinel errors wrapped by every [Error] of the package, so they can be checked with errors.Is
+var (
+	// ErrNegative a value which cannot be negative is negative
+	ErrNegative = errors.New("negative value")
+
+	// ErrInvalidValue a value is not a valid decimal
+	ErrInvalidValue = errors.New("invalid decimal value")
+
+	// ErrInvalidStage the tax stage doesnt exists
+	ErrInvalidStage = errors.New("invalid tax stage")
+
+	// ErrInvalidMode the tax mode doesnt exists
+	ErrInvalidMode = errors.New("invalid tax mode")
+
+	// ErrInvalidBase the tax base doesnt exists
+	ErrInvalidBase = errors.New("invalid tax base")
+
+	// ErrInvalidCode the code identifying a tax is empty or unknown
+	ErrInvalidCode = errors.New("invalid tax code")
+
+	// ErrUnknown any other error
+	ErrUnknown = errors.New("tax error")
+)
+
+// Error is the error returned by the tax package.
+//
+// It carries a stable code, the operation in which happened, and the offending field and value,
+// so callers can react to it with errors.As instead of matching its message
+type Error struct {
+	Code  Code
+	Op    Op
+	Field string
+	Value any
+
+	err error
+	msg string
+}
+
+func (e *Error) Error() string {
+	if e.Op != "" {
+		return fmt.Sprintf("%s: %s %v", e.Op, e.msg, e.Value)
+	}
+
+	return fmt.Sprintf("%s %v", e.msg, e.Value)
+}
+
+// Unwrap returns the sentinel error of the kind of e
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func newError(code Code, field string, sentinel error, msg string, info any) *Error {
+	return &Error{
+		Code:  code,
+		Field: field,
+		Value: info,
+		err:   sentinel,
+		msg:   msg,
+	}
+}
+
+// withOp sets the operation of err when is an [Error] without operation
+func withOp(err error, op Op) error {
+	var e *Error
+
+	if errors.As(err, &e) && e.Op == "" {
+		e.Op = op
+	}
+
+	return err
+}
 
 // ErrNegativeTaxable the value over which calculate tax is negative
 func ErrNegativeTaxable(info any) error {
-	return fmt.Errorf("[ErrNegativeTaxable] the specified values is negative. a taxable cannot be negative %v", info)
+	return newError(CodeNegativeTaxable, "taxable", ErrNegative, "[ErrNegativeTaxable] the specified values is negative. a taxable cannot be negative", info)
 }
 
 // ErrNegativeTax the calculated tax is negative
 func ErrNegativeTax(info any) error {
-	return fmt.Errorf("[ErrNegativeTax] the specified values is negative. a tax cannot be negative %v", info)
+	return newError(CodeNegativeTax, "tax", ErrNegative, "[ErrNegativeTax] the specified values is negative. a tax cannot be negative", info)
 }
 
 // ErrNegativeQty the quatity being sold is negative
 func ErrNegativeQty(info any) error {
-	return fmt.Errorf("[ErrNegativeQty tax] the specified values is negative. a quantity cannot be negative %v", info)
+	return newError(CodeNegativeQty, "qty", ErrNegative, "[ErrNegativeQty tax] the specified values is negative. a quantity cannot be negative", info)
 }
 
 // ErrNegativePercent the percentage of the tax is negative
 func ErrNegativePercent(info any) error {
-	return fmt.Errorf("[ErrNegativePercent tax] the specified values is negative. a percentual tax cannot be negative %v", info)
+	return newError(CodeNegativePercent, "percent", ErrNegative, "[ErrNegativePercent tax] the specified values is negative. a percentual tax cannot be negative", info)
 }
 
 // ErrNegativeAmountByUnit the amoun by unit of the tax is negative
 func ErrNegativeAmountByUnit(info any) error {
-	return fmt.Errorf("[ErrNegativeAmountByUnit tax] the specified values is negative. an amount tax cannot be negative %v", info)
+	return newError(CodeNegativeAmountByUnit, "amountUnit", ErrNegative, "[ErrNegativeAmountByUnit tax] the specified values is negative. an amount tax cannot be negative", info)
 }
 
 // ErrNegativeAmountByLine the amoun by line of the tax is negative
 func ErrNegativeAmountByLine(info any) error {
-	return fmt.Errorf("[ErrNegativeAmountByLine tax] the specified values is negative. an amount line tax cannot be negative %v", info)
+	return newError(CodeNegativeAmountByLine, "amountLine", ErrNegative, "[ErrNegativeAmountByLine tax] the specified values is negative. an amount line tax cannot be negative", info)
 }
 
 // ErrInvalidDecimal a calculation o convertion produced an invalid  Bigdecimal value
 func ErrInvalidDecimal(info any) error {
-	return fmt.Errorf("[ErrInvalidDecimal tax] the specified values is not a valid decimal value. %v", info)
+	return newError(CodeInvalidDecimal, "value", ErrInvalidValue, "[ErrInvalidDecimal tax] the specified values is not a valid decimal value.", info)
 }
 
 // ErrInvalidTaxStage the tax stage not exists
 func ErrInvalidTaxStage(info any) error {
-	return fmt.Errorf("[ErrInvalidTaxStage] the specified tax stage doesnt exists. %v", info)
+	return newError(CodeInvalidTaxStage, "stage", ErrInvalidStage, "[ErrInvalidTaxStage] the specified tax stage doesnt exists.", info)
 }
 
 // ErrInvalidTaxMode the tax stage not exists
 func ErrInvalidTaxMode(info any) error {
-	return fmt.Errorf("[ErrInvalidTaxMode] the specified tax mode doesnt exists. %v", info)
+	return newError(CodeInvalidTaxMode, "mode", ErrInvalidMode, "[ErrInvalidTaxMode] the specified tax mode doesnt exists.", info)
+}
+
+// ErrInvalidTaxBase the tax base not exists
+func ErrInvalidTaxBase(info any) error {
+	return newError(CodeInvalidTaxBase, "base", ErrInvalidBase, "[ErrInvalidTaxBase] the specified tax base doesnt exists.", info)
+}
+
+// ErrInvalidTaxCode the code identifying a tax is empty or unknown
+func ErrInvalidTaxCode(info any) error {
+	return newError(CodeInvalidTaxCode, "code", ErrInvalidCode, "[ErrInvalidTaxCode] the specified tax code is invalid.", info)
 }
 
 // ErrOther other error
 func ErrOther(info any) error {
-	return fmt.Errorf("[ErrOther Tax] there was an error. %v", info)
+	return newError(CodeOther, "", ErrUnknown, "[ErrOther Tax] there was an error.", info)
 }
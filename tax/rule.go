@@ -0,0 +1,163 @@
+package tax
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Attributes describes the line being calculated, so conditional taxes can decide if they apply
+type Attributes struct {
+	// UnitValue is the unit value of the line without discounts and taxes
+	UnitValue decimal.Decimal `json:"unitValue"`
+
+	// Qty is the quantity being sold
+	Qty decimal.Decimal `json:"qty"`
+
+	// Category is the product category of the line
+	Category string `json:"category,omitempty"`
+
+	// Segment is the customer segment of the operation
+	Segment string `json:"segment,omitempty"`
+
+	// Date is the date of the operation
+	Date time.Time `json:"date"`
+}
+
+// Predicate decides if a conditional tax applies over a line
+type Predicate func(Attributes) bool
+
+// Rule is a tax which is only registered when its predicate holds
+type Rule struct {
+	// Code identifies the rule when it fires. It is required
+	Code string
+
+	Value decimal.Decimal
+	Mode  Mode
+	Stage Stage
+	Base  Base
+
+	// When is the condition which must hold to apply the tax
+	When Predicate
+}
+
+// Validate checks the rule could be registered
+func (r Rule) Validate() error {
+	if r.Code == "" {
+		return withOp(ErrInvalidTaxCode("conditional taxes are reported by their code when they fire"), OpAddTax)
+	}
+
+	if r.When == nil {
+		return withOp(ErrOther("the conditional tax "+r.Code+" has no predicate"), OpAddTax)
+	}
+
+	if r.Value.IsNegative() {
+		return withOp(ErrNegativeTax(r.Value), OpAddTax)
+	}
+
+	if r.Mode > AmountUnitMode {
+		return withOp(ErrInvalidTaxMode(r.Mode), OpAddTax)
+	}
+
+	if r.Stage > OverTaxIgnorable {
+		return withOp(ErrInvalidTaxStage(r.Stage), OpAddTax)
+	}
+
+	if r.Base > ListPriceBase {
+		return withOp(ErrInvalidTaxBase(r.Base), OpAddTax)
+	}
+
+	return nil
+}
+
+// Fired returns the rules whose predicate holds for the received attributes
+func Fired(rules []Rule, attrs Attributes) []Rule {
+	fired := make([]Rule, 0, len(rules))
+
+	for _, r := range rules {
+		if r.When(attrs) {
+			fired = append(fired, r)
+		}
+	}
+
+	return fired
+}
+
+// UnitValueOver holds when the unit value is greater than threshold, as luxury taxes do
+func UnitValueOver(threshold decimal.Decimal) Predicate {
+	return func(a Attributes) bool {
+		return a.UnitValue.GreaterThan(threshold)
+	}
+}
+
+// QtyOver holds when the quantity is greater than threshold
+func QtyOver(threshold decimal.Decimal) Predicate {
+	return func(a Attributes) bool {
+		return a.Qty.GreaterThan(threshold)
+	}
+}
+
+// InCategory holds when the line belongs to any of the received categories
+func InCategory(categories ...string) Predicate {
+	return func(a Attributes) bool {
+		return contains(categories, a.Category)
+	}
+}
+
+// ForSegment holds when the customer belongs to any of the received segments
+func ForSegment(segments ...string) Predicate {
+	return func(a Attributes) bool {
+		return contains(segments, a.Segment)
+	}
+}
+
+// Between holds when the date of the operation is in the range [from, to).
+// A zero to means there is no end for the range
+func Between(from time.Time, to time.Time) Predicate {
+	return func(a Attributes) bool {
+		return !a.Date.Before(from) && (to.IsZero() || a.Date.Before(to))
+	}
+}
+
+// All holds when every received predicate holds
+func All(predicates ...Predicate) Predicate {
+	return func(a Attributes) bool {
+		for _, p := range predicates {
+			if !p(a) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Any holds when at least one of the received predicates holds
+func Any(predicates ...Predicate) Predicate {
+	return func(a Attributes) bool {
+		for _, p := range predicates {
+			if p(a) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Not holds when the received predicate does not
+func Not(predicate Predicate) Predicate {
+	return func(a Attributes) bool {
+		return !predicate(a)
+	}
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
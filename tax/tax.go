@@ -110,6 +110,36 @@ const (
 	FromBrute = 1
 )
 
+// Base represents the value over which a tax is calculated when discounts are applied.
+//
+// Most taxes, like VAT, are calculated over the discounted value, but some excise
+// taxes are legally calculated over the list price no matter what discounts apply
+type Base uint8
+
+const (
+	// DiscountedBase taxes calculated over the value with the discounts applied
+	DiscountedBase = Base(0)
+
+	// ListPriceBase taxes calculated over the list price, ignoring the applied discounts
+	ListPriceBase = Base(1)
+
+	InvalidBase = Base(99)
+)
+
+// String converts Base to string
+func (b Base) String() string {
+	return fmt.Sprintf("%d", b)
+}
+
+// NewBaseFromInt returns a Base from int
+func NewBaseFromInt(v int) (Base, error) {
+	if v < 0 || v > 1 {
+		return InvalidBase, ErrInvalidTaxBase(v)
+	}
+
+	return Base(v), nil
+}
+
 func NewStageFromInt(st int) (Stage, error) {
 	switch st {
 	case 0:
@@ -232,6 +262,22 @@ func (ts *TaxStage) SetTaxable(v decimal.Decimal) decimal.Decimal {
 	return v
 }
 
+// Clone returns a new pointer to a copy of the stage
+func (ts *TaxStage) Clone() *TaxStage {
+	c := *ts
+	return &c
+}
+
+// Scale returns a new pointer to a copy of the stage with its amounts multiplied by rate,
+// as when converting them to another currency. Percentuals are kept
+func (ts *TaxStage) Scale(rate decimal.Decimal) *TaxStage {
+	c := ts.Clone()
+	c.amountUnit = c.amountUnit.Mul(rate)
+	c.amountLine = c.amountLine.Mul(rate)
+
+	return c
+}
+
 func (ts *TaxStage) Reset() {
 	ts.amountLine = numbers.Zero.Copy()
 	ts.amountUnit = numbers.Zero.Copy()
@@ -242,7 +288,7 @@ func (ts *TaxStage) Reset() {
 // AddAmountLine adds a new decimal value as tax to the tax registry
 func (ts *TaxStage) AddAmountLine(tax decimal.Decimal) error {
 	if tax.IsNegative() {
-		return ErrNegativeAmountByLine(tax)
+		return withOp(ErrNegativeAmountByLine(tax), OpAddTax)
 	}
 
 	ts.amountLine = ts.amountLine.Add(tax)
@@ -264,7 +310,7 @@ func (ts *TaxStage) AddAmountLineFromString(tax string) error {
 	tx, err := decimal.NewFromString(tax)
 
 	if err != nil {
-		return ErrInvalidDecimal(tax)
+		return withOp(ErrInvalidDecimal(tax), OpAddTax)
 	}
 
 	return ts.AddAmountLine(tx)
@@ -273,7 +319,7 @@ func (ts *TaxStage) AddAmountLineFromString(tax string) error {
 // AddAmountUnit adds a new decimal value as tax to the tax registry
 func (ts *TaxStage) AddAmountUnit(tax decimal.Decimal) error {
 	if tax.IsNegative() {
-		return ErrNegativeAmountByUnit(tax)
+		return withOp(ErrNegativeAmountByUnit(tax), OpAddTax)
 	}
 
 	ts.amountUnit = ts.amountUnit.Add(tax)
@@ -295,7 +341,7 @@ func (ts *TaxStage) AddAmountUnitFromString(tax string) error {
 	tx, err := decimal.NewFromString(tax)
 
 	if err != nil {
-		return ErrInvalidDecimal(tax)
+		return withOp(ErrInvalidDecimal(tax), OpAddTax)
 	}
 
 	return ts.AddAmountUnit(tx)
@@ -304,7 +350,7 @@ func (ts *TaxStage) AddAmountUnitFromString(tax string) error {
 // AddPercentual adds a new decimal value as tax to the tax registry
 func (ts *TaxStage) AddPercentual(tax decimal.Decimal) error {
 	if tax.IsNegative() {
-		return ErrNegativePercent(tax)
+		return withOp(ErrNegativePercent(tax), OpAddTax)
 	}
 
 	ts.percentuals = ts.percentuals.Add(tax)
@@ -326,7 +372,7 @@ func (ts *TaxStage) AddPercentualFromString(tax string) error {
 	tx, err := decimal.NewFromString(tax)
 
 	if err != nil {
-		return ErrInvalidDecimal(tax)
+		return withOp(ErrInvalidDecimal(tax), OpAddTax)
 	}
 
 	return ts.AddPercentual(tx)
@@ -335,11 +381,11 @@ func (ts *TaxStage) AddPercentualFromString(tax string) error {
 // Tax calculates the recorded taxes of the stage over the received taxable
 func (ts *TaxStage) Tax(taxable decimal.Decimal, qty decimal.Decimal) (decimal.Decimal, error) {
 	if taxable.IsNegative() {
-		return numbers.Zero.Copy(), ErrNegativeTaxable(taxable)
+		return numbers.Zero.Copy(), withOp(ErrNegativeTaxable(taxable), OpTax)
 	}
 
 	if qty.IsNegative() {
-		return numbers.Zero.Copy(), ErrNegativeTaxable(qty)
+		return numbers.Zero.Copy(), withOp(ErrNegativeQty(qty), OpTax)
 	}
 
 	ts.taxable = taxable.Mul(qty)
@@ -362,13 +408,13 @@ func (ts *TaxStage) TaxFromString(taxable string, qty string) (decimal.Decimal,
 	tx, err := decimal.NewFromString(taxable)
 
 	if err != nil {
-		return numbers.Zero.Copy(), ErrInvalidDecimal(taxable)
+		return numbers.Zero.Copy(), withOp(ErrInvalidDecimal(taxable), OpTax)
 	}
 
 	qt, err := decimal.NewFromString(qty)
 
 	if err != nil {
-		return numbers.Zero.Copy(), ErrInvalidDecimal(qty)
+		return numbers.Zero.Copy(), withOp(ErrInvalidDecimal(qty), OpTax)
 	}
 
 	return ts.Tax(tx, qt)
@@ -398,6 +444,17 @@ func (ts *TaxStage) Taxable() decimal.Decimal {
 	return ts.taxable.Copy()
 }
 
+// IsZero reports whether the stage has no registered taxes
+func (ts *TaxStage) IsZero() bool {
+	return ts.percentuals.IsZero() && ts.amountUnit.IsZero() && ts.amountLine.IsZero()
+}
+
+// Factors returns the slope and intercept of the stage taxes for the received quantity,
+// so that Tax(taxable, qty) == taxable * slope + intercept
+func (ts *TaxStage) Factors(qty decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	return ts.percentuals.Div(numbers.Hundred).Mul(qty), ts.amountUnit.Mul(qty).Add(ts.amountLine)
+}
+
 type Handler struct {
 	OverTaxables      *TaxStage
 	OverTaxes         *TaxStage
@@ -412,12 +469,49 @@ func NewHandler() *Handler {
 	}
 }
 
+// Clone returns a new pointer to a copy of the handler and its stages
+func (h *Handler) Clone() *Handler {
+	return &Handler{
+		OverTaxables:      h.OverTaxables.Clone(),
+		OverTaxes:         h.OverTaxes.Clone(),
+		OverTaxIgnorables: h.OverTaxIgnorables.Clone(),
+	}
+}
+
+// Scale returns a new pointer to a copy of the handler with the amounts of its stages multiplied by rate
+func (h *Handler) Scale(rate decimal.Decimal) *Handler {
+	return &Handler{
+		OverTaxables:      h.OverTaxables.Scale(rate),
+		OverTaxes:         h.OverTaxes.Scale(rate),
+		OverTaxIgnorables: h.OverTaxIgnorables.Scale(rate),
+	}
+}
+
 func (h *Handler) Reset() {
 	h.OverTaxables.Reset()
 	h.OverTaxes.Reset()
 	h.OverTaxIgnorables.Reset()
 }
 
+// IsZero reports whether the handler has no registered taxes in any of its stages
+func (h *Handler) IsZero() bool {
+	return h.OverTaxables.IsZero() && h.OverTaxes.IsZero() && h.OverTaxIgnorables.IsZero()
+}
+
+// Factors returns the slope and intercept of all the registered taxes for the received quantity,
+// so that Tax(unitTaxable, qty) == unitTaxable * slope + intercept
+func (h *Handler) Factors(qty decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	s1, i1 := h.OverTaxables.Factors(qty)
+	s2, i2 := h.OverTaxes.Factors(qty)
+	s3, i3 := h.OverTaxIgnorables.Factors(qty)
+
+	// overtaxes are calculated over unitTaxable + overtaxables / qty
+	slope := s1.Add(s2).Add(s1.Mul(s2).Div(qty)).Add(s3)
+	intercept := i1.Add(i2).Add(i1.Mul(s2).Div(qty)).Add(i3)
+
+	return slope, intercept
+}
+
 func (h *Handler) AddTax(value decimal.Decimal, mode Mode, stage Stage) error {
 	switch stage {
 	case OverTaxable:
@@ -429,7 +523,7 @@ func (h *Handler) AddTax(value decimal.Decimal, mode Mode, stage Stage) error {
 		case AmountUnitMode:
 			return h.OverTaxables.AddAmountUnit(value)
 		default:
-			return ErrInvalidTaxMode(mode)
+			return withOp(ErrInvalidTaxMode(mode), OpAddTax)
 		}
 	case OverTax:
 		switch mode {
@@ -440,7 +534,7 @@ func (h *Handler) AddTax(value decimal.Decimal, mode Mode, stage Stage) error {
 		case AmountUnitMode:
 			return h.OverTaxes.AddAmountUnit(value)
 		default:
-			return ErrInvalidTaxMode(mode)
+			return withOp(ErrInvalidTaxMode(mode), OpAddTax)
 		}
 	case OverTaxIgnorable:
 		switch mode {
@@ -451,11 +545,11 @@ func (h *Handler) AddTax(value decimal.Decimal, mode Mode, stage Stage) error {
 		case AmountUnitMode:
 			return h.OverTaxIgnorables.AddAmountUnit(value)
 		default:
-			return ErrInvalidTaxMode(mode)
+			return withOp(ErrInvalidTaxMode(mode), OpAddTax)
 		}
 	}
 
-	return ErrInvalidTaxStage(stage)
+	return withOp(ErrInvalidTaxStage(stage), OpAddTax)
 }
 
 func (h *Handler) AddTaxFromFloat32(value float32, mode Mode, stage Stage) error {
@@ -469,7 +563,7 @@ func (h *Handler) AddTaxFromFloat32(value float32, mode Mode, stage Stage) error
 		case AmountUnitMode:
 			return h.OverTaxables.AddAmountUnitFromFloat32(value)
 		default:
-			return ErrInvalidTaxMode(mode)
+			return withOp(ErrInvalidTaxMode(mode), OpAddTax)
 		}
 	case OverTax:
 		switch mode {
@@ -480,7 +574,7 @@ func (h *Handler) AddTaxFromFloat32(value float32, mode Mode, stage Stage) error
 		case AmountUnitMode:
 			return h.OverTaxes.AddAmountUnitFromFloat32(value)
 		default:
-			return ErrInvalidTaxMode(mode)
+			return withOp(ErrInvalidTaxMode(mode), OpAddTax)
 		}
 	case OverTaxIgnorable:
 		switch mode {
@@ -491,11 +585,11 @@ func (h *Handler) AddTaxFromFloat32(value float32, mode Mode, stage Stage) error
 		case AmountUnitMode:
 			return h.OverTaxIgnorables.AddAmountUnitFromFloat32(value)
 		default:
-			return ErrInvalidTaxMode(mode)
+			return withOp(ErrInvalidTaxMode(mode), OpAddTax)
 		}
 	}
 
-	return ErrInvalidTaxStage(stage)
+	return withOp(ErrInvalidTaxStage(stage), OpAddTax)
 }
 
 func (h *Handler) AddTaxFromFloat64(value float64, mode Mode, stage Stage) error {
@@ -509,7 +603,7 @@ func (h *Handler) AddTaxFromFloat64(value float64, mode Mode, stage Stage) error
 		case AmountUnitMode:
 			return h.OverTaxables.AddAmountUnitFromFloat64(value)
 		default:
-			return ErrInvalidTaxMode(mode)
+			return withOp(ErrInvalidTaxMode(mode), OpAddTax)
 		}
 	case OverTax:
 		switch mode {
@@ -520,7 +614,7 @@ func (h *Handler) AddTaxFromFloat64(value float64, mode Mode, stage Stage) error
 		case AmountUnitMode:
 			return h.OverTaxes.AddAmountUnitFromFloat64(value)
 		default:
-			return ErrInvalidTaxMode(mode)
+			return withOp(ErrInvalidTaxMode(mode), OpAddTax)
 		}
 	case OverTaxIgnorable:
 		switch mode {
@@ -531,11 +625,11 @@ func (h *Handler) AddTaxFromFloat64(value float64, mode Mode, stage Stage) error
 		case AmountUnitMode:
 			return h.OverTaxIgnorables.AddAmountUnitFromFloat64(value)
 		default:
-			return ErrInvalidTaxMode(mode)
+			return withOp(ErrInvalidTaxMode(mode), OpAddTax)
 		}
 	}
 
-	return ErrInvalidTaxStage(stage)
+	return withOp(ErrInvalidTaxStage(stage), OpAddTax)
 }
 
 func (h *Handler) AddTaxFromString(value string, mode Mode, stage Stage) error {
@@ -549,7 +643,7 @@ func (h *Handler) AddTaxFromString(value string, mode Mode, stage Stage) error {
 		case AmountUnitMode:
 			return h.OverTaxables.AddAmountUnitFromString(value)
 		default:
-			return ErrInvalidTaxMode(mode)
+			return withOp(ErrInvalidTaxMode(mode), OpAddTax)
 		}
 	case OverTax:
 		switch mode {
@@ -560,7 +654,7 @@ func (h *Handler) AddTaxFromString(value string, mode Mode, stage Stage) error {
 		case AmountUnitMode:
 			return h.OverTaxes.AddAmountUnitFromString(value)
 		default:
-			return ErrInvalidTaxMode(mode)
+			return withOp(ErrInvalidTaxMode(mode), OpAddTax)
 		}
 	case OverTaxIgnorable:
 		switch mode {
@@ -571,11 +665,11 @@ func (h *Handler) AddTaxFromString(value string, mode Mode, stage Stage) error {
 		case AmountUnitMode:
 			return h.OverTaxIgnorables.AddAmountUnitFromString(value)
 		default:
-			return ErrInvalidTaxMode(mode)
+			return withOp(ErrInvalidTaxMode(mode), OpAddTax)
 		}
 	}
 
-	return ErrInvalidTaxStage(stage)
+	return withOp(ErrInvalidTaxStage(stage), OpAddTax)
 }
 
 func (h *Handler) Tax(unit_taxable decimal.Decimal, qty decimal.Decimal) (decimal.Decimal, error) {
@@ -671,7 +765,7 @@ func (h *Handler) TaxFromString(taxable string, qty string) (decimal.Decimal, er
 func (h *Handler) Untax(brute decimal.Decimal, q decimal.Decimal, flow int8) (decimal.Decimal, error) {
 
 	if q.LessThanOrEqual(numbers.Zero) {
-		return numbers.Zero.Copy(), ErrNegativeQty(fmt.Sprintf("untaxing %v with qty %v", brute, q))
+		return numbers.Zero.Copy(), withOp(ErrNegativeQty(fmt.Sprintf("untaxing %v with qty %v", brute, q)), OpUntax)
 	}
 
 	u1 := h.OverTaxIgnorables.Untax(brute, q)
@@ -701,5 +795,5 @@ func (h *Handler) LineTax(taxable decimal.Decimal, qty decimal.Decimal, value de
 		return value.Mul(qty), nil
 	}
 
-	return numbers.Zero.Copy(), ErrInvalidTaxMode(mode)
+	return numbers.Zero.Copy(), withOp(ErrInvalidTaxMode(mode), OpTax)
 }
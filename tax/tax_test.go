@@ -1,6 +1,7 @@
 package tax
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/shopspring/decimal"
@@ -171,6 +172,31 @@ func TestTaxHandler(t *testing.T) {
 
 }
 
+func TestErrors(t *testing.T) {
+	h := NewHandler()
+
+	err := h.AddTax(decimal.NewFromInt(-1), PercentualMode, OverTax)
+
+	var e *Error
+
+	if !errors.Is(err, ErrNegative) || !errors.As(err, &e) || e.Code != CodeNegativePercent || e.Op != OpAddTax {
+		t.Logf("expected a negative percent error, got %#v", err)
+		t.FailNow()
+	}
+
+	if err = h.AddTax(decimal.NewFromInt(1), InvalidMode, OverTax); !errors.Is(err, ErrInvalidMode) {
+		t.Logf("expected an invalid mode error, got %v", err)
+		t.FailNow()
+	}
+
+	_, err = h.Untax(decimal.NewFromInt(100), decimal.Zero, FromUv)
+
+	if !errors.As(err, &e) || e.Code != CodeNegativeQty || e.Op != OpUntax || e.Field != "qty" {
+		t.Logf("expected a negative quantity error in untax, got %#v", err)
+		t.FailNow()
+	}
+}
+
 func BenchmarkTaxStageRegistryTaxes(b *testing.B) {
 	taxStager := NewTaxStage()
 
@@ -0,0 +1,230 @@
+package ubl
+
+import (
+	"github.com/profe-ajedrez/bolson"
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+// scale is the number of decimals of the amounts in EN 16931
+const scale = 2
+
+// Calculate calculates the lines, the VAT breakdown and the totals of d
+func Calculate(d Document) (*Calculated, error) {
+	if d.Kind != Invoice && d.Kind != CreditNote {
+		return nil, ErrInvalidKind(d.Kind)
+	}
+
+	c := &Calculated{Document: d, Lines: make([]Line, len(d.Items))}
+
+	for i, item := range d.Items {
+		l, err := calculateItem(item)
+
+		if err != nil {
+			return nil, ErrInvalidItem(i, err)
+		}
+
+		c.Lines[i] = l
+	}
+
+	if err := c.breakdown(); err != nil {
+		return nil, err
+	}
+
+	c.total()
+
+	return c, nil
+}
+
+// amount returns the amount of the allowance or charge a, whose percent applies over base when a has no base
+func (a AllowanceCharge) amount(base decimal.Decimal) (decimal.Decimal, error) {
+	if a.Amount.IsNegative() || a.Percent.IsNegative() || a.Base.IsNegative() {
+		return decimal.Zero, discount.ErrNegativeDiscount(a)
+	}
+
+	if a.Percent.IsZero() {
+		return a.Amount.Round(scale), nil
+	}
+
+	if a.Base.IsPositive() {
+		base = a.Base
+	}
+
+	return base.Mul(a.Percent).Div(numbers.Hundred).Round(scale), nil
+}
+
+func calculateItem(item Item) (Line, error) {
+	l := Line{Item: item, Amounts: make([]decimal.Decimal, len(item.AllowanceCharges))}
+
+	b := bolson.New()
+
+	if err := b.AddCodedTax(string(item.Category), item.Rate, tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase); err != nil {
+		return l, err
+	}
+
+	gross := item.Qty.Mul(item.Price)
+	charges := decimal.Zero
+
+	for i, a := range item.AllowanceCharges {
+		amount, err := a.amount(gross)
+
+		if err != nil {
+			return l, err
+		}
+
+		l.Amounts[i] = amount
+
+		if a.Charge {
+			charges = charges.Add(amount)
+			continue
+		}
+
+		if err := b.AddDiscount(amount, discount.AmountLine); err != nil {
+			return l, err
+		}
+	}
+
+	calc, err := b.CalculateLine(bolson.Line{Value: item.Price, Qty: item.Qty, MaxDiscount: numbers.Hundred, From: bolson.FromUnitValue})
+
+	if err != nil {
+		return l, err
+	}
+
+	l.Calc = calc
+	l.Net = calc.WithDiscount.Net.Round(scale).Add(charges)
+
+	return l, nil
+}
+
+// breakdown groups the lines and the allowances and charges of the document by category and rate, and
+// calculates the VAT of each group
+func (c *Calculated) breakdown() error {
+	type key struct {
+		category Category
+		rate     string
+	}
+
+	groups := map[key]int{}
+
+	group := func(category Category, rate decimal.Decimal) int {
+		k := key{category, rate.String()}
+
+		if i, ok := groups[k]; ok {
+			return i
+		}
+
+		groups[k] = len(c.Subtotals)
+		c.Subtotals = append(c.Subtotals, Subtotal{Category: category, Rate: rate})
+
+		return groups[k]
+	}
+
+	for _, l := range c.Lines {
+		s := &c.Subtotals[group(l.Category, l.Rate)]
+		s.Taxable = s.Taxable.Add(l.Net)
+
+		if s.ExemptionReason == "" {
+			s.ExemptionReason = l.ExemptionReason
+		}
+	}
+
+	// the lines of each group, over which the percentages of the document apply
+	lines := make([]decimal.Decimal, len(c.Subtotals))
+
+	for i, s := range c.Subtotals {
+		lines[i] = s.Taxable
+	}
+
+	c.Amounts = make([]decimal.Decimal, len(c.AllowanceCharges))
+
+	for i, a := range c.AllowanceCharges {
+		g := group(a.Category, a.Rate)
+		base := decimal.Zero
+
+		if g < len(lines) {
+			base = lines[g]
+		}
+
+		amount, err := a.amount(base)
+
+		if err != nil {
+			return ErrInvalidAllowanceCharge(i, err)
+		}
+
+		c.Amounts[i] = amount
+
+		if !a.Charge {
+			amount = amount.Neg()
+		}
+
+		c.Subtotals[g].Taxable = c.Subtotals[g].Taxable.Add(amount)
+	}
+
+	for i, s := range c.Subtotals {
+		t, err := vat(s.Category, s.Rate, s.Taxable)
+
+		if err != nil {
+			return err
+		}
+
+		c.Subtotals[i].Tax = t
+	}
+
+	return nil
+}
+
+// vat calculates the VAT of the category with rate over taxable, rounded as EN 16931 requires
+func vat(category Category, rate decimal.Decimal, taxable decimal.Decimal) (decimal.Decimal, error) {
+	if taxable.IsZero() || rate.IsZero() {
+		return decimal.Zero, nil
+	}
+
+	b := bolson.New()
+
+	if err := b.AddCodedTax(string(category), rate, tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase); err != nil {
+		return decimal.Zero, err
+	}
+
+	calc, err := b.Calculate(taxable.Abs(), decimal.NewFromInt(1), numbers.Hundred)
+
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	amount := calc.Taxes[0].Amount.Round(scale)
+
+	if taxable.IsNegative() {
+		return amount.Neg(), nil
+	}
+
+	return amount, nil
+}
+
+// total calculates the document totals from the lines and the breakdown
+func (c *Calculated) total() {
+	t := Totals{Prepaid: c.Prepaid.Round(scale)}
+
+	for _, l := range c.Lines {
+		t.LineExtension = t.LineExtension.Add(l.Net)
+	}
+
+	for i, a := range c.AllowanceCharges {
+		if a.Charge {
+			t.Charges = t.Charges.Add(c.Amounts[i])
+		} else {
+			t.Allowances = t.Allowances.Add(c.Amounts[i])
+		}
+	}
+
+	for _, s := range c.Subtotals {
+		t.Tax = t.Tax.Add(s.Tax)
+	}
+
+	t.TaxExclusive = t.LineExtension.Sub(t.Allowances).Add(t.Charges)
+	t.TaxInclusive = t.TaxExclusive.Add(t.Tax)
+	t.Payable = t.TaxInclusive.Sub(t.Prepaid)
+
+	c.Totals = t
+}
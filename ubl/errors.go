@@ -0,0 +1,18 @@
+package ubl
+
+import "fmt"
+
+// ErrInvalidItem an item could not be calculated
+func ErrInvalidItem(index int, err error) error {
+	return fmt.Errorf("[ErrInvalidItem] the item %d could not be calculated. %w", index+1, err)
+}
+
+// ErrInvalidAllowanceCharge an allowance or charge of the document could not be calculated
+func ErrInvalidAllowanceCharge(index int, err error) error {
+	return fmt.Errorf("[ErrInvalidAllowanceCharge] the allowance or charge %d could not be calculated. %w", index+1, err)
+}
+
+// ErrInvalidKind the kind of document is not supported
+func ErrInvalidKind(info any) error {
+	return fmt.Errorf("[ErrInvalidKind] the kind of document is not supported. %v", info)
+}
@@ -0,0 +1,193 @@
+// Package ubl writes calculated documents as UBL 2.1 invoices and credit notes following the european
+// standard EN 16931.
+//
+// The amounts follow the rounding of the standard, each line net amount and each allowance or charge is
+// rounded to 2 decimals and the VAT is calculated once by category and rate over the sum of those amounts:
+//
+//	calc, err := ubl.Calculate(doc)
+//	err = ubl.Validate(calc)
+//	xml, err := calc.XML()
+package ubl
+
+import (
+	"time"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/shopspring/decimal"
+)
+
+// Kind is the kind of document
+type Kind uint8
+
+const (
+	// Invoice is a commercial invoice, type code 380
+	Invoice = Kind(0)
+
+	// CreditNote is a credit note, type code 381
+	CreditNote = Kind(1)
+)
+
+// Category is the VAT category code of the UNCL5305 list used by EN 16931
+type Category string
+
+const (
+	Standard       = Category("S")
+	ZeroRated      = Category("Z")
+	Exempt         = Category("E")
+	ReverseCharge  = Category("AE")
+	IntraCommunity = Category("K")
+	Export         = Category("G")
+	OutOfScope     = Category("O")
+)
+
+// exempt indicates if the category must have a rate of zero and an exemption reason
+func (c Category) exempt() bool {
+	switch c {
+	case Exempt, ReverseCharge, IntraCommunity, Export, OutOfScope:
+		return true
+	}
+
+	return false
+}
+
+func (c Category) valid() bool {
+	return c == Standard || c == ZeroRated || c.exempt()
+}
+
+// Party is the seller or the buyer
+type Party struct {
+	Name string `json:"name"`
+
+	// VATID is the VAT identifier with the prefix of its country, as DE123456789
+	VATID      string `json:"vatId,omitempty"`
+	Street     string `json:"street,omitempty"`
+	City       string `json:"city,omitempty"`
+	PostalCode string `json:"postalCode,omitempty"`
+
+	// Country is the ISO 3166-1 alpha-2 code of the country
+	Country string `json:"country"`
+}
+
+// AllowanceCharge is a discount, allowance, or a surcharge, charge, of a line or of the document
+type AllowanceCharge struct {
+	Charge bool   `json:"charge,omitempty"`
+	Reason string `json:"reason,omitempty"`
+
+	// ReasonCode is the code of the UNCL5189 list for allowances or the UNCL7161 list for charges
+	ReasonCode string `json:"reasonCode,omitempty"`
+
+	// Amount is used when Percent is zero
+	Amount decimal.Decimal `json:"amount"`
+
+	// Percent is applied over Base, which by default is the gross value of the line or, in the document,
+	// the sum of the lines of the same category and rate
+	Percent decimal.Decimal `json:"percent"`
+	Base    decimal.Decimal `json:"base"`
+
+	// Category and Rate are the VAT of the allowances and charges of the document
+	Category Category        `json:"category,omitempty"`
+	Rate     decimal.Decimal `json:"rate"`
+}
+
+// Item is a line of the document
+type Item struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	Qty decimal.Decimal `json:"qty"`
+
+	// UnitCode is the code of the UN/ECE recommendation 20, C62 (one) by default
+	UnitCode string `json:"unitCode,omitempty"`
+
+	// Price is the net price of one unit
+	Price decimal.Decimal `json:"price"`
+
+	Category Category        `json:"category"`
+	Rate     decimal.Decimal `json:"rate"`
+
+	// ExemptionReason is required by the exempt categories
+	ExemptionReason string `json:"exemptionReason,omitempty"`
+
+	AllowanceCharges []AllowanceCharge `json:"allowanceCharges,omitempty"`
+}
+
+// Document are the data of an invoice or credit note before being calculated
+type Document struct {
+	Kind      Kind      `json:"kind"`
+	ID        string    `json:"id"`
+	IssueDate time.Time `json:"issueDate"`
+	DueDate   time.Time `json:"dueDate"`
+
+	// PaymentTerms can be used instead of DueDate
+	PaymentTerms string `json:"paymentTerms,omitempty"`
+
+	// Currency is the ISO 4217 code of the currency
+	Currency       string `json:"currency"`
+	BuyerReference string `json:"buyerReference,omitempty"`
+
+	// PrecedingInvoice is the invoice corrected by a credit note
+	PrecedingInvoice string `json:"precedingInvoice,omitempty"`
+
+	Seller Party `json:"seller"`
+	Buyer  Party `json:"buyer"`
+
+	Items            []Item            `json:"items"`
+	AllowanceCharges []AllowanceCharge `json:"allowanceCharges,omitempty"`
+
+	// Prepaid is the amount already paid
+	Prepaid decimal.Decimal `json:"prepaid"`
+}
+
+// Line is a calculated item
+type Line struct {
+	Item
+
+	// Net is the line net amount, BT-131
+	Net decimal.Decimal `json:"net"`
+
+	// Amounts are the amounts of the allowances and charges of the item, BT-136 and BT-141
+	Amounts []decimal.Decimal `json:"amounts,omitempty"`
+
+	Calc bolson.Bag `json:"calc"`
+}
+
+// Subtotal is the VAT breakdown of a category and rate, BG-23
+type Subtotal struct {
+	Category        Category        `json:"category"`
+	Rate            decimal.Decimal `json:"rate"`
+	Taxable         decimal.Decimal `json:"taxable"`
+	Tax             decimal.Decimal `json:"tax"`
+	ExemptionReason string          `json:"exemptionReason,omitempty"`
+}
+
+// Totals are the document totals, BG-22
+type Totals struct {
+	// LineExtension is the sum of the line net amounts, BT-106
+	LineExtension decimal.Decimal `json:"lineExtension"`
+
+	// Allowances and Charges are the sums of the allowances and charges of the document, BT-107 and BT-108
+	Allowances decimal.Decimal `json:"allowances"`
+	Charges    decimal.Decimal `json:"charges"`
+
+	// TaxExclusive, Tax and TaxInclusive are BT-109, BT-110 and BT-112
+	TaxExclusive decimal.Decimal `json:"taxExclusive"`
+	Tax          decimal.Decimal `json:"tax"`
+	TaxInclusive decimal.Decimal `json:"taxInclusive"`
+
+	Prepaid decimal.Decimal `json:"prepaid"`
+
+	// Payable is the amount due, BT-115
+	Payable decimal.Decimal `json:"payable"`
+}
+
+// Calculated is a document with its lines, VAT breakdown and totals calculated
+type Calculated struct {
+	Document
+
+	Lines []Line `json:"lines"`
+
+	// Amounts are the amounts of the allowances and charges of the document, BT-92 and BT-99
+	Amounts   []decimal.Decimal `json:"amounts,omitempty"`
+	Subtotals []Subtotal        `json:"subtotals"`
+	Totals    Totals            `json:"totals"`
+}
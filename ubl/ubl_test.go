@@ -0,0 +1,263 @@
+package ubl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func invoice() Document {
+	return Document{
+		ID:        "INV-2026-001",
+		IssueDate: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+		DueDate:   time.Date(2026, 11, 17, 0, 0, 0, 0, time.UTC),
+		Currency:  "EUR",
+		Seller:    Party{Name: "Verkäufer GmbH", VATID: "DE123456789", Street: "Hauptstraße 1", City: "Berlin", PostalCode: "10115", Country: "DE"},
+		Buyer:     Party{Name: "Acheteur SARL", VATID: "FR12345678901", City: "Paris", Country: "FR"},
+		Items: []Item{
+			{
+				ID: "1", Name: "Widget", Qty: dec("3"), Price: dec("12.345"), Category: Standard, Rate: dec("21"),
+				AllowanceCharges: []AllowanceCharge{{Reason: "Promotion", Percent: dec("10")}},
+			},
+			{
+				ID: "2", Name: "Service", Qty: dec("2"), Price: dec("50"), Category: Standard, Rate: dec("21"), UnitCode: "HUR",
+				AllowanceCharges: []AllowanceCharge{{Charge: true, Reason: "Delivery", Amount: dec("5")}},
+			},
+			{ID: "3", Name: "Book", Qty: dec("1"), Price: dec("20"), Category: Exempt, ExemptionReason: "Exempt under article 132"},
+		},
+		AllowanceCharges: []AllowanceCharge{
+			{Reason: "Loyalty", Percent: dec("5"), Category: Standard, Rate: dec("21")},
+			{Charge: true, ReasonCode: "ABL", Reason: "Packing", Amount: dec("10"), Category: Standard, Rate: dec("21")},
+		},
+		Prepaid: dec("50"),
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	c, err := Calculate(invoice())
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	// 37.035 minus the 10%, 3.70, is 33.335, rounded to 33.34
+	nets := []string{"33.34", "105", "20"}
+
+	for i, l := range c.Lines {
+		if !l.Net.Equal(dec(nets[i])) {
+			t.Logf("line %d: expected net %s. Got %s", i+1, nets[i], l.Net)
+			t.FailNow()
+		}
+	}
+
+	if len(c.Subtotals) != 2 {
+		t.Logf("expected 2 VAT breakdowns. Got %v", c.Subtotals)
+		t.FailNow()
+	}
+
+	// 138.34 minus 5%, 6.92, plus 10
+	s := c.Subtotals[0]
+
+	if s.Category != Standard || !s.Taxable.Equal(dec("141.42")) || !s.Tax.Equal(dec("29.70")) {
+		t.Logf("unexpected breakdown %+v", s)
+		t.FailNow()
+	}
+
+	if s := c.Subtotals[1]; s.Category != Exempt || !s.Taxable.Equal(dec("20")) || !s.Tax.IsZero() || s.ExemptionReason == "" {
+		t.Logf("unexpected breakdown %+v", s)
+		t.FailNow()
+	}
+
+	tt := c.Totals
+
+	expected := map[string][2]decimal.Decimal{
+		"lineExtension": {tt.LineExtension, dec("158.34")},
+		"allowances":    {tt.Allowances, dec("6.92")},
+		"charges":       {tt.Charges, dec("10")},
+		"taxExclusive":  {tt.TaxExclusive, dec("161.42")},
+		"tax":           {tt.Tax, dec("29.70")},
+		"taxInclusive":  {tt.TaxInclusive, dec("191.12")},
+		"payable":       {tt.Payable, dec("141.12")},
+	}
+
+	for name, v := range expected {
+		if !v[0].Equal(v[1]) {
+			t.Logf("%s: expected %s. Got %s", name, v[1], v[0])
+			t.FailNow()
+		}
+	}
+
+	if err := Validate(c); err != nil {
+		t.Logf("unexpected validation error %v", err)
+		t.FailNow()
+	}
+}
+
+// contains checks the elements are in s in order
+func contains(t *testing.T, s string, elements ...string) {
+	pos := 0
+
+	for _, e := range elements {
+		i := strings.Index(s[pos:], e)
+
+		if i < 0 {
+			t.Logf("expected %s after position %d in %s", e, pos, s)
+			t.FailNow()
+		}
+
+		pos += i + len(e)
+	}
+}
+
+func TestXML(t *testing.T) {
+	c, _ := Calculate(invoice())
+
+	out, err := c.XML()
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	contains(t, string(out),
+		`<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"`,
+		`<cbc:CustomizationID>urn:cen.eu:en16931:2017</cbc:CustomizationID>`,
+		`<cbc:ID>INV-2026-001</cbc:ID>`, `<cbc:IssueDate>2026-10-18</cbc:IssueDate>`, `<cbc:DueDate>2026-11-17</cbc:DueDate>`,
+		`<cbc:InvoiceTypeCode>380</cbc:InvoiceTypeCode>`, `<cbc:DocumentCurrencyCode>EUR</cbc:DocumentCurrencyCode>`,
+		`<cac:AccountingSupplierParty>`, `<cac:Party>`, `<cac:PostalAddress>`, `<cbc:IdentificationCode>DE</cbc:IdentificationCode>`,
+		`<cac:PartyTaxScheme>`, `<cbc:CompanyID>DE123456789</cbc:CompanyID>`, `<cbc:RegistrationName>Verkäufer GmbH</cbc:RegistrationName>`,
+		`<cac:AccountingCustomerParty>`,
+		`<cac:AllowanceCharge>`, `<cbc:ChargeIndicator>false</cbc:ChargeIndicator>`, `<cbc:AllowanceChargeReason>Loyalty</cbc:AllowanceChargeReason>`,
+		`<cbc:MultiplierFactorNumeric>5</cbc:MultiplierFactorNumeric>`, `<cbc:Amount currencyID="EUR">6.92</cbc:Amount>`,
+		`<cbc:BaseAmount currencyID="EUR">138.34</cbc:BaseAmount>`, `<cac:TaxCategory>`, `<cbc:ID>S</cbc:ID>`, `<cbc:Percent>21</cbc:Percent>`,
+		`<cbc:ChargeIndicator>true</cbc:ChargeIndicator>`, `<cbc:AllowanceChargeReasonCode>ABL</cbc:AllowanceChargeReasonCode>`,
+		`<cac:TaxTotal>`, `<cbc:TaxAmount currencyID="EUR">29.70</cbc:TaxAmount>`,
+		`<cac:TaxSubtotal>`, `<cbc:TaxableAmount currencyID="EUR">141.42</cbc:TaxableAmount>`,
+		`<cbc:TaxableAmount currencyID="EUR">20.00</cbc:TaxableAmount>`, `<cbc:ID>E</cbc:ID>`, `<cbc:Percent>0</cbc:Percent>`,
+		`<cbc:TaxExemptionReason>Exempt under article 132</cbc:TaxExemptionReason>`,
+		`<cac:LegalMonetaryTotal>`, `<cbc:LineExtensionAmount currencyID="EUR">158.34</cbc:LineExtensionAmount>`,
+		`<cbc:TaxExclusiveAmount currencyID="EUR">161.42</cbc:TaxExclusiveAmount>`, `<cbc:TaxInclusiveAmount currencyID="EUR">191.12</cbc:TaxInclusiveAmount>`,
+		`<cbc:AllowanceTotalAmount currencyID="EUR">6.92</cbc:AllowanceTotalAmount>`, `<cbc:ChargeTotalAmount currencyID="EUR">10.00</cbc:ChargeTotalAmount>`,
+		`<cbc:PrepaidAmount currencyID="EUR">50.00</cbc:PrepaidAmount>`, `<cbc:PayableAmount currencyID="EUR">141.12</cbc:PayableAmount>`,
+		`<cac:InvoiceLine>`, `<cbc:ID>1</cbc:ID>`, `<cbc:InvoicedQuantity unitCode="C62">3</cbc:InvoicedQuantity>`,
+		`<cbc:LineExtensionAmount currencyID="EUR">33.34</cbc:LineExtensionAmount>`, `<cbc:Amount currencyID="EUR">3.70</cbc:Amount>`,
+		`<cbc:BaseAmount currencyID="EUR">37.04</cbc:BaseAmount>`, `<cac:Item>`, `<cbc:Name>Widget</cbc:Name>`, `<cac:ClassifiedTaxCategory>`,
+		`<cac:Price>`, `<cbc:PriceAmount currencyID="EUR">12.345</cbc:PriceAmount>`,
+		`<cbc:InvoicedQuantity unitCode="HUR">2</cbc:InvoicedQuantity>`,
+		`</Invoice>`,
+	)
+}
+
+func TestCreditNote(t *testing.T) {
+	d := invoice()
+	d.Kind = CreditNote
+	d.PrecedingInvoice = "INV-2026-001"
+
+	c, err := Calculate(d)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	out, _ := c.XML()
+
+	contains(t, string(out),
+		`<CreditNote xmlns="urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"`,
+		`<cbc:CreditNoteTypeCode>381</cbc:CreditNoteTypeCode>`,
+		`<cac:BillingReference>`, `<cac:InvoiceDocumentReference>`, `<cbc:ID>INV-2026-001</cbc:ID>`,
+		`<cac:CreditNoteLine>`, `<cbc:CreditedQuantity unitCode="C62">3</cbc:CreditedQuantity>`,
+		`</CreditNote>`,
+	)
+
+	for _, e := range []string{"DueDate", "InvoiceTypeCode", "InvoiceLine", "InvoicedQuantity"} {
+		if strings.Contains(string(out), "<cbc:"+e) || strings.Contains(string(out), "<cac:"+e) {
+			t.Logf("unexpected %s in a credit note %s", e, out)
+			t.FailNow()
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	d := invoice()
+	d.DueDate = time.Time{}
+	d.Seller.VATID = ""
+	d.Items[0].AllowanceCharges[0].Reason = ""
+	d.Items[2].ExemptionReason = ""
+	d.Items[2].Rate = dec("7")
+
+	c, err := Calculate(d)
+
+	if err != nil {
+		t.Logf("unexpected error %v", err)
+		t.FailNow()
+	}
+
+	c.Totals.Tax = c.Totals.Tax.Add(dec("0.01"))
+
+	err = Validate(c)
+
+	var ve *ValidationError
+
+	if !errors.As(err, &ve) {
+		t.Logf("expected a validation error. Got %v", err)
+		t.FailNow()
+	}
+
+	expected := []string{
+		"[BR-CO-25] document BT-9",
+		"[BR-42] line 1 BT-139",
+		"[BR-E-05] line 3 BT-152",
+		"[BR-S-02] document BT-31",
+		"[BR-E-10] document BT-120",
+		"[BR-E-09] document BT-117",
+		"[BR-CO-14] document BT-110",
+		"[BR-CO-15] document BT-112",
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(err.Error(), e) {
+			t.Logf("expected %s in %v", e, err)
+			t.FailNow()
+		}
+	}
+
+	if len(ve.Violations) != len(expected) {
+		t.Logf("expected %d violations. Got %v", len(expected), ve.Violations)
+		t.FailNow()
+	}
+}
+
+func TestCalculateInvalid(t *testing.T) {
+	d := invoice()
+	d.Kind = Kind(7)
+
+	if _, err := Calculate(d); err == nil || !strings.Contains(err.Error(), "ErrInvalidKind") {
+		t.Logf("expected ErrInvalidKind. Got %v", err)
+		t.FailNow()
+	}
+
+	d = invoice()
+	d.Items[1].AllowanceCharges[0].Amount = dec("-5")
+
+	if _, err := Calculate(d); err == nil || !strings.Contains(err.Error(), "ErrInvalidItem") {
+		t.Logf("expected ErrInvalidItem. Got %v", err)
+		t.FailNow()
+	}
+
+	d = invoice()
+	d.AllowanceCharges[0].Percent = dec("-5")
+
+	if _, err := Calculate(d); err == nil || !strings.Contains(err.Error(), "ErrInvalidAllowanceCharge") {
+		t.Logf("expected ErrInvalidAllowanceCharge. Got %v", err)
+		t.FailNow()
+	}
+}
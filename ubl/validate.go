@@ -0,0 +1,289 @@
+package ubl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/profe-ajedrez/bolson/numbers"
+	"github.com/shopspring/decimal"
+)
+
+// DocumentLine is the line of the violations of the document itself instead of one of its lines
+const DocumentLine = 0
+
+// Violation is a business rule of EN 16931 not satisfied by a document
+type Violation struct {
+	// Line is the number of the line starting at 1, or [DocumentLine]
+	Line int `json:"line"`
+
+	// Rule is the identifier of the rule in EN 16931, as BR-CO-10
+	Rule string `json:"rule"`
+
+	// Field is the offending business term, as BT-106
+	Field string `json:"field"`
+
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Line == DocumentLine {
+		return fmt.Sprintf("[%s] document %s: %s", v.Rule, v.Field, v.Message)
+	}
+
+	return fmt.Sprintf("[%s] line %d %s: %s", v.Rule, v.Line, v.Field, v.Message)
+}
+
+// ValidationError reports every violation found by [Validate]
+type ValidationError struct {
+	Violations []Violation `json:"violations"`
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+
+	return fmt.Sprintf("[ErrValidation] %d violations found. %s", len(e.Violations), strings.Join(msgs, "; "))
+}
+
+// rules are the prefixes of the rules of each category
+var rules = map[Category]string{
+	Standard:       "BR-S",
+	ZeroRated:      "BR-Z",
+	Exempt:         "BR-E",
+	ReverseCharge:  "BR-AE",
+	IntraCommunity: "BR-IC",
+	Export:         "BR-G",
+	OutOfScope:     "BR-O",
+}
+
+type validator struct {
+	violations []Violation
+}
+
+func (v *validator) add(line int, rule string, field string, msg string) {
+	v.violations = append(v.violations, Violation{Line: line, Rule: rule, Field: field, Message: msg})
+}
+
+func (v *validator) required(line int, rule string, field string, s string) {
+	if strings.TrimSpace(s) == "" {
+		v.add(line, rule, field, "is required")
+	}
+}
+
+// equal checks a total against the value the rule expects
+func (v *validator) equal(rule string, field string, got decimal.Decimal, expected decimal.Decimal, msg string) {
+	if !got.Equal(expected) {
+		v.add(DocumentLine, rule, field, fmt.Sprintf("%s must be %s, %s", got.StringFixed(scale), expected.StringFixed(scale), msg))
+	}
+}
+
+// decimals checks an amount has at most 2 decimals
+func (v *validator) decimals(line int, field string, d decimal.Decimal) {
+	if !d.Equal(d.Round(scale)) {
+		v.add(line, "BR-DEC", field, fmt.Sprintf("%s has more than %d decimals", d, scale))
+	}
+}
+
+// Validate checks the business rules of EN 16931 over c, the required terms, the rules of the VAT
+// categories and the rules over the totals, reporting every violation found in a [*ValidationError]
+func Validate(c *Calculated) error {
+	v := &validator{}
+
+	v.required(DocumentLine, "BR-02", "BT-1", c.ID)
+
+	if c.IssueDate.IsZero() {
+		v.add(DocumentLine, "BR-03", "BT-2", "is required")
+	}
+
+	if len(c.Currency) != 3 {
+		v.add(DocumentLine, "BR-05", "BT-5", "must be an ISO 4217 code")
+	}
+
+	v.required(DocumentLine, "BR-06", "BT-27", c.Seller.Name)
+	v.required(DocumentLine, "BR-07", "BT-44", c.Buyer.Name)
+	v.required(DocumentLine, "BR-09", "BT-40", c.Seller.Country)
+	v.required(DocumentLine, "BR-11", "BT-55", c.Buyer.Country)
+
+	if len(c.Lines) == 0 {
+		v.add(DocumentLine, "BR-16", "BG-25", "must have at least one line")
+	}
+
+	if c.Kind == Invoice && c.Totals.Payable.IsPositive() && c.DueDate.IsZero() && c.PaymentTerms == "" {
+		v.add(DocumentLine, "BR-CO-25", "BT-9", "a due date or payment terms are required when the amount due is positive")
+	}
+
+	v.lines(c)
+	v.allowanceCharges(c)
+	v.categories(c)
+	v.totals(c)
+
+	if len(v.violations) > 0 {
+		return &ValidationError{Violations: v.violations}
+	}
+
+	return nil
+}
+
+func (v *validator) lines(c *Calculated) {
+	for i, l := range c.Lines {
+		n := i + 1
+
+		v.required(n, "BR-21", "BT-126", l.ID)
+		v.required(n, "BR-25", "BT-153", l.Name)
+
+		if l.Qty.IsZero() {
+			v.add(n, "BR-22", "BT-129", "is required")
+		}
+
+		if l.Price.IsNegative() {
+			v.add(n, "BR-27", "BT-146", "cannot be negative")
+		}
+
+		if !l.Category.valid() {
+			v.add(n, "BR-CO-04", "BT-151", fmt.Sprintf("%q is not a VAT category", l.Category))
+		}
+
+		v.decimals(n, "BT-131", l.Net)
+
+		for j, a := range l.AllowanceCharges {
+			rule, field := "BR-42", "BT-139"
+
+			if a.Charge {
+				rule, field = "BR-44", "BT-144"
+			}
+
+			if a.Reason == "" && a.ReasonCode == "" {
+				v.add(n, rule, field, "an allowance or charge needs a reason or a reason code")
+			}
+
+			v.decimals(n, "BT-136", l.Amounts[j])
+		}
+	}
+}
+
+func (v *validator) allowanceCharges(c *Calculated) {
+	for i, a := range c.AllowanceCharges {
+		reason, category := "BR-33", "BR-32"
+
+		if a.Charge {
+			reason, category = "BR-38", "BR-37"
+		}
+
+		if a.Reason == "" && a.ReasonCode == "" {
+			v.add(DocumentLine, reason, "BT-97", fmt.Sprintf("the allowance or charge %d needs a reason or a reason code", i+1))
+		}
+
+		if !a.Category.valid() {
+			v.add(DocumentLine, category, "BT-95", fmt.Sprintf("the allowance or charge %d needs a VAT category", i+1))
+		}
+
+		v.decimals(DocumentLine, "BT-92", c.Amounts[i])
+	}
+}
+
+// categories checks the rules of each VAT category over the lines, the parties and the breakdown
+func (v *validator) categories(c *Calculated) {
+	for i, l := range c.Lines {
+		prefix, ok := rules[l.Category]
+
+		if !ok {
+			continue
+		}
+
+		if l.Category == Standard && !l.Rate.IsPositive() {
+			v.add(i+1, prefix+"-05", "BT-152", "the standard rate must be greater than zero")
+		}
+
+		if l.Category != Standard && !l.Rate.IsZero() {
+			v.add(i+1, prefix+"-05", "BT-152", "the rate of the category must be zero")
+		}
+	}
+
+	if len(c.Subtotals) == 0 {
+		v.add(DocumentLine, "BR-CO-18", "BG-23", "must have at least one VAT breakdown")
+	}
+
+	for _, s := range c.Subtotals {
+		prefix, ok := rules[s.Category]
+
+		if !ok {
+			continue
+		}
+
+		switch s.Category {
+		case Standard, ZeroRated:
+			if c.Seller.VATID == "" {
+				v.add(DocumentLine, prefix+"-02", "BT-31", "the seller VAT identifier is required")
+			}
+		case ReverseCharge, IntraCommunity:
+			if c.Seller.VATID == "" || c.Buyer.VATID == "" {
+				v.add(DocumentLine, prefix+"-02", "BT-48", "the seller and buyer VAT identifiers are required")
+			}
+		}
+
+		if s.Category.exempt() && s.ExemptionReason == "" {
+			v.add(DocumentLine, prefix+"-10", "BT-120", fmt.Sprintf("the VAT breakdown %s needs an exemption reason", s.Category))
+		}
+
+		if s.Category.exempt() && !s.Tax.IsZero() {
+			v.add(DocumentLine, prefix+"-09", "BT-117", "the VAT amount of the category must be zero")
+		}
+
+		// the taxable amount of the category is the sum of its lines plus its charges minus its allowances
+		expected := decimal.Zero
+
+		for _, l := range c.Lines {
+			if l.Category == s.Category && l.Rate.Equal(s.Rate) {
+				expected = expected.Add(l.Net)
+			}
+		}
+
+		for i, a := range c.AllowanceCharges {
+			if a.Category == s.Category && a.Rate.Equal(s.Rate) {
+				if a.Charge {
+					expected = expected.Add(c.Amounts[i])
+				} else {
+					expected = expected.Sub(c.Amounts[i])
+				}
+			}
+		}
+
+		v.equal(prefix+"-08", "BT-116", s.Taxable, expected, "the lines plus charges minus allowances of the category")
+		v.equal("BR-CO-17", "BT-117", s.Tax, s.Taxable.Mul(s.Rate).Div(numbers.Hundred).Round(scale), "the taxable amount by the rate")
+	}
+}
+
+func (v *validator) totals(c *Calculated) {
+	t := c.Totals
+
+	lines, allowances, charges, tax := decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero
+
+	for _, l := range c.Lines {
+		lines = lines.Add(l.Net)
+	}
+
+	for i, a := range c.AllowanceCharges {
+		if a.Charge {
+			charges = charges.Add(c.Amounts[i])
+		} else {
+			allowances = allowances.Add(c.Amounts[i])
+		}
+	}
+
+	for _, s := range c.Subtotals {
+		tax = tax.Add(s.Tax)
+	}
+
+	v.equal("BR-CO-10", "BT-106", t.LineExtension, lines, "the sum of the line net amounts")
+	v.equal("BR-CO-11", "BT-107", t.Allowances, allowances, "the sum of the allowances")
+	v.equal("BR-CO-12", "BT-108", t.Charges, charges, "the sum of the charges")
+	v.equal("BR-CO-13", "BT-109", t.TaxExclusive, t.LineExtension.Sub(t.Allowances).Add(t.Charges), "the lines minus allowances plus charges")
+	v.equal("BR-CO-14", "BT-110", t.Tax, tax, "the sum of the VAT breakdown")
+	v.equal("BR-CO-15", "BT-112", t.TaxInclusive, t.TaxExclusive.Add(t.Tax), "the amount without VAT plus the VAT")
+	v.equal("BR-CO-16", "BT-115", t.Payable, t.TaxInclusive.Sub(t.Prepaid), "the amount with VAT minus the paid amount")
+
+	v.decimals(DocumentLine, "BT-113", c.Prepaid)
+}
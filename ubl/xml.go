@@ -0,0 +1,302 @@
+package ubl
+
+import (
+	"encoding/xml"
+
+	"github.com/shopspring/decimal"
+)
+
+// namespaces of UBL 2.1
+const (
+	NamespaceInvoice    = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	NamespaceCreditNote = "urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"
+	NamespaceCAC        = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	NamespaceCBC        = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+)
+
+// CustomizationID identifies the documents as following EN 16931
+const CustomizationID = "urn:cen.eu:en16931:2017"
+
+const dateLayout = "2006-01-02"
+
+// xmlDocument is the Invoice or the CreditNote, the elements which only one of them has are omitted when empty
+type xmlDocument struct {
+	XMLName              xml.Name              `xml:""`
+	Xmlns                string                `xml:"xmlns,attr"`
+	XmlnsCAC             string                `xml:"xmlns:cac,attr"`
+	XmlnsCBC             string                `xml:"xmlns:cbc,attr"`
+	CustomizationID      string                `xml:"cbc:CustomizationID"`
+	ID                   string                `xml:"cbc:ID"`
+	IssueDate            string                `xml:"cbc:IssueDate"`
+	DueDate              string                `xml:"cbc:DueDate,omitempty"`
+	InvoiceTypeCode      string                `xml:"cbc:InvoiceTypeCode,omitempty"`
+	CreditNoteTypeCode   string                `xml:"cbc:CreditNoteTypeCode,omitempty"`
+	DocumentCurrencyCode string                `xml:"cbc:DocumentCurrencyCode"`
+	BuyerReference       string                `xml:"cbc:BuyerReference,omitempty"`
+	BillingReference     *xmlBillingReference  `xml:"cac:BillingReference,omitempty"`
+	Supplier             xmlParty              `xml:"cac:AccountingSupplierParty>cac:Party"`
+	Customer             xmlParty              `xml:"cac:AccountingCustomerParty>cac:Party"`
+	PaymentTerms         string                `xml:"cac:PaymentTerms>cbc:Note,omitempty"`
+	AllowanceCharges     []xmlAllowanceCharge  `xml:"cac:AllowanceCharge"`
+	TaxTotal             xmlTaxTotal           `xml:"cac:TaxTotal"`
+	LegalMonetaryTotal   xmlLegalMonetaryTotal `xml:"cac:LegalMonetaryTotal"`
+	InvoiceLines         []xmlLine             `xml:"cac:InvoiceLine"`
+	CreditNoteLines      []xmlLine             `xml:"cac:CreditNoteLine"`
+}
+
+type xmlBillingReference struct {
+	ID string `xml:"cac:InvoiceDocumentReference>cbc:ID"`
+}
+
+type xmlParty struct {
+	PostalAddress xmlAddress      `xml:"cac:PostalAddress"`
+	TaxScheme     *xmlPartyScheme `xml:"cac:PartyTaxScheme,omitempty"`
+	Name          string          `xml:"cac:PartyLegalEntity>cbc:RegistrationName"`
+}
+
+type xmlAddress struct {
+	Street     string `xml:"cbc:StreetName,omitempty"`
+	City       string `xml:"cbc:CityName,omitempty"`
+	PostalCode string `xml:"cbc:PostalZone,omitempty"`
+	Country    string `xml:"cac:Country>cbc:IdentificationCode"`
+}
+
+type xmlPartyScheme struct {
+	CompanyID string `xml:"cbc:CompanyID"`
+	TaxScheme string `xml:"cac:TaxScheme>cbc:ID"`
+}
+
+type xmlAmount struct {
+	Currency string `xml:"currencyID,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type xmlQuantity struct {
+	UnitCode string `xml:"unitCode,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type xmlCategory struct {
+	ID              string `xml:"cbc:ID"`
+	Percent         string `xml:"cbc:Percent,omitempty"`
+	ExemptionReason string `xml:"cbc:TaxExemptionReason,omitempty"`
+	TaxScheme       string `xml:"cac:TaxScheme>cbc:ID"`
+}
+
+type xmlAllowanceCharge struct {
+	ChargeIndicator bool         `xml:"cbc:ChargeIndicator"`
+	ReasonCode      string       `xml:"cbc:AllowanceChargeReasonCode,omitempty"`
+	Reason          string       `xml:"cbc:AllowanceChargeReason,omitempty"`
+	Multiplier      string       `xml:"cbc:MultiplierFactorNumeric,omitempty"`
+	Amount          xmlAmount    `xml:"cbc:Amount"`
+	BaseAmount      *xmlAmount   `xml:"cbc:BaseAmount,omitempty"`
+	TaxCategory     *xmlCategory `xml:"cac:TaxCategory,omitempty"`
+}
+
+type xmlTaxTotal struct {
+	TaxAmount xmlAmount        `xml:"cbc:TaxAmount"`
+	Subtotals []xmlTaxSubtotal `xml:"cac:TaxSubtotal"`
+}
+
+type xmlTaxSubtotal struct {
+	TaxableAmount xmlAmount   `xml:"cbc:TaxableAmount"`
+	TaxAmount     xmlAmount   `xml:"cbc:TaxAmount"`
+	Category      xmlCategory `xml:"cac:TaxCategory"`
+}
+
+type xmlLegalMonetaryTotal struct {
+	LineExtensionAmount  xmlAmount  `xml:"cbc:LineExtensionAmount"`
+	TaxExclusiveAmount   xmlAmount  `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount   xmlAmount  `xml:"cbc:TaxInclusiveAmount"`
+	AllowanceTotalAmount *xmlAmount `xml:"cbc:AllowanceTotalAmount,omitempty"`
+	ChargeTotalAmount    *xmlAmount `xml:"cbc:ChargeTotalAmount,omitempty"`
+	PrepaidAmount        *xmlAmount `xml:"cbc:PrepaidAmount,omitempty"`
+	PayableAmount        xmlAmount  `xml:"cbc:PayableAmount"`
+}
+
+type xmlLine struct {
+	ID                  string               `xml:"cbc:ID"`
+	InvoicedQuantity    *xmlQuantity         `xml:"cbc:InvoicedQuantity,omitempty"`
+	CreditedQuantity    *xmlQuantity         `xml:"cbc:CreditedQuantity,omitempty"`
+	LineExtensionAmount xmlAmount            `xml:"cbc:LineExtensionAmount"`
+	AllowanceCharges    []xmlAllowanceCharge `xml:"cac:AllowanceCharge"`
+	Name                string               `xml:"cac:Item>cbc:Name"`
+	Category            xmlCategory          `xml:"cac:Item>cac:ClassifiedTaxCategory"`
+	Price               xmlAmount            `xml:"cac:Price>cbc:PriceAmount"`
+}
+
+// XML returns the Invoice or CreditNote element of c
+func (c *Calculated) XML() ([]byte, error) {
+	out, err := xml.MarshalIndent(c.document(), "", "  ")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), out...), nil
+}
+
+func (c *Calculated) money(d decimal.Decimal) xmlAmount {
+	return xmlAmount{Currency: c.Currency, Value: d.StringFixed(scale)}
+}
+
+// optional returns the amount d, or nil when it is zero
+func (c *Calculated) optional(d decimal.Decimal) *xmlAmount {
+	if d.IsZero() {
+		return nil
+	}
+
+	m := c.money(d)
+
+	return &m
+}
+
+func category(id Category, rate decimal.Decimal, reason string) xmlCategory {
+	x := xmlCategory{ID: string(id), TaxScheme: "VAT", ExemptionReason: reason}
+
+	// the percent is not given for the documents out of scope of VAT
+	if id != OutOfScope {
+		x.Percent = rate.String()
+	}
+
+	return x
+}
+
+func (c *Calculated) allowanceCharge(a AllowanceCharge, amount decimal.Decimal, base decimal.Decimal) xmlAllowanceCharge {
+	x := xmlAllowanceCharge{ChargeIndicator: a.Charge, ReasonCode: a.ReasonCode, Reason: a.Reason, Amount: c.money(amount)}
+
+	if a.Percent.IsPositive() {
+		if a.Base.IsPositive() {
+			base = a.Base
+		}
+
+		m := c.money(base)
+		x.Multiplier, x.BaseAmount = a.Percent.String(), &m
+	}
+
+	return x
+}
+
+func (c *Calculated) document() xmlDocument {
+	t := c.Totals
+
+	x := xmlDocument{
+		XMLName:              xml.Name{Local: "Invoice"},
+		Xmlns:                NamespaceInvoice,
+		XmlnsCAC:             NamespaceCAC,
+		XmlnsCBC:             NamespaceCBC,
+		CustomizationID:      CustomizationID,
+		ID:                   c.ID,
+		IssueDate:            c.IssueDate.Format(dateLayout),
+		InvoiceTypeCode:      "380",
+		DocumentCurrencyCode: c.Currency,
+		BuyerReference:       c.BuyerReference,
+		Supplier:             party(c.Seller),
+		Customer:             party(c.Buyer),
+		PaymentTerms:         c.PaymentTerms,
+		TaxTotal:             xmlTaxTotal{TaxAmount: c.money(t.Tax)},
+		LegalMonetaryTotal: xmlLegalMonetaryTotal{
+			LineExtensionAmount:  c.money(t.LineExtension),
+			TaxExclusiveAmount:   c.money(t.TaxExclusive),
+			TaxInclusiveAmount:   c.money(t.TaxInclusive),
+			AllowanceTotalAmount: c.optional(t.Allowances),
+			ChargeTotalAmount:    c.optional(t.Charges),
+			PrepaidAmount:        c.optional(t.Prepaid),
+			PayableAmount:        c.money(t.Payable),
+		},
+	}
+
+	if !c.DueDate.IsZero() {
+		x.DueDate = c.DueDate.Format(dateLayout)
+	}
+
+	if c.Kind == CreditNote {
+		x.XMLName.Local, x.Xmlns = "CreditNote", NamespaceCreditNote
+		x.InvoiceTypeCode, x.CreditNoteTypeCode = "", "381"
+
+		// the credit notes of UBL 2.1 have no due date
+		x.DueDate = ""
+	}
+
+	if c.PrecedingInvoice != "" {
+		x.BillingReference = &xmlBillingReference{ID: c.PrecedingInvoice}
+	}
+
+	for i, a := range c.AllowanceCharges {
+		ac := c.allowanceCharge(a, c.Amounts[i], c.subtotalLines(a.Category, a.Rate))
+		cat := category(a.Category, a.Rate, "")
+		ac.TaxCategory = &cat
+
+		x.AllowanceCharges = append(x.AllowanceCharges, ac)
+	}
+
+	for _, s := range c.Subtotals {
+		x.TaxTotal.Subtotals = append(x.TaxTotal.Subtotals, xmlTaxSubtotal{
+			TaxableAmount: c.money(s.Taxable),
+			TaxAmount:     c.money(s.Tax),
+			Category:      category(s.Category, s.Rate, s.ExemptionReason),
+		})
+	}
+
+	for _, l := range c.Lines {
+		xl := xmlLine{
+			ID:                  l.ID,
+			LineExtensionAmount: c.money(l.Net),
+			Name:                l.Name,
+			Category:            category(l.Category, l.Rate, ""),
+			Price:               xmlAmount{Currency: c.Currency, Value: l.Price.String()},
+		}
+
+		unit := l.UnitCode
+
+		if unit == "" {
+			unit = "C62"
+		}
+
+		qty := &xmlQuantity{UnitCode: unit, Value: l.Qty.String()}
+
+		if c.Kind == CreditNote {
+			xl.CreditedQuantity = qty
+		} else {
+			xl.InvoicedQuantity = qty
+		}
+
+		for i, a := range l.AllowanceCharges {
+			xl.AllowanceCharges = append(xl.AllowanceCharges, c.allowanceCharge(a, l.Amounts[i], l.Qty.Mul(l.Price)))
+		}
+
+		if c.Kind == CreditNote {
+			x.CreditNoteLines = append(x.CreditNoteLines, xl)
+		} else {
+			x.InvoiceLines = append(x.InvoiceLines, xl)
+		}
+	}
+
+	return x
+}
+
+// subtotalLines returns the sum of the lines of the category and rate
+func (c *Calculated) subtotalLines(id Category, rate decimal.Decimal) decimal.Decimal {
+	sum := decimal.Zero
+
+	for _, l := range c.Lines {
+		if l.Category == id && l.Rate.Equal(rate) {
+			sum = sum.Add(l.Net)
+		}
+	}
+
+	return sum
+}
+
+func party(p Party) xmlParty {
+	x := xmlParty{
+		PostalAddress: xmlAddress{Street: p.Street, City: p.City, PostalCode: p.PostalCode, Country: p.Country},
+		Name:          p.Name,
+	}
+
+	if p.VATID != "" {
+		x.TaxScheme = &xmlPartyScheme{CompanyID: p.VATID, TaxScheme: "VAT"}
+	}
+
+	return x
+}
@@ -0,0 +1,194 @@
+package bolson
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/numbers"
+)
+
+// ConfigurationLine is the line index used by the violations of the configuration itself
+const ConfigurationLine = -1
+
+// Codes of the violations found by [Bolson.Validate]. The violations found calculating the planned
+// lines have the codes of the errors returned, see [ErrorCode]
+const (
+	CodeDiscountOverHundred = Code("DiscountOverHundred")
+	CodeMissingRateProvider = Code("MissingRateProvider")
+	CodeUnknownRate         = Code("UnknownRate")
+	CodeDuplicateTaxCode    = Code("DuplicateTaxCode")
+	CodeZeroQuantity        = Code("ZeroQuantity")
+	CodeNegativeQuantity    = Code("NegativeQuantity")
+	CodeNegativeValue       = Code("NegativeValue")
+	CodeInvalidMaxDiscount  = Code("InvalidMaxDiscount")
+	CodeImpossibleInversion = Code("ImpossibleInversion")
+	CodeCalculationFailed   = Code("CalculationFailed")
+)
+
+// Violation is a problem found validating the configuration of [Bolson] or a planned line
+type Violation struct {
+	// Line is the index of the planned line, or [ConfigurationLine]
+	Line int `json:"line"`
+
+	// Code identifies the kind of the problem
+	Code Code `json:"code"`
+
+	// Field is the offending field
+	Field string `json:"field"`
+
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Line == ConfigurationLine {
+		return fmt.Sprintf("[%s] configuration %s: %s", v.Code, v.Field, v.Message)
+	}
+
+	return fmt.Sprintf("[%s] line %d %s: %s", v.Code, v.Line, v.Field, v.Message)
+}
+
+// ValidationError reports every violation found by [Bolson.Validate]
+type ValidationError struct {
+	Violations []Violation `json:"violations"`
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+
+	return fmt.Sprintf("[%s] %d violations found. %s", CodeValidation, len(e.Violations), strings.Join(msgs, "; "))
+}
+
+// Validate checks the configuration of b and the planned lines, returning a [*ValidationError]
+// with every problem found, or nil if there are none.
+//
+// Planned lines are calculated when they pass the static checks, so problems like discounts over
+// the max discount are reported too
+func (b Bolson) Validate(lines ...Line) error {
+	violations := b.validateConfiguration()
+
+	// lines cannot be calculated when the indexed amounts cannot be converted
+	calculable := true
+
+	for _, v := range violations {
+		if v.Code == CodeMissingRateProvider || v.Code == CodeUnknownRate {
+			calculable = false
+		}
+	}
+
+	for i, l := range lines {
+		violations = append(violations, b.validateLine(i, l, calculable)...)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Violations: violations}
+}
+
+func (b Bolson) validateConfiguration() []Violation {
+	var violations []Violation
+
+	add := func(code Code, field string, msg string) {
+		violations = append(violations, Violation{Line: ConfigurationLine, Code: code, Field: field, Message: msg})
+	}
+
+	if b.discountHandler.Percent().GreaterThan(numbers.Hundred) {
+		add(CodeDiscountOverHundred, "discount.percent", fmt.Sprintf("the percentual discounts sum %v%%, so the discounted values are negative", b.discountHandler.Percent()))
+	}
+
+	if len(b.settings.indexedTaxes) > 0 || len(b.settings.indexedDiscounts) > 0 {
+		if b.settings.rateProvider == nil {
+			add(CodeMissingRateProvider, "rateProvider", "there are amounts in indexed units but no rate provider to convert them")
+		} else if _, err := b.clone().registerIndexed(); err != nil {
+			add(CodeUnknownRate, "date", err.Error())
+		}
+	}
+
+	// the details of the coded taxes are reported by code, so each code must be registered once.
+	// Conditional taxes may share a code between them, as the tiers of a tax, but not with the others
+	codes := map[string]bool{}
+
+	for _, r := range b.settings.coded {
+		if codes[r.Code] {
+			add(CodeDuplicateTaxCode, "code", fmt.Sprintf("the coded tax %s is registered more than once", r.Code))
+		}
+
+		codes[r.Code] = true
+	}
+
+	for _, t := range b.settings.indexedTaxes {
+		if t.code == "" {
+			continue
+		}
+
+		if codes[t.code] {
+			add(CodeDuplicateTaxCode, "code", fmt.Sprintf("the coded tax %s is registered more than once", t.code))
+		}
+
+		codes[t.code] = true
+	}
+
+	for _, r := range b.settings.rules {
+		if codes[r.Code] {
+			add(CodeDuplicateTaxCode, "code", fmt.Sprintf("the conditional tax %s has the code of a coded tax", r.Code))
+		}
+	}
+
+	return violations
+}
+
+func (b Bolson) validateLine(i int, l Line, calculable bool) []Violation {
+	var violations []Violation
+
+	add := func(code Code, field string, msg string) {
+		violations = append(violations, Violation{Line: i, Code: code, Field: field, Message: msg})
+	}
+
+	if l.Qty.IsZero() {
+		add(CodeZeroQuantity, "qty", "a zero quantity leads to divisions by zero")
+	} else if l.Qty.IsNegative() {
+		add(CodeNegativeQuantity, "qty", fmt.Sprintf("the quantity %v is negative", l.Qty))
+	}
+
+	if l.Value.IsNegative() {
+		add(CodeNegativeValue, "value", fmt.Sprintf("the value %v is negative", l.Value))
+	}
+
+	if l.MaxDiscount.IsNegative() || l.MaxDiscount.GreaterThan(numbers.Hundred) {
+		add(CodeInvalidMaxDiscount, "maxDiscount", fmt.Sprintf("the max discount %v is out of [0, 100] and will be taken as 100", l.MaxDiscount))
+	} else if b.discountHandler.Percent().GreaterThan(l.MaxDiscount) {
+		add(Code(discount.CodeOverMaxDiscount), "maxDiscount", fmt.Sprintf("the percentual discounts sum %v%%, over the max discount %v%%", b.discountHandler.Percent(), l.MaxDiscount))
+	}
+
+	switch l.From {
+	case FromUnitValue, FromBruteWD:
+	case FromBrute:
+		if b.discountHandler.Percent().GreaterThanOrEqual(numbers.Hundred) {
+			add(CodeImpossibleInversion, "from", "a percentual discount of 100% or more discards the brute, so the unit value cannot be obtained from it")
+		}
+	default:
+		add(CodeInvalidOrigin, "from", fmt.Sprintf("the origin %v doesnt exists", l.From))
+	}
+
+	if len(violations) > 0 || !calculable {
+		return violations
+	}
+
+	if _, err := b.clone().CalculateLine(l); err != nil {
+		code, field := ErrorCode(err)
+
+		if code == "" {
+			code = string(CodeCalculationFailed)
+		}
+
+		add(Code(code), field, err.Error())
+	}
+
+	return violations
+}
@@ -0,0 +1,114 @@
+package bolson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/profe-ajedrez/bolson/discount"
+	"github.com/profe-ajedrez/bolson/index"
+	"github.com/profe-ajedrez/bolson/tax"
+	"github.com/shopspring/decimal"
+)
+
+func TestValidate(t *testing.T) {
+	b := New()
+
+	_ = b.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable)
+
+	hundred := decimal.NewFromInt(100)
+
+	if err := b.Validate(Line{Value: hundred, Qty: decimal.NewFromInt(1), MaxDiscount: hundred}); err != nil {
+		t.Logf("expected a valid configuration, got %v", err)
+		t.FailNow()
+	}
+
+	_ = b.AddTax(decimal.NewFromInt(5), tax.PercentualMode, tax.OverTax)
+	_ = b.AddTaxOverBase(decimal.NewFromInt(1), tax.PercentualMode, tax.OverTax, tax.ListPriceBase)
+	_ = b.AddDiscount(decimal.NewFromInt(100), discount.Percentual)
+	_ = b.AddIndexedDiscount(decimal.NewFromInt(1), index.UF, discount.AmountLine)
+
+	err := b.Validate(
+		Line{Value: hundred, Qty: decimal.Zero, MaxDiscount: hundred, From: FromBruteWD},
+		Line{Value: hundred, Qty: decimal.NewFromInt(1), MaxDiscount: hundred, From: FromBrute},
+		Line{Value: hundred, Qty: decimal.NewFromInt(1), MaxDiscount: decimal.NewFromInt(10), From: FromUnitValue},
+	)
+
+	var ve *ValidationError
+
+	if !errors.As(err, &ve) {
+		t.Logf("expected a validation error, got %v", err)
+		t.FailNow()
+	}
+
+	expected := []struct {
+		line int
+		code Code
+	}{
+		{line: ConfigurationLine, code: CodeMissingRateProvider},
+		{line: 0, code: CodeZeroQuantity},
+		{line: 1, code: CodeImpossibleInversion},
+		{line: 2, code: Code(discount.CodeOverMaxDiscount)},
+	}
+
+	if len(ve.Violations) != len(expected) {
+		t.Logf("expected %d violations, got %v", len(expected), ve)
+		t.FailNow()
+	}
+
+	for i, e := range expected {
+		if ve.Violations[i].Line != e.line || ve.Violations[i].Code != e.code {
+			t.Logf("Fail violation[%d] --- expected %v %v --- got %v", i, e.line, e.code, ve.Violations[i])
+			t.FailNow()
+		}
+	}
+}
+
+func TestValidateCalculatesLines(t *testing.T) {
+	b := New()
+
+	_ = b.AddTax(decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable)
+	_ = b.AddDiscount(decimal.NewFromInt(50), discount.AmountLine)
+
+	// the amount discount is over the max discount only for the cheapest line
+	err := b.Validate(
+		Line{Value: decimal.NewFromInt(1000), Qty: decimal.NewFromInt(1), MaxDiscount: decimal.NewFromInt(10)},
+		Line{Value: decimal.NewFromInt(100), Qty: decimal.NewFromInt(1), MaxDiscount: decimal.NewFromInt(10)},
+	)
+
+	var ve *ValidationError
+
+	if !errors.As(err, &ve) || len(ve.Violations) != 1 || ve.Violations[0].Line != 1 || ve.Violations[0].Code != Code(discount.CodeOverMaxDiscount) {
+		t.Logf("expected an over max discount violation in line 1, got %v", err)
+		t.FailNow()
+	}
+}
+
+func TestValidateDuplicateTaxCodes(t *testing.T) {
+	b := New()
+
+	_ = b.AddCodedTax("iva", decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase)
+	_ = b.AddConditionalTax(tax.Rule{Code: "luxury", Value: decimal.NewFromInt(10), When: tax.UnitValueOver(decimal.NewFromInt(1000))})
+	_ = b.AddConditionalTax(tax.Rule{Code: "luxury", Value: decimal.NewFromInt(15), When: tax.UnitValueOver(decimal.NewFromInt(5000))})
+
+	if err := b.Validate(); err != nil {
+		t.Logf("expected the tiers of a conditional tax to share their code, got %v", err)
+		t.FailNow()
+	}
+
+	_ = b.AddCodedTax("iva", decimal.NewFromInt(19), tax.PercentualMode, tax.OverTaxable, tax.DiscountedBase)
+	_ = b.AddConditionalTax(tax.Rule{Code: "iva", Value: decimal.NewFromInt(1), When: tax.QtyOver(decimal.NewFromInt(10))})
+
+	var ve *ValidationError
+
+	if err := b.Validate(); !errors.As(err, &ve) || len(ve.Violations) != 2 {
+		t.Logf("expected two violations, got %v", err)
+		t.FailNow()
+	}
+
+	for i, v := range ve.Violations {
+		if v.Line != ConfigurationLine || v.Code != CodeDuplicateTaxCode || v.Field != "code" {
+			t.Logf("Fail violation[%d] --- expected %v --- got %v", i, CodeDuplicateTaxCode, v)
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package words
+
+var currencies = map[Language]map[string]Currency{
+	Spanish: {
+		"CLP": {Major: Unit{Singular: "peso", Plural: "pesos"}, Minor: Unit{Singular: "centavo", Plural: "centavos"}},
+		"MXN": {Major: Unit{Singular: "peso", Plural: "pesos"}, Minor: Unit{Singular: "centavo", Plural: "centavos"}, Scale: 2, Suffix: "M.N."},
+		"USD": {Major: Unit{Singular: "dólar", Plural: "dólares"}, Minor: Unit{Singular: "centavo", Plural: "centavos"}, Scale: 2},
+		"EUR": {Major: Unit{Singular: "euro", Plural: "euros"}, Minor: Unit{Singular: "céntimo", Plural: "céntimos"}, Scale: 2},
+		"UF":  {Major: Unit{Singular: "unidad de fomento", Plural: "unidades de fomento", Feminine: true}, Minor: Unit{Singular: "centésimo", Plural: "centésimos"}, Scale: 2},
+	},
+	English: {
+		"CLP": {Major: Unit{Singular: "peso", Plural: "pesos"}, Minor: Unit{Singular: "centavo", Plural: "centavos"}},
+		"MXN": {Major: Unit{Singular: "peso", Plural: "pesos"}, Minor: Unit{Singular: "centavo", Plural: "centavos"}, Scale: 2},
+		"USD": {Major: Unit{Singular: "dollar", Plural: "dollars"}, Minor: Unit{Singular: "cent", Plural: "cents"}, Scale: 2},
+		"EUR": {Major: Unit{Singular: "euro", Plural: "euros"}, Minor: Unit{Singular: "cent", Plural: "cents"}, Scale: 2},
+	},
+}
+
+// CurrencyFor returns the wording of the currency with the ISO 4217 code in lang. The scales are the
+// ones of the currency package
+func CurrencyFor(lang Language, code string) (Currency, error) {
+	byCode, ok := currencies[lang]
+
+	if !ok {
+		return Currency{}, ErrInvalidLanguage(lang)
+	}
+
+	c, ok := byCode[code]
+
+	if !ok {
+		return Currency{}, ErrUnknownCurrency(code)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,89 @@
+package words
+
+import "strings"
+
+type english struct{}
+
+var (
+	englishUnits = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+	}
+
+	englishTens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+
+	englishScales = []struct {
+		size uint64
+		name string
+	}{
+		{1_000_000_000_000_000, "quadrillion"},
+		{1_000_000_000_000, "trillion"},
+		{1_000_000_000, "billion"},
+		{1_000_000, "million"},
+		{1_000, "thousand"},
+	}
+)
+
+func (english) minus() string {
+	return "minus"
+}
+
+func (english) and() string {
+	return "and"
+}
+
+func (e english) cardinal(n uint64, _ bool) string {
+	return e.number(n)
+}
+
+func (e english) amount(n uint64, u Unit) string {
+	if n == 1 {
+		return e.number(n) + " " + u.Singular
+	}
+
+	return e.number(n) + " " + u.Plural
+}
+
+// number writes n with the short scale, where a billion is a thousand of millions
+func (e english) number(n uint64) string {
+	if n == 0 {
+		return englishUnits[0]
+	}
+
+	var parts []string
+
+	for _, scale := range englishScales {
+		if count := n / scale.size; count > 0 {
+			parts = append(parts, e.belowThousand(count)+" "+scale.name)
+			n %= scale.size
+		}
+	}
+
+	if n > 0 {
+		parts = append(parts, e.belowThousand(n))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (e english) belowThousand(n uint64) string {
+	hundreds, rest := n/100, n%100
+
+	var parts []string
+
+	if hundreds > 0 {
+		parts = append(parts, englishUnits[hundreds]+" hundred")
+	}
+
+	switch {
+	case rest == 0:
+	case rest < 20:
+		parts = append(parts, englishUnits[rest])
+	case rest%10 == 0:
+		parts = append(parts, englishTens[rest/10])
+	default:
+		parts = append(parts, englishTens[rest/10]+"-"+englishUnits[rest%10])
+	}
+
+	return strings.Join(parts, " ")
+}
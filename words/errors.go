@@ -0,0 +1,18 @@
+package words
+
+import "fmt"
+
+// ErrOutOfRange the amount is too big to be written
+func ErrOutOfRange(info any) error {
+	return fmt.Errorf("[ErrOutOfRange] the amount is too big to be written in words. %v", info)
+}
+
+// ErrInvalidLanguage the language doesnt exists
+func ErrInvalidLanguage(info any) error {
+	return fmt.Errorf("[ErrInvalidLanguage] the specified language doesnt exists. %v", info)
+}
+
+// ErrUnknownCurrency there is no wording for the currency in the language
+func ErrUnknownCurrency(info any) error {
+	return fmt.Errorf("[ErrUnknownCurrency] there is no wording for the currency. %v", info)
+}
@@ -0,0 +1,193 @@
+package words
+
+import "strings"
+
+type spanish struct{}
+
+// gender of the word following a spanish number, which changes its endings in 1 and in hundreds
+type gender uint8
+
+const (
+	// alone is the number without word following, "veintiuno"
+	alone = gender(0)
+
+	// masculine is the number before a masculine word, "veintiún"
+	masculine = gender(1)
+
+	// feminine is the number before a feminine word, "veintiuna"
+	feminine = gender(2)
+)
+
+var (
+	spanishUnits = []string{
+		"cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve",
+		"diez", "once", "doce", "trece", "catorce", "quince", "dieciséis", "diecisiete", "dieciocho", "diecinueve",
+		"veinte", "veintiuno", "veintidós", "veintitrés", "veinticuatro", "veinticinco", "veintiséis", "veintisiete", "veintiocho", "veintinueve",
+	}
+
+	spanishTens = []string{"", "", "", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa"}
+
+	spanishHundreds = []string{"", "ciento", "doscient", "trescient", "cuatrocient", "quinient", "seiscient", "setecient", "ochocient", "novecient"}
+)
+
+func (spanish) minus() string {
+	return "menos"
+}
+
+func (spanish) and() string {
+	return "con"
+}
+
+func (s spanish) cardinal(n uint64, fem bool) string {
+	if fem {
+		return s.number(n, feminine)
+	}
+
+	return s.number(n, alone)
+}
+
+func (s spanish) amount(n uint64, u Unit) string {
+	g := masculine
+
+	if u.Feminine {
+		g = feminine
+	}
+
+	unit := u.Plural
+
+	if n == 1 {
+		unit = u.Singular
+	}
+
+	// exact millions are followed by de, "un millón de pesos"
+	if n >= 1_000_000 && n%1_000_000 == 0 {
+		return s.number(n, g) + " de " + unit
+	}
+
+	return s.number(n, g) + " " + unit
+}
+
+// number writes n with the long scale, where a billón is a million of millions
+func (s spanish) number(n uint64, g gender) string {
+	if n == 0 {
+		return spanishUnits[0]
+	}
+
+	var parts []string
+
+	// millón and billón are masculine
+	for _, scale := range []struct {
+		size     uint64
+		singular string
+		plural   string
+	}{
+		{1_000_000_000_000, "billón", "billones"},
+		{1_000_000, "millón", "millones"},
+	} {
+		count := n / scale.size % 1_000_000
+
+		if scale.size == 1_000_000_000_000 {
+			count = n / scale.size
+		}
+
+		switch {
+		case count == 1:
+			parts = append(parts, "un "+scale.singular)
+		case count > 1:
+			parts = append(parts, s.belowMillion(count, masculine)+" "+scale.plural)
+		}
+	}
+
+	if rest := n % 1_000_000; rest > 0 {
+		parts = append(parts, s.belowMillion(rest, g))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (s spanish) belowMillion(n uint64, g gender) string {
+	thousands, rest := n/1000, n%1000
+
+	var parts []string
+
+	switch {
+	case thousands == 1:
+		parts = append(parts, "mil")
+	case thousands > 1:
+		// before mil the number is shortened even without unit, "veintiún mil"
+		tg := g
+
+		if tg == alone {
+			tg = masculine
+		}
+
+		parts = append(parts, s.belowThousand(thousands, tg)+" mil")
+	}
+
+	if rest > 0 {
+		parts = append(parts, s.belowThousand(rest, g))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (s spanish) belowThousand(n uint64, g gender) string {
+	hundreds, rest := n/100, n%100
+
+	var parts []string
+
+	switch {
+	case n == 100:
+		return "cien"
+	case hundreds == 1:
+		parts = append(parts, spanishHundreds[1])
+	case hundreds > 1:
+		ending := "os"
+
+		if g == feminine {
+			ending = "as"
+		}
+
+		parts = append(parts, spanishHundreds[hundreds]+ending)
+	}
+
+	if rest > 0 {
+		parts = append(parts, s.belowHundred(rest, g))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (s spanish) belowHundred(n uint64, g gender) string {
+	if n < 30 {
+		return one(spanishUnits[n], n%10 == 1 && n != 11, g)
+	}
+
+	tens, units := n/10, n%10
+
+	if units == 0 {
+		return spanishTens[tens]
+	}
+
+	return spanishTens[tens] + " y " + one(spanishUnits[units], units == 1, g)
+}
+
+// one changes the ending of the words finished in uno to agree with g
+func one(word string, isOne bool, g gender) string {
+	if !isOne {
+		return word
+	}
+
+	switch g {
+	case masculine:
+		if word == "veintiuno" {
+			return "veintiún"
+		}
+
+		return strings.TrimSuffix(word, "o")
+	case feminine:
+		return strings.TrimSuffix(word, "o") + "a"
+	}
+
+	return word
+}
@@ -0,0 +1,165 @@
+// Package words writes amounts in words, as printed in invoices, checks and receipts.
+//
+//	words.Amount(decimal.NewFromInt(119000), words.Config{Language: words.Spanish, Currency: clp, Upper: true})
+//	// CIENTO DIECINUEVE MIL PESOS
+package words
+
+import (
+	"strings"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/shopspring/decimal"
+)
+
+// Language in which the amounts are written
+type Language uint8
+
+const (
+	Spanish = Language(0)
+	English = Language(1)
+
+	InvalidLanguage = Language(99)
+)
+
+// Max is the greatest integer part which can be written
+const Max = uint64(999_999_999_999_999_999)
+
+// Unit is the wording of a currency unit
+type Unit struct {
+	Singular string `json:"singular"`
+	Plural   string `json:"plural"`
+
+	// Feminine indicates the gender of the unit, as in spanish it changes the number, "una libra"
+	Feminine bool `json:"feminine,omitempty"`
+}
+
+// Currency is the wording of a currency
+type Currency struct {
+	Major Unit `json:"major"`
+	Minor Unit `json:"minor"`
+
+	// Scale is the number of decimal places of the minor unit, amounts are rounded to it
+	Scale int32 `json:"scale"`
+
+	// Suffix is written at the end, as M.N. in mexican pesos
+	Suffix string `json:"suffix,omitempty"`
+}
+
+// Config indicates how amounts are written
+type Config struct {
+	Language Language `json:"language"`
+	Currency Currency `json:"currency"`
+
+	// Fraction writes the minor unit as a fraction, "50/100", instead of words
+	Fraction bool `json:"fraction,omitempty"`
+
+	// Upper writes in uppercase
+	Upper bool `json:"upper,omitempty"`
+}
+
+// writer writes the integer n followed by unit
+type writer interface {
+	cardinal(n uint64, fem bool) string
+	amount(n uint64, u Unit) string
+	minus() string
+	and() string
+}
+
+func writerOf(lang Language) (writer, error) {
+	switch lang {
+	case Spanish:
+		return spanish{}, nil
+	case English:
+		return english{}, nil
+	}
+
+	return nil, ErrInvalidLanguage(lang)
+}
+
+// Amount writes amount in words with the currency and language of cfg, rounding it to the scale of the currency.
+//
+// In spanish "un", "una" and "uno" agree with the gender of the unit, and the unit follows "de" after
+// exact millions, "un millón de pesos"
+func Amount(amount decimal.Decimal, cfg Config) (string, error) {
+	w, err := writerOf(cfg.Language)
+
+	if err != nil {
+		return "", err
+	}
+
+	amount = amount.Round(cfg.Currency.Scale)
+
+	var sb strings.Builder
+
+	if amount.IsNegative() {
+		sb.WriteString(w.minus())
+		sb.WriteString(" ")
+		amount = amount.Neg()
+	}
+
+	major := amount.Truncate(0)
+
+	if major.GreaterThan(decimal.NewFromInt(int64(Max))) {
+		return "", ErrOutOfRange(amount)
+	}
+
+	sb.WriteString(w.amount(uint64(major.IntPart()), cfg.Currency.Major))
+
+	if cfg.Currency.Scale > 0 {
+		minor := uint64(amount.Sub(major).Shift(cfg.Currency.Scale).IntPart())
+
+		switch {
+		case cfg.Fraction:
+			sb.WriteString(" ")
+			sb.WriteString(fraction(minor, cfg.Currency.Scale))
+		case minor > 0:
+			sb.WriteString(" ")
+			sb.WriteString(w.and())
+			sb.WriteString(" ")
+			sb.WriteString(w.amount(minor, cfg.Currency.Minor))
+		}
+	}
+
+	if cfg.Currency.Suffix != "" {
+		sb.WriteString(" ")
+		sb.WriteString(cfg.Currency.Suffix)
+	}
+
+	if cfg.Upper {
+		return strings.ToUpper(sb.String()), nil
+	}
+
+	return sb.String(), nil
+}
+
+// Total writes the brute with discount of calc, which is the amount to pay
+func Total(calc bolson.Bag, cfg Config) (string, error) {
+	return Amount(calc.WithDiscount.Brute, cfg)
+}
+
+// Cardinal writes n as a number without unit, "uno", "veintiuno"
+func Cardinal(n uint64, lang Language) (string, error) {
+	w, err := writerOf(lang)
+
+	if err != nil {
+		return "", err
+	}
+
+	if n > Max {
+		return "", ErrOutOfRange(n)
+	}
+
+	return w.cardinal(n, false), nil
+}
+
+// fraction writes minor over the size of the minor unit, 5/100
+func fraction(minor uint64, scale int32) string {
+	den := decimal.New(1, scale).String()
+	num := decimal.NewFromInt(int64(minor)).String()
+
+	if pad := len(den) - 1 - len(num); pad > 0 {
+		num = strings.Repeat("0", pad) + num
+	}
+
+	return num + "/" + den
+}
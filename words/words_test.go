@@ -0,0 +1,119 @@
+package words
+
+import (
+	"testing"
+
+	"github.com/profe-ajedrez/bolson"
+	"github.com/shopspring/decimal"
+)
+
+func TestAmountSpanish(t *testing.T) {
+	clp, _ := CurrencyFor(Spanish, "CLP")
+	mxn, _ := CurrencyFor(Spanish, "MXN")
+	usd, _ := CurrencyFor(Spanish, "USD")
+	uf, _ := CurrencyFor(Spanish, "UF")
+
+	testCases := []struct {
+		amount   string
+		cfg      Config
+		expected string
+	}{
+		{"119000", Config{Currency: clp, Upper: true}, "CIENTO DIECINUEVE MIL PESOS"},
+		{"0", Config{Currency: clp}, "cero pesos"},
+		{"1", Config{Currency: clp}, "un peso"},
+		{"21", Config{Currency: clp}, "veintiún pesos"},
+		{"31", Config{Currency: clp}, "treinta y un pesos"},
+		{"100", Config{Currency: clp}, "cien pesos"},
+		{"101", Config{Currency: clp}, "ciento un pesos"},
+		{"1000", Config{Currency: clp}, "mil pesos"},
+		{"21000", Config{Currency: clp}, "veintiún mil pesos"},
+		{"101000", Config{Currency: clp}, "ciento un mil pesos"},
+		{"516", Config{Currency: clp}, "quinientos dieciséis pesos"},
+		{"1000000", Config{Currency: clp}, "un millón de pesos"},
+		{"2000000", Config{Currency: clp}, "dos millones de pesos"},
+		{"1000100", Config{Currency: clp}, "un millón cien pesos"},
+		{"21500000", Config{Currency: clp}, "veintiún millones quinientos mil pesos"},
+		{"1000000000", Config{Currency: clp}, "mil millones de pesos"},
+		{"1000000000000", Config{Currency: clp}, "un billón de pesos"},
+		{"2001001001001", Config{Currency: clp}, "dos billones mil un millones mil un pesos"},
+		{"-5", Config{Currency: clp}, "menos cinco pesos"},
+		{"1234.5", Config{Currency: clp}, "mil doscientos treinta y cinco pesos"},
+		{"1.01", Config{Currency: usd}, "un dólar con un centavo"},
+		{"2.50", Config{Currency: usd}, "dos dólares con cincuenta centavos"},
+		{"119.5", Config{Currency: mxn, Fraction: true, Upper: true}, "CIENTO DIECINUEVE PESOS 50/100 M.N."},
+		{"7", Config{Currency: mxn, Fraction: true}, "siete pesos 00/100 M.N."},
+		{"1", Config{Currency: uf}, "una unidad de fomento"},
+		{"21", Config{Currency: uf}, "veintiuna unidades de fomento"},
+		{"200", Config{Currency: uf}, "doscientas unidades de fomento"},
+		{"221000", Config{Currency: uf}, "doscientas veintiuna mil unidades de fomento"},
+	}
+
+	for i, tc := range testCases {
+		got, err := Amount(decimal.RequireFromString(tc.amount), tc.cfg)
+
+		if err != nil || got != tc.expected {
+			t.Logf("Fail test case[%d] --- expected %q --- got %q %v", i, tc.expected, got, err)
+			t.FailNow()
+		}
+	}
+}
+
+func TestAmountEnglish(t *testing.T) {
+	usd, _ := CurrencyFor(English, "USD")
+
+	testCases := []struct {
+		amount   string
+		cfg      Config
+		expected string
+	}{
+		{"1", Config{Language: English, Currency: usd}, "one dollar"},
+		{"123456.78", Config{Language: English, Currency: usd}, "one hundred twenty-three thousand four hundred fifty-six dollars and seventy-eight cents"},
+		{"1000000.01", Config{Language: English, Currency: usd}, "one million dollars and one cent"},
+		{"90", Config{Language: English, Currency: usd, Fraction: true, Upper: true}, "NINETY DOLLARS 00/100"},
+		{"-0.5", Config{Language: English, Currency: usd}, "minus zero dollars and fifty cents"},
+	}
+
+	for i, tc := range testCases {
+		got, err := Amount(decimal.RequireFromString(tc.amount), tc.cfg)
+
+		if err != nil || got != tc.expected {
+			t.Logf("Fail test case[%d] --- expected %q --- got %q %v", i, tc.expected, got, err)
+			t.FailNow()
+		}
+	}
+}
+
+func TestCardinalAndErrors(t *testing.T) {
+	for n, expected := range map[uint64]string{1: "uno", 21: "veintiuno", 21000: "veintiún mil", 71: "setenta y uno"} {
+		if got, _ := Cardinal(n, Spanish); got != expected {
+			t.Logf("expected %q got %q", expected, got)
+			t.FailNow()
+		}
+	}
+
+	if _, err := Amount(decimal.RequireFromString("1e18"), Config{}); err == nil {
+		t.Log("expected error out of range")
+		t.FailNow()
+	}
+
+	if _, err := Cardinal(1, InvalidLanguage); err == nil {
+		t.Log("expected error for invalid language")
+		t.FailNow()
+	}
+
+	if _, err := CurrencyFor(English, "XYZ"); err == nil {
+		t.Log("expected error for unknown currency")
+		t.FailNow()
+	}
+}
+
+func TestTotal(t *testing.T) {
+	clp, _ := CurrencyFor(Spanish, "CLP")
+
+	got, err := Total(bolson.Bag{WithDiscount: bolson.WithDiscountValues{Brute: decimal.RequireFromString("119000.4")}}, Config{Currency: clp, Upper: true})
+
+	if err != nil || got != "CIENTO DIECINUEVE MIL PESOS" {
+		t.Logf("unexpected %q %v", got, err)
+		t.FailNow()
+	}
+}